@@ -0,0 +1,35 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides a fake clientset of the KNE Topology CRD for testing.
+package fake
+
+import (
+	topologyclientv1 "github.com/openconfig/kne/api/topology/clientset/v1alpha1"
+	topologyv1 "github.com/openconfig/kne/api/topology/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+)
+
+// NewSimpleClientset returns a simple fake clientset with the given objects.
+func NewSimpleClientset(objects ...runtime.Object) (*topologyclientv1.Clientset, error) {
+	cs, err := topologyclientv1.NewForConfig(&rest.Config{})
+	if err != nil {
+		return nil, err
+	}
+	c := dfake.NewSimpleDynamicClient(topologyv1.Scheme, objects...)
+	cs.SetDynamicClient(c.Resource(topologyclientv1.GVR()))
+	return cs, nil
+}
@@ -0,0 +1,138 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/kne/api/topology/clientset/v1alpha1/fake"
+	topologyv1 "github.com/openconfig/kne/api/topology/v1alpha1"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	topoObj = &topologyv1.Topology{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kne.openconfig.net/v1alpha1",
+			Kind:       "Topology",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lab",
+			Namespace: "test",
+		},
+		Spec: topologyv1.TopologySpec{Topology: &tpb.Topology{
+			Name: "lab",
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Vendor: tpb.Vendor_ARISTA,
+				Config: &tpb.Config{Image: "ceos:latest"},
+			}, {
+				Name:   "r2",
+				Vendor: tpb.Vendor_HOST,
+			}},
+			Links: []*tpb.Link{{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1"}},
+		}},
+	}
+	ignoreResourceVersion = cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion")
+)
+
+func TestTopology(t *testing.T) {
+	ctx := context.Background()
+	cs, err := fake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("failed to create fake clientset: %v", err)
+	}
+	tc := cs.Topology("test")
+
+	got, err := tc.Create(ctx, topoObj.DeepCopy(), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if s := cmp.Diff(topoObj, got, protocmp.Transform(), ignoreResourceVersion); s != "" {
+		t.Errorf("Create() unexpected diff (-want +got):\n%s", s)
+	}
+	_, err = tc.Create(ctx, topoObj.DeepCopy(), metav1.CreateOptions{})
+	if s := errdiff.Substring(err, "already exists"); s != "" {
+		t.Errorf("Create() unexpected error: %s", s)
+	}
+
+	got, err = tc.Get(ctx, "lab", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if s := cmp.Diff(topoObj, got, protocmp.Transform(), ignoreResourceVersion); s != "" {
+		t.Errorf("Get() unexpected diff (-want +got):\n%s", s)
+	}
+
+	got.Finalizers = []string{topologyv1.Finalizer}
+	got.Status = topologyv1.TopologyStatus{
+		State: "RUNNING",
+		Nodes: []topologyv1.NodeStatus{{Name: "r1", State: "RUNNING"}},
+	}
+	want := got.DeepCopy()
+	if got, err = tc.Update(ctx, got, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if got, err = tc.UpdateStatus(ctx, got, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("UpdateStatus() unexpected error: %v", err)
+	}
+	if s := cmp.Diff(want, got, protocmp.Transform(), ignoreResourceVersion); s != "" {
+		t.Errorf("UpdateStatus() unexpected diff (-want +got):\n%s", s)
+	}
+
+	list, err := tc.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "lab" {
+		t.Errorf("List() got %+v, want topology lab", list.Items)
+	}
+
+	if err := tc.Delete(ctx, "lab", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	_, err = tc.Get(ctx, "lab", metav1.GetOptions{})
+	if s := errdiff.Substring(err, "not found"); s != "" {
+		t.Errorf("Get() after Delete() unexpected error: %s", s)
+	}
+}
+
+func TestTopologySpecJSON(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		want    *tpb.Topology
+		wantErr string
+	}{{
+		desc: "proto field names",
+		in:   `{"name": "lab", "nodes": [{"name": "r1", "vendor": "ARISTA"}], "links": [{"a_node": "r1", "a_int": "eth1"}]}`,
+		want: &tpb.Topology{
+			Name:  "lab",
+			Nodes: []*tpb.Node{{Name: "r1", Vendor: tpb.Vendor_ARISTA}},
+			Links: []*tpb.Link{{ANode: "r1", AInt: "eth1"}},
+		},
+	}, {
+		desc:    "unknown field",
+		in:      `{"name": "lab", "bad": 1}`,
+		wantErr: "unknown field",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var s topologyv1.TopologySpec
+			err := s.UnmarshalJSON([]byte(tt.in))
+			if d := errdiff.Substring(err, tt.wantErr); d != "" {
+				t.Fatalf("UnmarshalJSON() unexpected error: %s", d)
+			}
+			if err != nil {
+				return
+			}
+			if d := cmp.Diff(tt.want, s.Topology, protocmp.Transform()); d != "" {
+				t.Errorf("UnmarshalJSON() unexpected diff (-want +got):\n%s", d)
+			}
+		})
+	}
+}
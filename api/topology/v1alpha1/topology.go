@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This is required to generate CRD using controller-gen
+// +groupName=kne.openconfig.net
+
+package v1alpha1
+
+import (
+	tpb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//go:generate controller-gen object paths=$GOFILE
+
+// Condition types of a Topology and of its nodes.
+const (
+	// ConditionReady is true once all nodes of the topology, or the node, are
+	// running.
+	ConditionReady = "Ready"
+	// ConditionReconciled is true if the resources of the topology were
+	// last reconciled without error.
+	ConditionReconciled = "Reconciled"
+)
+
+// Topology is a KNE topology created and kept in sync with the cluster by
+// the KNE operator.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Topology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TopologySpec `json:"spec"`
+	// +optional
+	Status TopologyStatus `json:"status,omitempty"`
+}
+
+// TopologySpec is the desired topology. It is encoded in JSON as the
+// topology proto, so the spec of a Topology object is the KNE topology file
+// in YAML. The name of the topology, which is the namespace of its nodes,
+// defaults to the name of the object.
+// +kubebuilder:object:generate=false
+type TopologySpec struct {
+	Topology *tpb.Topology `json:"-"`
+}
+
+// MarshalJSON marshals the topology as protojson.
+func (s TopologySpec) MarshalJSON() ([]byte, error) {
+	if s.Topology == nil {
+		return []byte("{}"), nil
+	}
+	return protojson.Marshal(s.Topology)
+}
+
+// UnmarshalJSON unmarshals the topology from protojson.
+func (s *TopologySpec) UnmarshalJSON(b []byte) error {
+	t := &tpb.Topology{}
+	if err := protojson.Unmarshal(b, t); err != nil {
+		return err
+	}
+	s.Topology = t
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out.
+func (s *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *s
+	if s.Topology != nil {
+		out.Topology = proto.Clone(s.Topology).(*tpb.Topology)
+	}
+}
+
+// DeepCopy copies the receiver into a new TopologySpec.
+func (s *TopologySpec) DeepCopy() *TopologySpec {
+	if s == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	s.DeepCopyInto(out)
+	return out
+}
+
+// TopologyStatus is the observed state of a Topology.
+// +k8s:deepcopy-gen=true
+type TopologyStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// State is the state of the topology, such as RUNNING or ERROR.
+	// +optional
+	State string `json:"state,omitempty"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Nodes is the status of the nodes of the topology, sorted by name.
+	// +optional
+	Nodes []NodeStatus `json:"nodes,omitempty"`
+}
+
+// NodeStatus is the observed state of a node of a Topology.
+// +k8s:deepcopy-gen=true
+type NodeStatus struct {
+	Name string `json:"name"`
+	// State is the state of the node, such as RUNNING or PENDING.
+	State string `json:"state"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Details are vendor specific details of the status of the node.
+	// +optional
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// TopologyList is a list of Topology objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Topology `json:"items"`
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	topologyclientv1 "github.com/openconfig/kne/api/topology/clientset/v1alpha1"
+	topologyv1 "github.com/openconfig/kne/api/topology/v1alpha1"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node/generic"
+	"google.golang.org/protobuf/proto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+)
+
+// newTopologyClient returns a client of the Topology objects of the KNE
+// operator in the cluster of kubecfg.
+func newTopologyClient(kubecfg string) (topologyclientv1.Interface, error) {
+	rCfg, err := clientcmd.BuildConfigFromFlags("", kubecfg)
+	if err != nil {
+		return nil, err
+	}
+	return topologyclientv1.NewForConfig(rCfg)
+}
+
+// readLocalFile reads f, relative to basePath if not absolute.
+func readLocalFile(basePath, f string) ([]byte, error) {
+	if !filepath.IsAbs(f) {
+		f = filepath.Join(basePath, f)
+	}
+	return os.ReadFile(f)
+}
+
+// inlinePodPatches replaces the files of patches, relative to basePath, by
+// their contents.
+func inlinePodPatches(patches []*tpb.PodPatch, basePath string) error {
+	for _, p := range patches {
+		f := p.GetFile()
+		if f == "" {
+			continue
+		}
+		b, err := readLocalFile(basePath, f)
+		if err != nil {
+			return fmt.Errorf("failed to read pod patch: %w", err)
+		}
+		p.Source = &tpb.PodPatch_Patch{Patch: string(b)}
+	}
+	return nil
+}
+
+// inlineConfigs returns a copy of topopb with the local files of its nodes,
+// relative to basePath, replaced by their contents, as the operator cannot
+// read them: the startup configs, the pod patches and the profiles of the
+// generic nodes. Topologies referencing local files which cannot be inlined,
+// such as file mounts and local credentials, Secrets or CA files, are
+// rejected.
+func inlineConfigs(topopb *tpb.Topology, basePath string) (*tpb.Topology, error) {
+	topopb = proto.Clone(topopb).(*tpb.Topology)
+	if ca := topopb.GetCa(); ca.GetCertFile() != "" || ca.GetKeyFile() != "" {
+		return nil, fmt.Errorf("the CA cannot be imported from local files by the operator, store it in the kne-ca Secret of the topology namespace instead")
+	}
+	for _, vp := range topopb.GetVendorPodPatches() {
+		if err := inlinePodPatches(vp.GetPatches(), basePath); err != nil {
+			return nil, fmt.Errorf("vendor %s: %w", vp.GetVendor(), err)
+		}
+	}
+	for _, n := range topopb.GetNodes() {
+		if err := inlineNode(n, basePath); err != nil {
+			return nil, fmt.Errorf("node %q: %w", n.GetName(), err)
+		}
+	}
+	return topopb, nil
+}
+
+func inlineNode(n *tpb.Node, basePath string) error {
+	if len(n.GetConfig().GetFileMounts()) > 0 {
+		return fmt.Errorf("file mounts cannot be read by the operator, use a ConfigMap or Secret instead")
+	}
+	if n.GetCredentials().GetFile() != "" {
+		return fmt.Errorf("credentials file cannot be read by the operator, use a Secret instead")
+	}
+	for _, s := range n.GetConfig().GetSecrets() {
+		if len(s.GetFiles()) > 0 {
+			return fmt.Errorf("secret %q cannot be created from local files by the operator, create it in the topology namespace instead", s.GetName())
+		}
+	}
+	if f := n.GetConfig().GetFile(); f != "" {
+		b, err := readLocalFile(basePath, f)
+		if err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		n.Config.ConfigData = &tpb.Config_Data{Data: b}
+	}
+	if err := inlinePodPatches(n.GetConfig().GetPodPatches(), basePath); err != nil {
+		return err
+	}
+	if n.GetVendor() == tpb.Vendor_GENERIC && n.GetPlugin() == "" {
+		vd, err := generic.InlineProfile(basePath, n)
+		if err != nil {
+			return err
+		}
+		n.Config.VendorData = vd
+	}
+	return nil
+}
+
+// submitTopology creates or updates the Topology object of topopb in
+// namespace for the KNE operator to create the topology.
+func submitTopology(ctx context.Context, client topologyclientv1.Interface, namespace string, topopb *tpb.Topology, basePath string) error {
+	topopb, err := inlineConfigs(topopb, basePath)
+	if err != nil {
+		return err
+	}
+	tc := client.Topology(namespace)
+	t, err := tc.Get(ctx, topopb.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		t = &topologyv1.Topology{
+			ObjectMeta: metav1.ObjectMeta{Name: topopb.GetName(), Namespace: namespace},
+			Spec:       topologyv1.TopologySpec{Topology: topopb},
+		}
+		if _, err := tc.Create(ctx, t, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create topology object %q: %w", topopb.GetName(), err)
+		}
+		log.Infof("Submitted topology %q to the operator", topopb.GetName())
+	case err != nil:
+		return fmt.Errorf("failed to get topology object %q: %w", topopb.GetName(), err)
+	default:
+		t.Spec.Topology = topopb
+		if _, err := tc.Update(ctx, t, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update topology object %q: %w", topopb.GetName(), err)
+		}
+		log.Infof("Updated topology %q of the operator", topopb.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/kne/api/topology/clientset/v1alpha1/fake"
+	gpb "github.com/openconfig/kne/proto/generic"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSubmitTopology(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "r1.cfg"), []byte("hostname r1"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	topopb := &tpb.Topology{
+		Name: "lab",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor_ARISTA,
+			Config: &tpb.Config{ConfigData: &tpb.Config_File{File: "r1.cfg"}},
+		}, {
+			Name:   "r2",
+			Vendor: tpb.Vendor_HOST,
+		}},
+	}
+	client, err := fake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("failed to create fake clientset: %v", err)
+	}
+
+	if err := submitTopology(ctx, client, "default", topopb, dir); err != nil {
+		t.Fatalf("submitTopology() unexpected error: %v", err)
+	}
+	got, err := client.Topology("default").Get(ctx, "lab", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get topology: %v", err)
+	}
+	want := &tpb.Topology{
+		Name: "lab",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor_ARISTA,
+			Config: &tpb.Config{ConfigData: &tpb.Config_Data{Data: []byte("hostname r1")}},
+		}, {
+			Name:   "r2",
+			Vendor: tpb.Vendor_HOST,
+		}},
+	}
+	if s := cmp.Diff(want, got.Spec.Topology, protocmp.Transform()); s != "" {
+		t.Errorf("submitTopology() unexpected spec (-want +got):\n%s", s)
+	}
+	if topopb.Nodes[0].GetConfig().GetFile() != "r1.cfg" {
+		t.Errorf("submitTopology() modified topology: %v", topopb)
+	}
+
+	topopb.Nodes = topopb.Nodes[1:]
+	if err := submitTopology(ctx, client, "default", topopb, dir); err != nil {
+		t.Fatalf("submitTopology() of existing topology unexpected error: %v", err)
+	}
+	got, err = client.Topology("default").Get(ctx, "lab", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get topology: %v", err)
+	}
+	if s := cmp.Diff(topopb, got.Spec.Topology, protocmp.Transform()); s != "" {
+		t.Errorf("submitTopology() unexpected updated spec (-want +got):\n%s", s)
+	}
+
+	topopb.Nodes = append(topopb.Nodes, &tpb.Node{
+		Name:   "r3",
+		Config: &tpb.Config{ConfigData: &tpb.Config_File{File: "missing.cfg"}},
+	})
+	err = submitTopology(ctx, client, "default", topopb, dir)
+	if s := errdiff.Substring(err, `node "r3": failed to read config`); s != "" {
+		t.Errorf("submitTopology() unexpected error: %s", s)
+	}
+}
+
+func TestInlineConfigs(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"patch.yaml":   "metadata:\n  labels:\n    team: lab\n",
+		"profile.yaml": "image: linux:latest\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	profileFile, err := anypb.New(&gpb.GenericConfig{ProfileFile: "profile.yaml"})
+	if err != nil {
+		t.Fatalf("failed to marshal vendor data: %v", err)
+	}
+	profile, err := anypb.New(&gpb.GenericConfig{Profile: &gpb.Profile{Image: "linux:latest"}})
+	if err != nil {
+		t.Fatalf("failed to marshal vendor data: %v", err)
+	}
+
+	tests := []struct {
+		desc    string
+		topo    *tpb.Topology
+		want    *tpb.Topology
+		wantErr string
+	}{{
+		desc: "pod patches and profile",
+		topo: &tpb.Topology{
+			Name: "lab",
+			VendorPodPatches: []*tpb.VendorPodPatches{{
+				Vendor:  tpb.Vendor_ARISTA,
+				Patches: []*tpb.PodPatch{{Source: &tpb.PodPatch_File{File: "patch.yaml"}}},
+			}},
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Vendor: tpb.Vendor_GENERIC,
+				Config: &tpb.Config{
+					VendorData: profileFile,
+					PodPatches: []*tpb.PodPatch{{Source: &tpb.PodPatch_File{File: "patch.yaml"}}},
+				},
+			}},
+		},
+		want: &tpb.Topology{
+			Name: "lab",
+			VendorPodPatches: []*tpb.VendorPodPatches{{
+				Vendor:  tpb.Vendor_ARISTA,
+				Patches: []*tpb.PodPatch{{Source: &tpb.PodPatch_Patch{Patch: "metadata:\n  labels:\n    team: lab\n"}}},
+			}},
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Vendor: tpb.Vendor_GENERIC,
+				Config: &tpb.Config{
+					VendorData: profile,
+					PodPatches: []*tpb.PodPatch{{Source: &tpb.PodPatch_Patch{Patch: "metadata:\n  labels:\n    team: lab\n"}}},
+				},
+			}},
+		},
+	}, {
+		desc: "missing pod patch",
+		topo: &tpb.Topology{
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Config: &tpb.Config{PodPatches: []*tpb.PodPatch{{Source: &tpb.PodPatch_File{File: "missing.yaml"}}}},
+			}},
+		},
+		wantErr: `node "r1": failed to read pod patch`,
+	}, {
+		desc: "file mounts",
+		topo: &tpb.Topology{
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Config: &tpb.Config{FileMounts: []*tpb.FileMount{{Source: "certs", Destination: "/certs"}}},
+			}},
+		},
+		wantErr: `node "r1": file mounts cannot be read by the operator`,
+	}, {
+		desc: "credentials file",
+		topo: &tpb.Topology{
+			Nodes: []*tpb.Node{{
+				Name:        "r1",
+				Credentials: &tpb.Credentials{Source: &tpb.Credentials_File{File: "creds.yaml"}},
+			}},
+		},
+		wantErr: `node "r1": credentials file cannot be read by the operator`,
+	}, {
+		desc: "secret files",
+		topo: &tpb.Topology{
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Config: &tpb.Config{Secrets: []*tpb.SecretRef{{Name: "license", Files: map[string]string{"key": "license.key"}}}},
+			}},
+		},
+		wantErr: `secret "license" cannot be created from local files`,
+	}, {
+		desc: "ca files",
+		topo: &tpb.Topology{
+			Ca: &tpb.CertificateAuthority{CertFile: "ca.pem", KeyFile: "ca.key"},
+		},
+		wantErr: "the CA cannot be imported from local files",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := inlineConfigs(tt.topo, dir)
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("inlineConfigs() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("inlineConfigs() unexpected topology (-want +got):\n%s", s)
+			}
+		})
+	}
+}
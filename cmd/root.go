@@ -16,13 +16,19 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/kr/pretty"
 	"github.com/openconfig/kne/cmd/deploy"
 	"github.com/openconfig/kne/cmd/internal"
 	"github.com/openconfig/kne/cmd/topology"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
 	"github.com/openconfig/kne/topo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -69,6 +75,32 @@ environment.`,
 	return root
 }
 
+// printNodeStatuses prints the state of each node with the reason it is in
+// that state, followed by its details.
+func printNodeStatuses(w io.Writer, statuses []*cpb.NodeStatus) {
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%s: %s", s.GetName(), strings.TrimPrefix(s.GetState().String(), "NODE_STATE_"))
+		if s.GetReason() != "" {
+			fmt.Fprintf(w, " (%s)", s.GetReason())
+		}
+		if s.GetMessage() != "" {
+			fmt.Fprintf(w, ": %s", s.GetMessage())
+		}
+		fmt.Fprintln(w)
+		if t := s.GetLastTransitionTime(); t != nil {
+			fmt.Fprintf(w, "  last transition: %s\n", t.AsTime().Format(time.RFC3339))
+		}
+		keys := make([]string, 0, len(s.GetDetails()))
+		for k := range s.GetDetails() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "  %s: %s\n", k, s.GetDetails()[k])
+		}
+	}
+}
+
 func defaultCfgFile() string {
 	if home := homedir.HomeDir(); home != "" {
 		return filepath.Join(home, ".config", "kne", "config.yaml")
@@ -96,6 +128,8 @@ func newCreateCmd() *cobra.Command {
 	}
 	cmd.Flags().Bool("dryrun", false, "Generate topology but do not push to k8s")
 	cmd.Flags().Duration("timeout", 0, "Timeout for pod status enquiry")
+	cmd.Flags().Bool("operator", false, "Submit the topology to the KNE operator instead of creating it")
+	cmd.Flags().String("operator_namespace", "default", "Namespace of the topology object submitted to the KNE operator")
 	return cmd
 }
 
@@ -108,6 +142,7 @@ func newDeleteCmd() *cobra.Command {
 		ValidArgs: []string{"topology"},
 	}
 	cmd.Flags().Bool("skip_wait", false, "Skips waiting for resource deletion")
+	cmd.Flags().String("volume_policy", "", "Overrides the policy of the persistent volumes of all nodes (retain or delete)")
 	return cmd
 }
 
@@ -146,6 +181,16 @@ func createFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
+	if viper.GetBool("operator") && !viper.GetBool("dryrun") {
+		client, err := newTopologyClient(viper.GetString("kubecfg"))
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		if err := submitTopology(cmd.Context(), client, viper.GetString("operator_namespace"), topopb, bp); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		return nil
+	}
 	opts := []topo.Option{
 		topo.WithKubecfg(viper.GetString("kubecfg")),
 		topo.WithBasePath(bp),
@@ -161,6 +206,9 @@ func createFn(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
 	if viper.GetBool("dryrun") {
+		if err := tm.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
 		return nil
 	}
 	return tm.Create(cmd.Context(), viper.GetDuration("timeout"))
@@ -171,7 +219,18 @@ func deleteFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
-	tm, err := topo.New(topopb, topo.WithKubecfg(viper.GetString("kubecfg")), topo.WithSkipDeleteWait(viper.GetBool("skip_wait")))
+	tOpts := []topo.Option{
+		topo.WithKubecfg(viper.GetString("kubecfg")),
+		topo.WithSkipDeleteWait(viper.GetBool("skip_wait")),
+	}
+	if p := viper.GetString("volume_policy"); p != "" {
+		v, ok := tpb.PersistentVolume_Policy_value[strings.ToUpper(p)]
+		if !ok {
+			return fmt.Errorf("%s: invalid volume policy %q, want retain or delete", cmd.Use, p)
+		}
+		tOpts = append(tOpts, topo.WithVolumePolicy(tpb.PersistentVolume_Policy(v)))
+	}
+	tm, err := topo.New(topopb, tOpts...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
@@ -192,6 +251,8 @@ func showFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	fmt.Fprintf(out, "Nodes:\n")
+	printNodeStatuses(out, r.GetNodeStatuses())
 	fmt.Fprintf(out, "Pods:\n")
 	for k, pods := range r.Pods {
 		fmt.Fprintf(out, "Pod %s:\n", k)
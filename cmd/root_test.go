@@ -2,7 +2,13 @@ package cmd
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/kne/proto/controller"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestGetKubeCfg(t *testing.T) {
@@ -55,3 +61,31 @@ func TestGetKubeCfg(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintNodeStatuses(t *testing.T) {
+	statuses := []*cpb.NodeStatus{{
+		Name:  "r1",
+		State: cpb.NodeState_NODE_STATE_RUNNING,
+	}, {
+		Name:               "r2",
+		State:              cpb.NodeState_NODE_STATE_PENDING,
+		Reason:             "CrashLoopBackOff",
+		Message:            "container r2",
+		LastTransitionTime: timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Details: map[string]string{
+			"r2.restarts":         "3",
+			"r2.last_termination": "Error (exit code 1)",
+		},
+	}}
+	want := `r1: RUNNING
+r2: PENDING (CrashLoopBackOff): container r2
+  last transition: 2026-01-02T03:04:05Z
+  r2.last_termination: Error (exit code 1)
+  r2.restarts: 3
+`
+	var b strings.Builder
+	printNodeStatuses(&b, statuses)
+	if s := cmp.Diff(want, b.String()); s != "" {
+		t.Errorf("printNodeStatuses() unexpected diff (-want +got):\n%s", s)
+	}
+}
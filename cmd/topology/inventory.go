@@ -0,0 +1,300 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topology
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// inventoryHost is a node of the topology in the inventory.
+type inventoryHost struct {
+	Name    string            `json:"name"`
+	Vendor  string            `json:"vendor"`
+	Model   string            `json:"model,omitempty"`
+	OS      string            `json:"os,omitempty"`
+	Version string            `json:"version,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	// Address is the external IP of the services of the node.
+	Address string `json:"address,omitempty"`
+	// Services are the external ports of the services of the node by name.
+	Services map[string]uint32 `json:"services,omitempty"`
+	// TLSCA is the CA bundle file verifying the certs of the node, only set
+	// for nodes with CA issued certs.
+	TLSCA string `json:"tls_ca,omitempty"`
+}
+
+// inventoryCredentials are references to the credentials of the hosts, the
+// credentials themselves are never written to the inventory.
+type inventoryCredentials struct {
+	UsernameEnv string `json:"username_env"`
+	PasswordEnv string `json:"password_env"`
+}
+
+// inventory is the automation inventory of a topology.
+type inventory struct {
+	Topology    string                `json:"topology"`
+	Credentials *inventoryCredentials `json:"credentials"`
+	Hosts       []*inventoryHost      `json:"hosts"`
+	// Groups are the names of the hosts by group.
+	Groups map[string][]string `json:"groups"`
+}
+
+var invalidGroupChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// groupName returns a group name valid in all inventory formats.
+func groupName(parts ...string) string {
+	return invalidGroupChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_")
+}
+
+// newInventory returns the inventory of the nodes of t. The address of a node
+// is the external IP of its ssh service, or of its first service with an
+// external IP. Nodes are grouped by vendor and by label. The certs of nodes
+// with CA issued certs are verified with the caBundle file.
+func newInventory(t *tpb.Topology, creds *inventoryCredentials, caBundle string) *inventory {
+	inv := &inventory{
+		Topology:    t.GetName(),
+		Credentials: creds,
+		Groups:      map[string][]string{},
+	}
+	for _, n := range t.GetNodes() {
+		h := &inventoryHost{
+			Name:    n.GetName(),
+			Vendor:  n.GetVendor().String(),
+			Model:   n.GetModel(),
+			OS:      n.GetOs(),
+			Version: n.GetVersion(),
+			Labels:  n.GetLabels(),
+		}
+		if n.GetConfig().GetCert().GetCaIssued() != nil {
+			h.TLSCA = caBundle
+		}
+		var ports []uint32
+		for k := range n.GetServices() {
+			ports = append(ports, k)
+		}
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+		for _, k := range ports {
+			s := n.GetServices()[k]
+			if h.Services == nil {
+				h.Services = map[string]uint32{}
+			}
+			for _, name := range append([]string{s.GetName()}, s.GetNames()...) {
+				if name != "" {
+					h.Services[name] = k
+				}
+			}
+			if ip := s.GetOutsideIp(); ip != "" && (h.Address == "" || s.GetName() == "ssh") {
+				h.Address = ip
+			}
+		}
+		inv.Hosts = append(inv.Hosts, h)
+		vg := groupName("vendor", h.Vendor)
+		inv.Groups[vg] = append(inv.Groups[vg], h.Name)
+		for k, v := range h.Labels {
+			lg := groupName(k, v)
+			inv.Groups[lg] = append(inv.Groups[lg], h.Name)
+		}
+	}
+	sort.Slice(inv.Hosts, func(i, j int) bool { return inv.Hosts[i].Name < inv.Hosts[j].Name })
+	for _, hosts := range inv.Groups {
+		sort.Strings(hosts)
+	}
+	return inv
+}
+
+func writeAnsible(w io.Writer, inv *inventory) error {
+	hosts := map[string]any{}
+	for _, h := range inv.Hosts {
+		vars := map[string]any{
+			"kne_vendor": h.Vendor,
+		}
+		if h.Address != "" {
+			vars["ansible_host"] = h.Address
+		}
+		if p, ok := h.Services["ssh"]; ok {
+			vars["ansible_port"] = p
+		}
+		for k, v := range map[string]string{"kne_model": h.Model, "kne_os": h.OS, "kne_version": h.Version} {
+			if v != "" {
+				vars[k] = v
+			}
+		}
+		if len(h.Labels) > 0 {
+			vars["kne_labels"] = h.Labels
+		}
+		if len(h.Services) > 0 {
+			vars["kne_services"] = h.Services
+		}
+		hosts[h.Name] = vars
+	}
+	children := map[string]any{}
+	for g, names := range inv.Groups {
+		gh := map[string]any{}
+		for _, name := range names {
+			gh[name] = map[string]any{}
+		}
+		children[g] = map[string]any{"hosts": gh}
+	}
+	b, err := yaml.Marshal(map[string]any{
+		"all": map[string]any{
+			"vars": map[string]any{
+				"kne_topology":     inv.Topology,
+				"ansible_user":     fmt.Sprintf("{{ lookup('env', '%s') }}", inv.Credentials.UsernameEnv),
+				"ansible_password": fmt.Sprintf("{{ lookup('env', '%s') }}", inv.Credentials.PasswordEnv),
+			},
+			"hosts":    hosts,
+			"children": children,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// writeSSHConfig writes the hosts with an ssh service. ssh_config cannot
+// reference environment variables for the user, so the user is resolved when
+// the inventory is written and the password is never written.
+func writeSSHConfig(w io.Writer, inv *inventory) error {
+	// The first write error is kept by bw and returned by Flush.
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Topology %s\n", inv.Topology)
+	user := os.Getenv(inv.Credentials.UsernameEnv)
+	for _, h := range inv.Hosts {
+		p, ok := h.Services["ssh"]
+		if !ok || h.Address == "" {
+			continue
+		}
+		fmt.Fprintf(bw, "\nHost %s\n  HostName %s\n  Port %d\n", h.Name, h.Address, p)
+		if user != "" {
+			fmt.Fprintf(bw, "  User %s\n", user)
+		}
+		fmt.Fprint(bw, "  StrictHostKeyChecking no\n  UserKnownHostsFile /dev/null\n")
+	}
+	return bw.Flush()
+}
+
+// writeGNMIc writes a gnmic config with the hosts with a gnmi service as
+// targets. gnmic expands environment variables in its config file. The certs
+// of targets with CA issued certs are verified with the topology CA, those of
+// the other targets are self-signed and not verified.
+func writeGNMIc(w io.Writer, inv *inventory) error {
+	targets := map[string]any{}
+	for _, h := range inv.Hosts {
+		p, ok := h.Services["gnmi"]
+		if !ok || h.Address == "" {
+			continue
+		}
+		tags := []string{"vendor=" + h.Vendor}
+		for k, v := range h.Labels {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags[1:])
+		target := map[string]any{
+			"name":        h.Name,
+			"tags":        tags,
+			"skip-verify": h.TLSCA == "",
+		}
+		if h.TLSCA != "" {
+			target["tls-ca"] = h.TLSCA
+		}
+		targets[fmt.Sprintf("%s:%d", h.Address, p)] = target
+	}
+	b, err := yaml.Marshal(map[string]any{
+		"username": fmt.Sprintf("${%s}", inv.Credentials.UsernameEnv),
+		"password": fmt.Sprintf("${%s}", inv.Credentials.PasswordEnv),
+		"targets":  targets,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func writeInventory(w io.Writer, format string, inv *inventory) error {
+	switch format {
+	case "ansible":
+		return writeAnsible(w, inv)
+	case "ssh-config":
+		return writeSSHConfig(w, inv)
+	case "gnmic":
+		return writeGNMIc(w, inv)
+	case "json":
+		return writeJSON(w, inv)
+	default:
+		return fmt.Errorf("invalid format %q, must be ansible, ssh-config, gnmic or json", format)
+	}
+}
+
+func inventoryFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%s: missing topology", cmd.Use)
+	}
+	format := viper.GetString("format")
+	switch format {
+	case "ansible", "ssh-config", "gnmic", "json":
+	default:
+		return fmt.Errorf("%s: invalid format %q, must be ansible, ssh-config, gnmic or json", cmd.Use, format)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := newTopologyManager(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	ts, err := tm.Show(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	inv := newInventory(ts.GetTopology(), &inventoryCredentials{
+		UsernameEnv: viper.GetString("username_env"),
+		PasswordEnv: viper.GetString("password_env"),
+	}, tm.CABundleFile())
+	out := viper.GetString("output")
+	if out == "" {
+		if err := writeInventory(cmd.OutOrStdout(), format, inv); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		return nil
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := writeInventory(f, format, inv); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return nil
+}
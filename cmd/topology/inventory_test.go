@@ -0,0 +1,247 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestInventory(t *testing.T) {
+	shown := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r2",
+			Vendor: tpb.Vendor_NOKIA,
+			Model:  "ixrd2",
+			Labels: map[string]string{"role": "spine"},
+			Config: &tpb.Config{
+				Cert: &tpb.CertificateCfg{
+					Config: &tpb.CertificateCfg_CaIssued{CaIssued: &tpb.CAIssuedCertCfg{}},
+				},
+			},
+			Services: map[uint32]*tpb.Service{
+				22:   {Name: "ssh", Inside: 22, Outside: 22, OutsideIp: "192.168.18.101"},
+				9339: {Name: "gnmi", Names: []string{"gnoi"}, Inside: 57400, Outside: 9339, OutsideIp: "192.168.18.101"},
+			},
+		}, {
+			Name:    "r1",
+			Vendor:  tpb.Vendor_ARISTA,
+			Model:   "ceos",
+			Os:      "eos",
+			Version: "4.30",
+			Labels:  map[string]string{"role": "leaf"},
+			Services: map[uint32]*tpb.Service{
+				22:   {Name: "ssh", Inside: 22, Outside: 22, OutsideIp: "192.168.18.100"},
+				6030: {Name: "gnmi", Inside: 6030, Outside: 6030, OutsideIp: "192.168.18.100"},
+			},
+		}, {
+			Name:   "otg",
+			Vendor: tpb.Vendor_KEYSIGHT,
+		}},
+	}
+	tests := []struct {
+		desc        string
+		args        []string
+		user        string
+		topoManager *fakeTopologyManager
+		want        string
+		wantErr     string
+	}{{
+		desc:    "no args",
+		args:    []string{"inventory"},
+		wantErr: "missing topology",
+	}, {
+		desc:    "invalid format",
+		args:    []string{"inventory", "testdata/valid_topo.pb.txt", "--format", "csv"},
+		wantErr: `invalid format "csv"`,
+	}, {
+		desc:        "fail to show topology",
+		args:        []string{"inventory", "testdata/valid_topo.pb.txt"},
+		topoManager: &fakeTopologyManager{showErr: fmt.Errorf("some error")},
+		wantErr:     "some error",
+	}, {
+		desc:        "ansible",
+		args:        []string{"inventory", "testdata/valid_topo.pb.txt"},
+		topoManager: &fakeTopologyManager{topo: shown},
+		want: `all:
+  children:
+    role_leaf:
+      hosts:
+        r1: {}
+    role_spine:
+      hosts:
+        r2: {}
+    vendor_arista:
+      hosts:
+        r1: {}
+    vendor_keysight:
+      hosts:
+        otg: {}
+    vendor_nokia:
+      hosts:
+        r2: {}
+  hosts:
+    otg:
+      kne_vendor: KEYSIGHT
+    r1:
+      ansible_host: 192.168.18.100
+      ansible_port: 22
+      kne_labels:
+        role: leaf
+      kne_model: ceos
+      kne_os: eos
+      kne_services:
+        gnmi: 6030
+        ssh: 22
+      kne_vendor: ARISTA
+      kne_version: "4.30"
+    r2:
+      ansible_host: 192.168.18.101
+      ansible_port: 22
+      kne_labels:
+        role: spine
+      kne_model: ixrd2
+      kne_services:
+        gnmi: 9339
+        gnoi: 9339
+        ssh: 22
+      kne_vendor: NOKIA
+  vars:
+    ansible_password: '{{ lookup(''env'', ''KNE_PASSWORD'') }}'
+    ansible_user: '{{ lookup(''env'', ''KNE_USERNAME'') }}'
+    kne_topology: test
+`,
+	}, {
+		desc:        "ssh-config",
+		args:        []string{"inventory", "testdata/valid_topo.pb.txt", "--format", "ssh-config", "--username_env", "LAB_USER"},
+		user:        "admin",
+		topoManager: &fakeTopologyManager{topo: shown},
+		want: `# Topology test
+
+Host r1
+  HostName 192.168.18.100
+  Port 22
+  User admin
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+
+Host r2
+  HostName 192.168.18.101
+  Port 22
+  User admin
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+`,
+	}, {
+		desc:        "gnmic",
+		args:        []string{"inventory", "testdata/valid_topo.pb.txt", "--format", "gnmic", "--password_env", "LAB_PASSWORD"},
+		topoManager: &fakeTopologyManager{topo: shown},
+		want: `password: ${LAB_PASSWORD}
+targets:
+  192.168.18.100:6030:
+    name: r1
+    skip-verify: true
+    tags:
+    - vendor=ARISTA
+    - role=leaf
+  192.168.18.101:9339:
+    name: r2
+    skip-verify: false
+    tags:
+    - vendor=NOKIA
+    - role=spine
+    tls-ca: /tmp/test-ca.pem
+username: ${KNE_USERNAME}
+`,
+	}, {
+		desc:        "json",
+		args:        []string{"inventory", "testdata/valid_topo.pb.txt", "--format", "json"},
+		topoManager: &fakeTopologyManager{topo: &tpb.Topology{Name: "test", Nodes: shown.Nodes[2:]}},
+		want: `{
+  "topology": "test",
+  "credentials": {
+    "username_env": "KNE_USERNAME",
+    "password_env": "KNE_PASSWORD"
+  },
+  "hosts": [
+    {
+      "name": "otg",
+      "vendor": "KEYSIGHT"
+    }
+  ],
+  "groups": {
+    "vendor_keysight": [
+      "otg"
+    ]
+  }
+}
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			origNewTopologyManager := newTopologyManager
+			newTopologyManager = func(_ *tpb.Topology, _ ...topo.Option) (TopologyManager, error) {
+				return tt.topoManager, nil
+			}
+			defer func() {
+				newTopologyManager = origNewTopologyManager
+			}()
+			t.Setenv("LAB_USER", tt.user)
+			iCmd := New()
+			iCmd.PersistentFlags().String("kubecfg", "", "")
+			iCmd.SilenceUsage = true
+			iCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			buf := bytes.NewBuffer([]byte{})
+			iCmd.SetOut(buf)
+			iCmd.SetArgs(tt.args)
+			err := iCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("inventoryCmd failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, buf.String()); s != "" {
+				t.Errorf("inventoryCmd unexpected output (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("write error")
+}
+
+func TestWriteInventoryError(t *testing.T) {
+	inv := newInventory(&tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor_ARISTA,
+			Services: map[uint32]*tpb.Service{
+				22:   {Name: "ssh", Inside: 22, Outside: 22, OutsideIp: "192.168.18.100"},
+				6030: {Name: "gnmi", Inside: 6030, Outside: 6030, OutsideIp: "192.168.18.100"},
+			},
+		}},
+	}, &inventoryCredentials{UsernameEnv: "KNE_USERNAME", PasswordEnv: "KNE_PASSWORD"}, "")
+	for _, format := range []string{"ansible", "ssh-config", "gnmic", "json"} {
+		t.Run(format, func(t *testing.T) {
+			if s := errdiff.Check(writeInventory(errWriter{}, format, inv), "write error"); s != "" {
+				t.Errorf("writeInventory() failed: %s", s)
+			}
+		})
+	}
+}
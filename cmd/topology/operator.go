@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topology
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	log "k8s.io/klog/v2"
+)
+
+// loadManager returns the topology manager of the topology file.
+func loadManager(p string) (*topo.Manager, error) {
+	topopb, err := topo.Load(p)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := fileRelative(p)
+	if err != nil {
+		return nil, err
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")), topo.WithBasePath(bp))
+	return topo.New(topopb, tOpts...)
+}
+
+func rebootFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	tm, err := loadManager(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := tm.Reboot(cmd.Context(), args[1], &node.RebootOptions{
+		Warm:    viper.GetBool("warm"),
+		Delay:   viper.GetDuration("delay"),
+		Message: viper.GetString("message"),
+		Force:   viper.GetBool("force"),
+	}); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	log.Infof("Rebooting %q", args[1])
+	return nil
+}
+
+func filePutFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 4 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	perm, err := strconv.ParseUint(viper.GetString("mode"), 8, 32)
+	if err != nil || perm > 0777 {
+		return fmt.Errorf("%s: invalid mode %q", cmd.Use, viper.GetString("mode"))
+	}
+	tm, err := loadManager(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	f, err := os.Open(args[2])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	defer f.Close()
+	if err := tm.PutFile(cmd.Context(), args[1], args[3], f, os.FileMode(perm)); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	log.Infof("Wrote %q to %s:%s", args[2], args[1], args[3])
+	return nil
+}
+
+func fileGetFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 && len(args) != 4 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	tm, err := loadManager(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	var w io.Writer = cmd.OutOrStdout()
+	if len(args) == 4 {
+		f, err := os.Create(args[3])
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := tm.GetFile(cmd.Context(), args[1], args[2], w); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return nil
+}
+
+func pingFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	tm, err := loadManager(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	res, err := tm.Ping(cmd.Context(), args[1], &node.PingOptions{
+		Destination:     args[2],
+		Source:          viper.GetString("source"),
+		Count:           viper.GetInt32("count"),
+		Interval:        viper.GetDuration("interval"),
+		Size:            viper.GetInt32("size"),
+		NetworkInstance: viper.GetString("network_instance"),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	w := cmd.OutOrStdout()
+	for _, r := range res.Replies {
+		fmt.Fprintf(w, "%d bytes from %s: icmp_seq=%d ttl=%d time=%v\n", r.Bytes, r.Source, r.Sequence, r.TTL, r.Time)
+	}
+	fmt.Fprintf(w, "%d packets transmitted, %d received", res.Sent, res.Received)
+	if res.Received > 0 {
+		fmt.Fprintf(w, ", min/avg/max/stddev = %v/%v/%v/%v", res.MinTime, res.AvgTime, res.MaxTime, res.StdDev)
+	}
+	fmt.Fprintln(w)
+	if res.Received == 0 {
+		return fmt.Errorf("%s: no replies from %s", cmd.Use, args[2])
+	}
+	return nil
+}
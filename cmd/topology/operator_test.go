@@ -0,0 +1,158 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type operator struct {
+	*node.Impl
+	files map[string]string
+}
+
+func (o *operator) Operations() []node.Operation {
+	return node.AllOperations
+}
+
+func (o *operator) Reboot(context.Context, *node.RebootOptions) error {
+	return nil
+}
+
+func (o *operator) PutFile(_ context.Context, remote string, r io.Reader, _ os.FileMode) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	o.files[remote] = string(b)
+	return nil
+}
+
+func (o *operator) GetFile(_ context.Context, remote string, w io.Writer) error {
+	_, err := io.WriteString(w, o.files[remote])
+	return err
+}
+
+func (o *operator) Ping(_ context.Context, opts *node.PingOptions) (*node.PingResult, error) {
+	res := &node.PingResult{Sent: opts.Count}
+	if opts.Destination == "10.0.0.2" {
+		res.Received = opts.Count
+		for i := int32(1); i <= opts.Count; i++ {
+			res.Replies = append(res.Replies, &node.PingReply{Source: opts.Destination, Sequence: i, Bytes: 64, TTL: 64, Time: 1000})
+		}
+	}
+	return res, nil
+}
+
+func TestOperatorCommands(t *testing.T) {
+	files := map[string]string{}
+	node.Vendor(tpb.Vendor(1013), func(impl *node.Impl) (node.Node, error) {
+		return &operator{Impl: impl, files: files}, nil
+	})
+	node.Vendor(tpb.Vendor(1014), NewNC)
+	tOp := &tpb.Topology{
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1013),
+		}, {
+			Name:   "notoperator",
+			Vendor: tpb.Vendor(1014),
+		}},
+	}
+	fOp, closer := writeTopology(t, tOp)
+	defer closer()
+	local := filepath.Join(t.TempDir(), "local.txt")
+	if err := os.WriteFile(local, []byte("hello"), 0644); err != nil {
+		t.Fatalf("failed to write local file: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		args    []string
+		want    string
+		wantErr string
+	}{{
+		desc:    "reboot no args",
+		args:    []string{"reboot", fOp.Name()},
+		wantErr: "invalid args",
+	}, {
+		desc: "reboot",
+		args: []string{"reboot", fOp.Name(), "r1", "--warm", "--delay", "5s"},
+	}, {
+		desc:    "reboot not supported",
+		args:    []string{"reboot", fOp.Name(), "notoperator"},
+		wantErr: `node "notoperator" does not support reboot`,
+	}, {
+		desc:    "file put invalid mode",
+		args:    []string{"file", "put", fOp.Name(), "r1", local, "/tmp/remote.txt", "--mode", "999"},
+		wantErr: `invalid mode "999"`,
+	}, {
+		desc:    "file put no local file",
+		args:    []string{"file", "put", fOp.Name(), "r1", "missing.txt", "/tmp/remote.txt"},
+		wantErr: "no such file",
+	}, {
+		desc: "file put",
+		args: []string{"file", "put", fOp.Name(), "r1", local, "/tmp/remote.txt"},
+	}, {
+		desc: "file get",
+		args: []string{"file", "get", fOp.Name(), "r1", "/tmp/remote.txt"},
+		want: "hello",
+	}, {
+		desc: "ping",
+		args: []string{"ping", fOp.Name(), "r1", "10.0.0.2", "--count", "2"},
+		want: "64 bytes from 10.0.0.2: icmp_seq=1 ttl=64 time=1µs\n" +
+			"64 bytes from 10.0.0.2: icmp_seq=2 ttl=64 time=1µs\n" +
+			"2 packets transmitted, 2 received, min/avg/max/stddev = 0s/0s/0s/0s\n",
+	}, {
+		desc:    "ping no replies",
+		args:    []string{"ping", fOp.Name(), "r1", "10.0.0.3", "--count", "2"},
+		want:    "2 packets transmitted, 0 received\n",
+		wantErr: "no replies from 10.0.0.3",
+	}}
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cmd := New()
+			cmd.PersistentFlags().String("kubecfg", "", "")
+			cmd.SilenceUsage = true
+			cmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			buf := bytes.NewBuffer([]byte{})
+			cmd.SetOut(buf)
+			cmd.SetArgs(tt.args)
+			err := cmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("%v failed: %s", tt.args, s)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("%v got output %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
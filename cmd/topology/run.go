@@ -0,0 +1,266 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+// runResult is the collected output of the commands run on a single node.
+type runResult struct {
+	Node     string           `json:"node"`
+	Vendor   string           `json:"vendor"`
+	Error    string           `json:"error,omitempty"`
+	Commands []*commandOutput `json:"commands,omitempty"`
+}
+
+// commandOutput is the output of a single command run on a node.
+type commandOutput struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+	Error   string `json:"error,omitempty"`
+}
+
+// failed returns true if the node could not be reached or any of the
+// commands failed.
+func (r *runResult) failed() bool {
+	if r.Error != "" {
+		return true
+	}
+	for _, c := range r.Commands {
+		if c.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// selectNodes returns the sorted names of the nodes matching any of the
+// selectors. If no selectors are provided all nodes are returned.
+func selectNodes(nodes map[string]node.Node, selectors []string) ([]string, error) {
+	var names []string
+	for name, n := range nodes {
+		ok, err := matchNode(name, n.GetProto(), selectors)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func matchNode(name string, pb *tpb.Node, selectors []string) (bool, error) {
+	if len(selectors) == 0 {
+		return true, nil
+	}
+	for _, s := range selectors {
+		switch {
+		case strings.HasPrefix(s, "vendor="):
+			v := strings.TrimPrefix(s, "vendor=")
+			if _, ok := tpb.Vendor_value[strings.ToUpper(v)]; !ok {
+				return false, fmt.Errorf("invalid vendor %q in selector %q", v, s)
+			}
+			if strings.EqualFold(pb.GetVendor().String(), v) {
+				return true, nil
+			}
+		case strings.HasPrefix(s, "label:"):
+			k, v, ok := strings.Cut(strings.TrimPrefix(s, "label:"), "=")
+			if !ok {
+				return false, fmt.Errorf("invalid label selector %q, want label:key=value", s)
+			}
+			if lv, ok := pb.GetLabels()[k]; ok && lv == v {
+				return true, nil
+			}
+		default:
+			ok, err := path.Match(s, name)
+			if err != nil {
+				return false, fmt.Errorf("invalid name selector %q: %w", s, err)
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// runCommands runs cmds concurrently on all the named nodes. Failures are
+// recorded in the result for the node rather than returned.
+func runCommands(ctx context.Context, tm *topo.Manager, names []string, cmds []string) []*runResult {
+	results := make([]*runResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		results[i] = &runResult{
+			Node:   name,
+			Vendor: tm.Nodes()[name].GetProto().GetVendor().String(),
+		}
+		wg.Add(1)
+		go func(r *runResult) {
+			defer wg.Done()
+			crs, err := tm.RunCommands(ctx, r.Node, cmds)
+			if err != nil {
+				log.Warningf("Failed to run commands on %q: %v", r.Node, err)
+				r.Error = status.Convert(err).Message()
+				return
+			}
+			for _, cr := range crs {
+				co := &commandOutput{
+					Command: cr.Command,
+					Output:  cr.Output,
+				}
+				if cr.Err != nil {
+					co.Error = cr.Err.Error()
+				}
+				r.Commands = append(r.Commands, co)
+			}
+		}(results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+func writeText(w io.Writer, results []*runResult) error {
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "==== %s (%s) ====\n", r.Node, r.Vendor); err != nil {
+			return err
+		}
+		if r.Error != "" {
+			if _, err := fmt.Fprintf(w, "error: %s\n", r.Error); err != nil {
+				return err
+			}
+		}
+		for _, c := range r.Commands {
+			if _, err := fmt.Fprintf(w, "> %s\n%s\n", c.Command, c.Output); err != nil {
+				return err
+			}
+			if c.Error != "" {
+				if _, err := fmt.Fprintf(w, "error: %s\n", c.Error); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(v)
+}
+
+func writeResults(w io.Writer, format string, results []*runResult) error {
+	switch format {
+	case "json":
+		return writeJSON(w, results)
+	default:
+		return writeText(w, results)
+	}
+}
+
+func runFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%s: missing topology", cmd.Use)
+	}
+	cmds := viper.GetStringSlice("cmd")
+	if len(cmds) == 0 {
+		return fmt.Errorf("%s: at least one --cmd must be provided", cmd.Use)
+	}
+	format := viper.GetString("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("%s: invalid format %q, want text or json", cmd.Use, format)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	names, err := selectNodes(tm.Nodes(), viper.GetStringSlice("nodes"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("%s: no devices match %q", cmd.Use, viper.GetStringSlice("nodes"))
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), viper.GetDuration("timeout"))
+	defer cancel()
+	log.Infof("Running %d command(s) on %d device(s)", len(cmds), len(names))
+	results := runCommands(ctx, tm, names, cmds)
+
+	if dir := viper.GetString("output_dir"); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		ext := ".txt"
+		if format == "json" {
+			ext = ".json"
+		}
+		for _, r := range results {
+			f, err := os.Create(filepath.Join(dir, r.Node+ext))
+			if err != nil {
+				return fmt.Errorf("%s: %w", cmd.Use, err)
+			}
+			var werr error
+			if format == "json" {
+				werr = writeJSON(f, r)
+			} else {
+				werr = writeText(f, []*runResult{r})
+			}
+			if err := f.Close(); werr == nil {
+				werr = err
+			}
+			if werr != nil {
+				return fmt.Errorf("%s: %w", cmd.Use, werr)
+			}
+			log.Infof("Wrote output of %q to %q", r.Node, f.Name())
+		}
+	} else if err := writeResults(cmd.OutOrStdout(), format, results); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+
+	var failed []string
+	for _, r := range results {
+		if r.failed() {
+			failed = append(failed, r.Node)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s: commands failed on %d of %d devices: %s", cmd.Use, len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
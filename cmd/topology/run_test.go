@@ -0,0 +1,262 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type commander struct {
+	*node.Impl
+	err error
+}
+
+func (c *commander) RunCommands(_ context.Context, cmds []string) ([]*node.CommandResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	var results []*node.CommandResult
+	for _, cmd := range cmds {
+		cr := &node.CommandResult{
+			Command: cmd,
+			Output:  fmt.Sprintf("%s output of %s", c.Name(), cmd),
+		}
+		if cmd == "bad" {
+			cr.Err = fmt.Errorf("invalid input")
+		}
+		results = append(results, cr)
+	}
+	return results, nil
+}
+
+func NewCommander(impl *node.Impl) (node.Node, error) {
+	return &commander{Impl: impl}, nil
+}
+
+func NewUnreachable(impl *node.Impl) (node.Node, error) {
+	return &commander{Impl: impl, err: fmt.Errorf("unreachable")}, nil
+}
+
+func TestSelectNodes(t *testing.T) {
+	nodes := map[string]node.Node{
+		"r1": &notConfigable{Impl: &node.Impl{Proto: &tpb.Node{
+			Name:   "r1",
+			Vendor: tpb.Vendor_ARISTA,
+			Labels: map[string]string{"role": "spine"},
+		}}},
+		"r2": &notConfigable{Impl: &node.Impl{Proto: &tpb.Node{
+			Name:   "r2",
+			Vendor: tpb.Vendor_NOKIA,
+			Labels: map[string]string{"role": "leaf"},
+		}}},
+		"otg": &notConfigable{Impl: &node.Impl{Proto: &tpb.Node{
+			Name:   "otg",
+			Vendor: tpb.Vendor_KEYSIGHT,
+		}}},
+	}
+	tests := []struct {
+		desc      string
+		selectors []string
+		want      []string
+		wantErr   string
+	}{{
+		desc: "all",
+		want: []string{"otg", "r1", "r2"},
+	}, {
+		desc:      "glob",
+		selectors: []string{"r*"},
+		want:      []string{"r1", "r2"},
+	}, {
+		desc:      "vendor",
+		selectors: []string{"vendor=nokia"},
+		want:      []string{"r2"},
+	}, {
+		desc:      "label",
+		selectors: []string{"label:role=spine"},
+		want:      []string{"r1"},
+	}, {
+		desc:      "multiple",
+		selectors: []string{"otg", "label:role=leaf"},
+		want:      []string{"otg", "r2"},
+	}, {
+		desc:      "no match",
+		selectors: []string{"foo"},
+	}, {
+		desc:      "invalid vendor",
+		selectors: []string{"vendor=foo"},
+		wantErr:   "invalid vendor",
+	}, {
+		desc:      "invalid label",
+		selectors: []string{"label:role"},
+		wantErr:   "invalid label selector",
+	}, {
+		desc:      "invalid glob",
+		selectors: []string{"r["},
+		wantErr:   "invalid name selector",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := selectNodes(nodes, tt.selectors)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("selectNodes() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("selectNodes() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tRun := &tpb.Topology{
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1008),
+		}, {
+			Name:   "r2",
+			Vendor: tpb.Vendor(1008),
+		}, {
+			Name:   "unreachable",
+			Vendor: tpb.Vendor(1009),
+		}, {
+			Name:   "notcommander",
+			Vendor: tpb.Vendor(1010),
+		}},
+	}
+	fRun, closer := writeTopology(t, tRun)
+	defer closer()
+	node.Vendor(tpb.Vendor(1008), NewCommander)
+	node.Vendor(tpb.Vendor(1009), NewUnreachable)
+	node.Vendor(tpb.Vendor(1010), NewNC)
+	outDir := t.TempDir()
+	tests := []struct {
+		desc      string
+		args      []string
+		want      string
+		wantFiles map[string]string
+		wantErr   string
+	}{{
+		desc:    "no args",
+		args:    []string{"run"},
+		wantErr: "missing topology",
+	}, {
+		desc:    "no commands",
+		args:    []string{"run", fRun.Name()},
+		wantErr: "at least one --cmd",
+	}, {
+		desc:    "invalid format",
+		args:    []string{"run", fRun.Name(), "--cmd", "show version", "--format", "xml"},
+		wantErr: "invalid format",
+	}, {
+		desc:    "no match",
+		args:    []string{"run", fRun.Name(), "--cmd", "show version", "--nodes", "foo"},
+		wantErr: "no devices match",
+	}, {
+		desc: "text",
+		args: []string{"run", fRun.Name(), "--cmd", "show version", "--cmd", "show ip, route", "--nodes", "r*"},
+		want: "==== r1 (1008) ====\n" +
+			"> show version\nr1 output of show version\n" +
+			"> show ip, route\nr1 output of show ip, route\n" +
+			"==== r2 (1008) ====\n" +
+			"> show version\nr2 output of show version\n" +
+			"> show ip, route\nr2 output of show ip, route\n",
+	}, {
+		desc: "command error",
+		args: []string{"run", fRun.Name(), "--cmd", "bad", "--nodes", "r1"},
+		want: "==== r1 (1008) ====\n" +
+			"> bad\nr1 output of bad\n" +
+			"error: invalid input\n",
+		wantErr: "commands failed on 1 of 1 devices: r1",
+	}, {
+		desc: "unreachable and not commander",
+		args: []string{"run", fRun.Name(), "--cmd", "show version", "--nodes", "r1,unreachable,notcommander"},
+		want: "==== notcommander (1010) ====\n" +
+			"error: node \"notcommander\" does not implement Commander interface\n" +
+			"==== r1 (1008) ====\n" +
+			"> show version\nr1 output of show version\n" +
+			"==== unreachable (1009) ====\n" +
+			"error: unreachable\n",
+		wantErr: "commands failed on 2 of 3 devices: notcommander, unreachable",
+	}, {
+		desc: "json",
+		args: []string{"run", fRun.Name(), "--cmd", "show version", "--nodes", "r1", "--format", "json"},
+		want: `[
+  {
+    "node": "r1",
+    "vendor": "1008",
+    "commands": [
+      {
+        "command": "show version",
+        "output": "r1 output of show version"
+      }
+    ]
+  }
+]
+`,
+	}, {
+		desc: "output dir",
+		args: []string{"run", fRun.Name(), "--cmd", "show version", "--nodes", "r*", "--output_dir", outDir},
+		wantFiles: map[string]string{
+			"r1.txt": "==== r1 (1008) ====\n> show version\nr1 output of show version\n",
+			"r2.txt": "==== r2 (1008) ====\n> show version\nr2 output of show version\n",
+		},
+	}}
+
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rCmd := New()
+			rCmd.PersistentFlags().String("kubecfg", "", "")
+			rCmd.SilenceUsage = true
+			rCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			buf := bytes.NewBuffer([]byte{})
+			rCmd.SetOut(buf)
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("runFn failed: %s", s)
+			}
+			if s := cmp.Diff(tt.want, buf.String()); s != "" {
+				t.Errorf("runFn unexpected output (-want +got):\n%s", s)
+			}
+			for name, want := range tt.wantFiles {
+				b, err := os.ReadFile(filepath.Join(outDir, name))
+				if err != nil {
+					t.Fatalf("failed to read output file: %v", err)
+				}
+				if s := cmp.Diff(want, string(b)); s != "" {
+					t.Errorf("runFn unexpected output in %q (-want +got):\n%s", name, s)
+				}
+			}
+		})
+	}
+}
@@ -17,10 +17,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/openconfig/gnmi/errlist"
 	cpb "github.com/openconfig/kne/proto/controller"
@@ -29,9 +35,11 @@ import (
 	"github.com/openconfig/kne/topo/node"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
+	"k8s.io/client-go/rest"
 	log "k8s.io/klog/v2"
 )
 
@@ -51,11 +59,13 @@ func New() *cobra.Command {
 		Short: "service returns the current topology with service endpoints defined.",
 		RunE:  serviceFn,
 	}
+	serviceCmd.Flags().Bool("credentials", false, "include the resolved credentials of the devices, including passwords read from Secrets and files")
 	certCmd := &cobra.Command{
 		Use:   "cert <topology> <device>",
 		Short: "push or generate certs for nodes in topology",
 		RunE:  certFn,
 	}
+	certCmd.Flags().String("gnoi_cert_id", "", "if set, issue a cert from the topology CA and install it on the device with gNOI under this id")
 	resetCfgCmd := &cobra.Command{
 		Use:   "reset <topology> <device>",
 		Short: "reset configuration of device to vendor default (if device not provide reset all nodes)",
@@ -63,6 +73,140 @@ func New() *cobra.Command {
 	}
 	resetCfgCmd.Flags().Bool("skip", false, "skip nodes if they are not resetable")
 	resetCfgCmd.Flags().Bool("push", false, "additionally push orginal topology configuration")
+	execCmd := &cobra.Command{
+		Use:   "exec <topology> <device> -- <command> [args...]",
+		Short: "execute a command in the container of a device",
+		RunE:  execFn,
+	}
+	execCmd.Flags().BoolP("stdin", "i", false, "pass stdin to the command")
+	cliCmd := &cobra.Command{
+		Use:   "cli <topology> <device>",
+		Short: "open an interactive session to the vendor CLI of a device",
+		RunE:  cliFn,
+	}
+	runCmd := &cobra.Command{
+		Use:   "run <topology>",
+		Short: "run CLI commands on selected devices and collect the output",
+		Long: `run executes the provided commands on the vendor CLI of each selected
+device concurrently and collects the output into a combined report or into
+one file per device.
+
+Devices are selected with --nodes which takes a comma separated list of
+selectors. A device is selected if it matches any selector:
+
+  r*               glob matched against the device name
+  vendor=ARISTA    devices of the given vendor
+  label:key=value  devices with the given label`,
+		RunE: runFn,
+	}
+	runCmd.Flags().StringSlice("nodes", nil, "selectors for the devices to run commands on (default all devices)")
+	runCmd.Flags().StringArray("cmd", nil, "command to run, can be repeated")
+	runCmd.Flags().String("format", "text", "output format (text or json)")
+	runCmd.Flags().String("output_dir", "", "if set, write the output of each device to a separate file in this directory")
+	runCmd.Flags().Duration("timeout", 5*time.Minute, "time to wait for commands to complete on each device")
+	bundleCmd := &cobra.Command{
+		Use:   "support-bundle <topology>",
+		Short: "write a tarball with the logs and cluster state of a topology for debugging",
+		RunE:  bundleFn,
+	}
+	bundleCmd.Flags().StringP("output", "o", "", "path of the tarball to write (default <topology name>-support-<timestamp>.tar.gz)")
+	bundleCmd.Flags().Bool("redact", false, "redact secrets, config maps, environment variables, node configs, passwords and vendor resource specs")
+	captureCmd := &cobra.Command{
+		Use:   "capture <topology> <device>:<interface>",
+		Short: "capture packets on a link of a device in pcapng format",
+		Long: `capture streams the packets sent and received on the link connected to
+the interface of a device. The capture runs in the meshnet daemon on the
+cluster node of the device so the device container is not modified.
+
+The output is written in pcapng format to stdout or to the file provided
+with --write, so it can be piped directly into wireshark:
+
+  kne topology capture topo.pb.txt r1:eth1 | wireshark -k -i -`,
+		RunE: captureFn,
+	}
+	captureCmd.Flags().StringP("write", "w", "", "write the capture to this file instead of stdout")
+	captureCmd.Flags().String("filter", "", "BPF filter applied to the capture")
+	renderCfgCmd := &cobra.Command{
+		Use:   "render-config <topology> [<device>]",
+		Short: "render the startup config templates of devices without creating the topology",
+		Long: `render-config prints the startup config of a device, or of all devices with
+a startup config if no device is provided, as it would be used when the
+topology is created. Configs with config_template set are rendered as Go
+templates with the device and the topology.`,
+		RunE: renderCfgFn,
+	}
+	renderCfgCmd.Flags().String("output_dir", "", "if set, write the config of each device to a separate file in this directory")
+	forwardCmd := &cobra.Command{
+		Use:   "forward <topology> [<device>...]",
+		Short: "forward local ports to the services of devices",
+		Long: `forward opens local port-forwards to the TCP services of the provided
+devices, or of all devices if none are provided, until interrupted. This
+allows to reach the devices when the load balancer of the cluster is not
+reachable from the host.
+
+Local ports are assigned sequentially from --base_port to the services of all
+devices, sorted by device name and service port, so the local port of a
+service does not change between runs. The forwards of a device are
+reestablished when its pod restarts.`,
+		RunE: forwardFn,
+	}
+	forwardCmd.Flags().Uint32("base_port", 20000, "first local port to forward")
+	inventoryCmd := &cobra.Command{
+		Use:   "inventory <topology>",
+		Short: "write an automation inventory of the devices of a topology",
+		Long: `inventory writes the devices of a topology with their external addresses,
+service ports, vendor, model, OS and labels in one of the formats:
+
+  ansible     Ansible YAML inventory, with groups by vendor and label
+  ssh-config  OpenSSH client config for the devices with an ssh service
+  gnmic       gnmic config with the devices with a gnmi service as targets
+  json        the inventory as JSON
+
+Credentials are never written to the inventory. The ansible and gnmic formats
+reference the environment variables provided with --username_env and
+--password_env, which are read by the tools when the inventory is used.`,
+		RunE: inventoryFn,
+	}
+	inventoryCmd.Flags().String("format", "ansible", "output format (ansible, ssh-config, gnmic or json)")
+	inventoryCmd.Flags().StringP("output", "o", "", "if set, write the inventory to this file instead of stdout")
+	inventoryCmd.Flags().String("username_env", "KNE_USERNAME", "environment variable referenced for the username of the devices")
+	inventoryCmd.Flags().String("password_env", "KNE_PASSWORD", "environment variable referenced for the password of the devices")
+	rebootCmd := &cobra.Command{
+		Use:   "reboot <topology> <device>",
+		Short: "reboot a device with gNOI",
+		RunE:  rebootFn,
+	}
+	rebootCmd.Flags().Bool("warm", false, "reboot only the software of the device")
+	rebootCmd.Flags().Duration("delay", 0, "time to wait before rebooting")
+	rebootCmd.Flags().String("message", "", "reason of the reboot")
+	rebootCmd.Flags().Bool("force", false, "reboot even if validation checks on the device fail")
+	fileCmd := &cobra.Command{
+		Use:   "file",
+		Short: "transfer files to and from devices with gNOI",
+	}
+	filePutCmd := &cobra.Command{
+		Use:   "put <topology> <device> <local file> <remote file>",
+		Short: "copy a local file to a device",
+		RunE:  filePutFn,
+	}
+	filePutCmd.Flags().String("mode", "644", "octal permissions of the remote file")
+	fileGetCmd := &cobra.Command{
+		Use:   "get <topology> <device> <remote file> [<local file>]",
+		Short: "copy a file from a device to a local file or stdout",
+		RunE:  fileGetFn,
+	}
+	fileCmd.AddCommand(filePutCmd)
+	fileCmd.AddCommand(fileGetCmd)
+	pingCmd := &cobra.Command{
+		Use:   "ping <topology> <device> <destination>",
+		Short: "ping a destination from a device with gNOI",
+		RunE:  pingFn,
+	}
+	pingCmd.Flags().Int32("count", 5, "number of packets to send")
+	pingCmd.Flags().String("source", "", "source address of the packets")
+	pingCmd.Flags().Duration("interval", 0, "time between packets (default device specific)")
+	pingCmd.Flags().Int32("size", 0, "size of the packets (default device specific)")
+	pingCmd.Flags().String("network_instance", "", "network instance to ping in")
 	generateCmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generates a topology of a given type.",
@@ -85,6 +229,17 @@ func New() *cobra.Command {
 	topoCmd.AddCommand(watchCmd)
 	topoCmd.AddCommand(resetCfgCmd)
 	topoCmd.AddCommand(generateCmd)
+	topoCmd.AddCommand(execCmd)
+	topoCmd.AddCommand(cliCmd)
+	topoCmd.AddCommand(runCmd)
+	topoCmd.AddCommand(bundleCmd)
+	topoCmd.AddCommand(captureCmd)
+	topoCmd.AddCommand(renderCfgCmd)
+	topoCmd.AddCommand(forwardCmd)
+	topoCmd.AddCommand(inventoryCmd)
+	topoCmd.AddCommand(rebootCmd)
+	topoCmd.AddCommand(fileCmd)
+	topoCmd.AddCommand(pingCmd)
 	return topoCmd
 }
 
@@ -108,7 +263,11 @@ func resetCfgFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
-	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	bp, err := fileRelative(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to find relative path for topology: %v", err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")), topo.WithBasePath(bp))
 	tm, err := topo.New(topopb, tOpts...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
@@ -136,35 +295,19 @@ func resetCfgFn(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	log.Infof("Trying to repush devices configs: %q", args[0])
-	bp, err := fileRelative(args[0])
-	if err != nil {
-		return fmt.Errorf("failed to find relative path for topology: %v", err)
-	}
 	var errList errlist.List
-	for name, node := range nodes {
-		cd := node.GetProto().GetConfig().GetConfigData()
-		if cd == nil {
-			log.Infof("Skipping node %q no config provided", name)
+	for name := range nodes {
+		b, err := tm.RenderConfig(name)
+		if err != nil {
+			errList.Add(err)
 			continue
 		}
-		var b []byte
-		switch v := cd.(type) {
-		case *tpb.Config_Data:
-			b = v.Data
-		case *tpb.Config_File:
-			cPath := v.File
-			if !filepath.IsAbs(cPath) {
-				cPath = filepath.Join(bp, cPath)
-			}
-			log.Infof("Pushing configuration %q to %q", cPath, name)
-			var err error
-			b, err = os.ReadFile(cPath)
-			if err != nil {
-				errList.Add(err)
-				continue
-			}
+		if b == nil {
+			log.Infof("Skipping node %q no config provided", name)
+			continue
 		}
-		err := tm.ConfigPush(cmd.Context(), name, bytes.NewBuffer(b))
+		log.Infof("Pushing configuration to %q", name)
+		err = tm.ConfigPush(cmd.Context(), name, bytes.NewBuffer(b))
 		switch {
 		default:
 			errList.Add(err)
@@ -178,6 +321,102 @@ func resetCfgFn(cmd *cobra.Command, args []string) error {
 	return errList.Err()
 }
 
+func forwardFn(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	forwards, err := tm.PortForwards(args[1:], viper.GetUint32("base_port"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ready := func() {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DEVICE\tSERVICE\tPORT\tENDPOINT")
+		for _, f := range forwards {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", f.Node, f.Service, f.Port, f.Endpoint())
+		}
+		w.Flush()
+	}
+	if err := tm.Forward(ctx, forwards, ready); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return nil
+}
+
+func renderCfgFn(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	bp, err := fileRelative(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	// Rendering does not need access to the cluster.
+	tOpts := append([]topo.Option{topo.WithClusterConfig(&rest.Config{})}, opts...)
+	tOpts = append(tOpts, topo.WithBasePath(bp))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	var names []string
+	if len(args) > 1 {
+		names = []string{args[1]}
+	} else {
+		for name := range tm.Nodes() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	dir := viper.GetString("output_dir")
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+	}
+	for _, name := range names {
+		b, err := tm.RenderConfig(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		if b == nil {
+			if len(args) > 1 {
+				return fmt.Errorf("%s: node %q has no config", cmd.Use, name)
+			}
+			continue
+		}
+		if dir != "" {
+			p := filepath.Join(dir, name+".cfg")
+			if err := os.WriteFile(p, b, 0644); err != nil {
+				return fmt.Errorf("%s: %w", cmd.Use, err)
+			}
+			log.Infof("Wrote config of %q to %q", name, p)
+			continue
+		}
+		if len(args) == 1 {
+			fmt.Fprintf(cmd.OutOrStdout(), "### %s\n", name)
+		}
+		if _, err := cmd.OutOrStdout().Write(b); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+	}
+	return nil
+}
+
 func generateRingFn(cmd *cobra.Command, args []string) error {
 	if len(args) != 3 {
 		return fmt.Errorf("%s: invalid args", cmd.Use)
@@ -306,15 +545,151 @@ func certFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
+	if id := viper.GetString("gnoi_cert_id"); id != "" {
+		return tm.InstallCertificate(cmd.Context(), args[1], id)
+	}
 	return tm.GenerateSelfSigned(cmd.Context(), args[1])
 }
 
+func execFn(cmd *cobra.Command, args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	var stdin io.Reader
+	if viper.GetBool("stdin") {
+		stdin = cmd.InOrStdin()
+		restore, err := makeRaw(stdin)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		defer restore()
+	}
+	return tm.Exec(cmd.Context(), args[1], args[2:], stdin, cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+func cliFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	restore, err := makeRaw(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	defer restore()
+	return tm.CLI(cmd.Context(), args[1], cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// makeRaw puts r into raw mode if it is a terminal so that keystrokes are
+// passed through to the remote TTY unmodified. The returned func restores
+// the previous terminal state.
+func makeRaw(r io.Reader) (func(), error) {
+	f, ok := r.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return func() {}, nil
+	}
+	state, err := term.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := term.Restore(int(f.Fd()), state); err != nil {
+			log.Warningf("failed to restore terminal state: %v", err)
+		}
+	}, nil
+}
+
+func bundleFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%s: missing topology", cmd.Use)
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	out := viper.GetString("output")
+	if out == "" {
+		out = fmt.Sprintf("%s-support-%s.tar.gz", topopb.GetName(), time.Now().Format("20060102-150405"))
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := tm.SupportBundle(cmd.Context(), f, viper.GetBool("redact")); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	log.Infof("Wrote support bundle to %q", out)
+	return nil
+}
+
+func captureFn(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%s: invalid args", cmd.Use)
+	}
+	node, intf, ok := strings.Cut(args[1], ":")
+	if !ok || node == "" || intf == "" {
+		return fmt.Errorf("%s: invalid interface %q, must be <device>:<interface>", cmd.Use, args[1])
+	}
+	topopb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	tOpts := append(opts, topo.WithKubecfg(viper.GetString("kubecfg")))
+	tm, err := topo.New(topopb, tOpts...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	w := cmd.OutOrStdout()
+	if out := viper.GetString("write"); out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := tm.Capture(ctx, node, intf, viper.GetString("filter"), w); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return nil
+}
+
 var newTopologyManager = func(topopb *tpb.Topology, opts ...topo.Option) (TopologyManager, error) {
 	return topo.New(topopb, opts...)
 }
 
 type TopologyManager interface {
 	Show(ctx context.Context) (*cpb.ShowTopologyResponse, error)
+	Credentials(ctx context.Context) map[string]*tpb.Credentials
+	CABundleFile() string
 }
 
 func serviceFn(cmd *cobra.Command, args []string) error {
@@ -334,6 +709,14 @@ func serviceFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if viper.GetBool("credentials") {
+		creds := tm.Credentials(cmd.Context())
+		for _, n := range ts.GetTopology().GetNodes() {
+			if c, ok := creds[n.GetName()]; ok {
+				n.Credentials = c
+			}
+		}
+	}
 
 	fmt.Fprintln(cmd.OutOrStdout(), prototext.Format(ts.Topology))
 	return nil
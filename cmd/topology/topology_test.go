@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -20,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	kfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -65,6 +67,31 @@ func NewR(impl *node.Impl) (node.Node, error) {
 	return &resettable{&notResettable{&notConfigable{Impl: impl}}}, nil
 }
 
+type execable struct {
+	*node.Impl
+}
+
+func (e *execable) Exec(_ context.Context, cmd []string, _ io.Reader, stdout, _ io.Writer) error {
+	_, err := fmt.Fprint(stdout, strings.Join(cmd, " "))
+	return err
+}
+
+func NewE(impl *node.Impl) (node.Node, error) {
+	return &execable{Impl: impl}, nil
+}
+
+type clier struct {
+	*execable
+}
+
+func (c *clier) CLICommand() []string {
+	return []string{"cli"}
+}
+
+func NewCLI(impl *node.Impl) (node.Node, error) {
+	return &clier{&execable{Impl: impl}}, nil
+}
+
 func writeTopology(t *testing.T, topo *tpb.Topology) (*os.File, func()) {
 	t.Helper()
 	f, err := os.CreateTemp("", "reset")
@@ -315,6 +342,7 @@ links: {
 
 type fakeTopologyManager struct {
 	topo    *tpb.Topology
+	creds   map[string]*tpb.Credentials
 	showErr error
 }
 
@@ -324,10 +352,18 @@ func (f *fakeTopologyManager) Show(_ context.Context) (*cpb.ShowTopologyResponse
 	}
 	return &cpb.ShowTopologyResponse{
 		State:    cpb.TopologyState_TOPOLOGY_STATE_RUNNING,
-		Topology: f.topo,
+		Topology: proto.Clone(f.topo).(*tpb.Topology),
 	}, nil
 }
 
+func (f *fakeTopologyManager) Credentials(_ context.Context) map[string]*tpb.Credentials {
+	return f.creds
+}
+
+func (f *fakeTopologyManager) CABundleFile() string {
+	return "/tmp/" + f.topo.GetName() + "-ca.pem"
+}
+
 func TestGenerateRing(t *testing.T) {
 	tests := []struct {
 		desc       string
@@ -505,6 +541,9 @@ func TestService(t *testing.T) {
 	if err := prototext.Unmarshal([]byte(validPbTxt), validProto); err != nil {
 		t.Fatalf("failed to build a valid Topology protobuf for testing: %v", err)
 	}
+	creds := map[string]*tpb.Credentials{"r1": {Username: "admin", Password: "secret"}}
+	credsProto := proto.Clone(validProto).(*tpb.Topology)
+	credsProto.Nodes[0].Credentials = creds["r1"]
 	tests := []struct {
 		desc        string
 		args        []string
@@ -523,14 +562,23 @@ func TestService(t *testing.T) {
 			args:        []string{"service", "testdata/valid_topo.pb.txt"},
 		}, {
 			desc:        "valid case",
-			topoManager: &fakeTopologyManager{topo: validProto},
+			topoManager: &fakeTopologyManager{topo: validProto, creds: creds},
 			want:        validProto,
 			args:        []string{"service", "testdata/valid_topo.pb.txt"},
+		}, {
+			desc:        "with credentials",
+			topoManager: &fakeTopologyManager{topo: validProto, creds: creds},
+			want:        credsProto,
+			args:        []string{"service", "testdata/valid_topo.pb.txt", "--credentials"},
 		},
 	}
 
 	sCmd := New()
 	sCmd.PersistentFlags().String("kubecfg", "", "")
+	sCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		viper.BindPFlags(cmd.Flags())
+		return nil
+	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			origNewTopologyManager := newTopologyManager
@@ -642,3 +690,441 @@ func TestPush(t *testing.T) {
 		})
 	}
 }
+
+func TestExec(t *testing.T) {
+	tWithExec := &tpb.Topology{
+		Nodes: []*tpb.Node{{
+			Name:   "execable",
+			Vendor: tpb.Vendor(1005),
+		}},
+	}
+	fExec, closer := writeTopology(t, tWithExec)
+	defer closer()
+	node.Vendor(tpb.Vendor(1005), NewE)
+	tests := []struct {
+		desc    string
+		args    []string
+		want    string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"exec"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "missing command",
+		args:    []string{"exec", fExec.Name(), "execable", "--"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "invalid device",
+		args:    []string{"exec", fExec.Name(), "foo", "--", "ls"},
+		wantErr: `node "foo" not found`,
+	}, {
+		desc: "valid",
+		args: []string{"exec", fExec.Name(), "execable", "--", "ls", "-l"},
+		want: "ls -l",
+	}}
+
+	rCmd := New()
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	rCmd.PersistentFlags().String("kubecfg", "", "")
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			rCmd.SetOut(buf)
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("execFn failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("execFn got output %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLI(t *testing.T) {
+	tWithCLI := &tpb.Topology{
+		Nodes: []*tpb.Node{{
+			Name:   "clier",
+			Vendor: tpb.Vendor(1006),
+		}, {
+			Name:   "execable",
+			Vendor: tpb.Vendor(1007),
+		}},
+	}
+	fCLI, closer := writeTopology(t, tWithCLI)
+	defer closer()
+	node.Vendor(tpb.Vendor(1006), NewCLI)
+	node.Vendor(tpb.Vendor(1007), NewE)
+	tests := []struct {
+		desc    string
+		args    []string
+		want    string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"cli"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "invalid device",
+		args:    []string{"cli", fCLI.Name(), "foo"},
+		wantErr: `node "foo" not found`,
+	}, {
+		desc:    "not clier",
+		args:    []string{"cli", fCLI.Name(), "execable"},
+		wantErr: "does not implement CLIer",
+	}, {
+		desc: "valid",
+		args: []string{"cli", fCLI.Name(), "clier"},
+		want: "cli",
+	}}
+
+	rCmd := New()
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	rCmd.PersistentFlags().String("kubecfg", "", "")
+	rCmd.SetIn(bytes.NewBuffer([]byte{}))
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			rCmd.SetOut(buf)
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("cliFn failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("cliFn got output %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupportBundle(t *testing.T) {
+	fBundle, closer := writeTopology(t, &tpb.Topology{Name: "test"})
+	defer closer()
+	out := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	tests := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"support-bundle"},
+		wantErr: "missing topology",
+	}, {
+		desc:    "invalid output",
+		args:    []string{"support-bundle", fBundle.Name(), "--output", filepath.Join(t.TempDir(), "dne", "bundle.tar.gz")},
+		wantErr: "no such file or directory",
+	}, {
+		desc: "valid",
+		args: []string{"support-bundle", fBundle.Name(), "--output", out},
+	}}
+
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rCmd := New()
+			rCmd.PersistentFlags().String("kubecfg", "", "")
+			rCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("bundleFn failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if _, err := os.Stat(out); err != nil {
+				t.Fatalf("bundleFn did not write bundle: %v", err)
+			}
+		})
+	}
+}
+
+func TestCapture(t *testing.T) {
+	fCapture, closer := writeTopology(t, &tpb.Topology{Name: "test"})
+	defer closer()
+	tests := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"capture"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "missing interface",
+		args:    []string{"capture", fCapture.Name(), "r1"},
+		wantErr: "invalid interface",
+	}, {
+		desc:    "empty interface",
+		args:    []string{"capture", fCapture.Name(), "r1:"},
+		wantErr: "invalid interface",
+	}, {
+		desc:    "node not found",
+		args:    []string{"capture", fCapture.Name(), "r1:eth1", "--filter", "tcp port 22"},
+		wantErr: `node "r1" not found`,
+	}}
+
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rCmd := New()
+			rCmd.PersistentFlags().String("kubecfg", "", "")
+			rCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("captureFn failed: %s", s)
+			}
+		})
+	}
+}
+
+func TestRenderConfig(t *testing.T) {
+	fRender, closer := writeTopology(t, &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1011),
+			Labels: map[string]string{"loopback": "10.0.0.1"},
+			Config: &tpb.Config{
+				ConfigTemplate: true,
+				ConfigData: &tpb.Config_Data{
+					Data: []byte("hostname {{.Name}}\nloopback {{.Labels.loopback}}\n{{range .Interfaces}}{{.Name}} -> {{.PeerName}}:{{.PeerIntName}}\n{{end}}"),
+				},
+			},
+		}, {
+			Name:   "r2",
+			Vendor: tpb.Vendor(1011),
+			Config: &tpb.Config{
+				ConfigData: &tpb.Config_Data{
+					Data: []byte("hostname {{.Name}}\n"),
+				},
+			},
+		}, {
+			Name:   "r3",
+			Vendor: tpb.Vendor(1011),
+			Config: &tpb.Config{
+				ConfigTemplate: true,
+				ConfigData: &tpb.Config_Data{
+					Data: []byte("{{.Labels.dne}}"),
+				},
+			},
+		}, {
+			Name:   "r4",
+			Vendor: tpb.Vendor(1011),
+		}},
+		Links: []*tpb.Link{{
+			ANode: "r1",
+			AInt:  "eth1",
+			ZNode: "r2",
+			ZInt:  "eth1",
+		}},
+	})
+	defer closer()
+	node.Vendor(tpb.Vendor(1011), NewNR)
+	outDir := t.TempDir()
+	tests := []struct {
+		desc    string
+		args    []string
+		want    string
+		wantDir map[string]string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"render-config"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "node not found",
+		args:    []string{"render-config", fRender.Name(), "dne"},
+		wantErr: `node "dne" not found`,
+	}, {
+		desc:    "no config",
+		args:    []string{"render-config", fRender.Name(), "r4"},
+		wantErr: `node "r4" has no config`,
+	}, {
+		desc:    "missing label",
+		args:    []string{"render-config", fRender.Name(), "r3"},
+		wantErr: `failed to render config template for node "r3"`,
+	}, {
+		desc: "template",
+		args: []string{"render-config", fRender.Name(), "r1"},
+		want: "hostname r1\nloopback 10.0.0.1\neth1 -> r2:eth1\n",
+	}, {
+		desc: "not a template",
+		args: []string{"render-config", fRender.Name(), "r2"},
+		want: "hostname {{.Name}}\n",
+	}, {
+		desc: "output dir",
+		args: []string{"render-config", fRender.Name(), "r1", "--output_dir", outDir},
+		wantDir: map[string]string{
+			"r1.cfg": "hostname r1\nloopback 10.0.0.1\neth1 -> r2:eth1\n",
+		},
+	}}
+
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rCmd := New()
+			rCmd.PersistentFlags().String("kubecfg", "", "")
+			rCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			var out bytes.Buffer
+			rCmd.SetOut(&out)
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("renderCfgFn failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, out.String()); s != "" {
+				t.Errorf("renderCfgFn unexpected output diff (-want +got):\n%s", s)
+			}
+			for name, want := range tt.wantDir {
+				b, err := os.ReadFile(filepath.Join(outDir, name))
+				if err != nil {
+					t.Fatalf("renderCfgFn did not write %q: %v", name, err)
+				}
+				if s := cmp.Diff(want, string(b)); s != "" {
+					t.Errorf("renderCfgFn unexpected %q diff (-want +got):\n%s", name, s)
+				}
+			}
+		})
+	}
+}
+
+func TestForward(t *testing.T) {
+	fForward, closer := writeTopology(t, &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1012),
+		}},
+	})
+	defer closer()
+	node.Vendor(tpb.Vendor(1012), NewNR)
+	tests := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{{
+		desc:    "no args",
+		args:    []string{"forward"},
+		wantErr: "invalid args",
+	}, {
+		desc:    "node not found",
+		args:    []string{"forward", fForward.Name(), "dne"},
+		wantErr: `node "dne" not found`,
+	}, {
+		desc:    "no services",
+		args:    []string{"forward", fForward.Name(), "r1"},
+		wantErr: "no ports to forward",
+	}}
+
+	origOpts := opts
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset")
+	}
+	opts = []topo.Option{
+		topo.WithClusterConfig(&rest.Config{}),
+		topo.WithKubeClient(kfake.NewSimpleClientset()),
+		topo.WithTopoClient(tf),
+	}
+	defer func() {
+		opts = origOpts
+	}()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rCmd := New()
+			rCmd.PersistentFlags().String("kubecfg", "", "")
+			rCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+				viper.BindPFlags(cmd.Flags())
+				return nil
+			}
+			rCmd.SetArgs(tt.args)
+			err := rCmd.ExecuteContext(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("forwardFn failed: %s", s)
+			}
+		})
+	}
+}
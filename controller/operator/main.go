@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Operator reconciles KNE Topology objects: it creates their topologies in
+// the cluster, recreates the deleted resources of their nodes, reports the
+// status of the nodes and deletes the topologies with their objects.
+package main
+
+import (
+	"context"
+	"flag"
+	"os/signal"
+	"syscall"
+	"time"
+
+	topologyclientv1 "github.com/openconfig/kne/api/topology/clientset/v1alpha1"
+	"github.com/openconfig/kne/topo"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+)
+
+var (
+	kubecfg   = flag.String("kubecfg", "", "kubeconfig file, the in-cluster config is used if not set")
+	namespace = flag.String("namespace", "", "Namespace of the watched topologies, all namespaces if not set")
+	basePath  = flag.String("base_path", "", "Path the files referenced by topologies are relative to")
+	workers   = flag.Int("workers", 4, "Number of topologies reconciled concurrently")
+	resync    = flag.Duration("resync", 30*time.Second, "Interval between reconciles of all topologies")
+	timeout   = flag.Duration("reconcile_timeout", defaultReconcileTimeout, "Maximum duration of creating the missing resources of a topology")
+)
+
+func restConfig() (*rest.Config, error) {
+	if *kubecfg == "" {
+		return rest.InClusterConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", *kubecfg)
+}
+
+func main() {
+	log.InitFlags(nil)
+	flag.Parse()
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	rCfg, err := restConfig()
+	if err != nil {
+		log.Exitf("Failed to get cluster config: %v", err)
+	}
+	kClient, err := kubernetes.NewForConfig(rCfg)
+	if err != nil {
+		log.Exitf("Failed to create kubernetes client: %v", err)
+	}
+	client, err := topologyclientv1.NewForConfig(rCfg)
+	if err != nil {
+		log.Exitf("Failed to create topology client: %v", err)
+	}
+	r := newReconciler(client, kClient, *namespace, topo.WithClusterConfig(rCfg), topo.WithBasePath(*basePath))
+	r.timeout = *timeout
+	log.Infof("Reconciling topologies with %d workers", *workers)
+	r.run(ctx, *workers, *resync)
+}
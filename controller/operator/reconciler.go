@@ -0,0 +1,383 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	topologyclientv1 "github.com/openconfig/kne/api/topology/clientset/v1alpha1"
+	topologyv1 "github.com/openconfig/kne/api/topology/v1alpha1"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	log "k8s.io/klog/v2"
+)
+
+// defaultReconcileTimeout is the default timeout of creating the missing
+// resources of a topology.
+const defaultReconcileTimeout = 5 * time.Minute
+
+// reconciler keeps the topologies of Topology objects in sync with the
+// cluster with a topology manager.
+type reconciler struct {
+	client  topologyclientv1.Interface
+	kClient kubernetes.Interface
+	// opts are the options of the topology managers, such as their clients.
+	opts []topo.Option
+	// namespace is the namespace of the watched Topology objects, all
+	// namespaces if empty.
+	namespace string
+	// timeout bounds the creation of the missing resources of a topology,
+	// such as waiting for the IPs of a node to issue its certificate, so
+	// that a topology cannot block a worker forever.
+	timeout time.Duration
+	queue   workqueue.TypedRateLimitingInterface[string]
+
+	// mu guards keys, the keys of the Topology objects by topology name.
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func newReconciler(client topologyclientv1.Interface, kClient kubernetes.Interface, namespace string, opts ...topo.Option) *reconciler {
+	return &reconciler{
+		client:    client,
+		kClient:   kClient,
+		opts:      append([]topo.Option{topo.WithKubeClient(kClient), topo.WithSkipDeleteWait(true)}, opts...),
+		namespace: namespace,
+		timeout:   defaultReconcileTimeout,
+		queue:     workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]()),
+		keys:      map[string]string{},
+	}
+}
+
+// topology returns the topology of t, named after t if the spec has no name.
+func topology(t *topologyv1.Topology) *tpb.Topology {
+	pb := &tpb.Topology{}
+	if t.Spec.Topology != nil {
+		pb = proto.Clone(t.Spec.Topology).(*tpb.Topology)
+	}
+	if pb.Name == "" {
+		pb.Name = t.Name
+	}
+	return pb
+}
+
+func hasFinalizer(t *topologyv1.Topology) bool {
+	for _, f := range t.Finalizers {
+		if f == topologyv1.Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcile reconciles the Topology object namespace/name.
+func (r *reconciler) reconcile(ctx context.Context, namespace, name string) error {
+	t, err := r.client.Topology(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	pb := topology(t)
+	r.mu.Lock()
+	r.keys[pb.GetName()] = namespace + "/" + name
+	r.mu.Unlock()
+	if t.DeletionTimestamp != nil {
+		return r.delete(ctx, t, pb)
+	}
+	if !hasFinalizer(t) {
+		t.Finalizers = append(t.Finalizers, topologyv1.Finalizer)
+		if t, err = r.client.Topology(namespace).Update(ctx, t, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	var statuses []*cpb.NodeStatus
+	tm, err := topo.New(pb, r.opts...)
+	if err == nil {
+		rCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		var created []string
+		if created, err = tm.Reconcile(rCtx); len(created) > 0 {
+			log.Infof("Topology %s: created nodes %s", name, strings.Join(created, ", "))
+		}
+		cancel()
+		statuses = tm.NodeStatuses(ctx)
+	}
+	t.Status = status(t, statuses, err)
+	if _, uErr := r.client.Topology(namespace).UpdateStatus(ctx, t, metav1.UpdateOptions{}); uErr != nil {
+		log.Warningf("Failed to update status of topology %s: %v", name, uErr)
+	}
+	return err
+}
+
+// delete deletes the topology of t from the cluster and removes the
+// finalizer of t.
+func (r *reconciler) delete(ctx context.Context, t *topologyv1.Topology, pb *tpb.Topology) error {
+	if !hasFinalizer(t) {
+		return nil
+	}
+	_, err := r.kClient.CoreV1().Namespaces().Get(ctx, pb.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Infof("Topology %s already deleted from cluster", t.Name)
+	case err != nil:
+		return err
+	default:
+		tm, err := topo.New(pb, r.opts...)
+		if err != nil {
+			return err
+		}
+		if err := tm.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	var finalizers []string
+	for _, f := range t.Finalizers {
+		if f != topologyv1.Finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	t.Finalizers = finalizers
+	if _, err := r.client.Topology(t.Namespace).Update(ctx, t, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	r.mu.Lock()
+	delete(r.keys, pb.GetName())
+	r.mu.Unlock()
+	return nil
+}
+
+// status returns the status of t from the statuses of its nodes and the
+// error of the last reconcile.
+func status(t *topologyv1.Topology, statuses []*cpb.NodeStatus, rErr error) topologyv1.TopologyStatus {
+	s := *t.Status.DeepCopy()
+	s.ObservedGeneration = t.Generation
+	if rErr != nil {
+		meta.SetStatusCondition(&s.Conditions, metav1.Condition{
+			Type:               topologyv1.ConditionReconciled,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: t.Generation,
+			Reason:             "ReconcileError",
+			Message:            rErr.Error(),
+		})
+	} else {
+		meta.SetStatusCondition(&s.Conditions, metav1.Condition{
+			Type:               topologyv1.ConditionReconciled,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: t.Generation,
+			Reason:             "Reconciled",
+		})
+	}
+
+	prev := map[string]topologyv1.NodeStatus{}
+	for _, n := range s.Nodes {
+		prev[n.Name] = n
+	}
+	s.Nodes = nil
+	var notReady []string
+	counts := map[cpb.NodeState]int{}
+	for _, ns := range statuses {
+		state := strings.TrimPrefix(ns.GetState().String(), "NODE_STATE_")
+		n := topologyv1.NodeStatus{
+			Name:       ns.GetName(),
+			State:      state,
+			Conditions: prev[ns.GetName()].Conditions,
+			Details:    ns.GetDetails(),
+		}
+		cond := metav1.Condition{
+			Type:               topologyv1.ConditionReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: t.Generation,
+			Reason:             "Running",
+			Message:            ns.GetMessage(),
+		}
+		if ns.GetState() != cpb.NodeState_NODE_STATE_RUNNING {
+			notReady = append(notReady, ns.GetName())
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = conditionReason(ns.GetReason(), state)
+		}
+		meta.SetStatusCondition(&n.Conditions, cond)
+		s.Nodes = append(s.Nodes, n)
+		counts[ns.GetState()]++
+	}
+
+	switch {
+	case len(statuses) == 0 && rErr != nil, counts[cpb.NodeState_NODE_STATE_FAILED] > 0:
+		s.State = "ERROR"
+	case len(notReady) == 0 && len(statuses) > 0:
+		s.State = "RUNNING"
+	default:
+		s.State = "CREATING"
+	}
+	ready := metav1.Condition{
+		Type:               topologyv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: t.Generation,
+		Reason:             "NodesRunning",
+	}
+	if s.State != "RUNNING" {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "NodesNotRunning"
+		if len(notReady) > 0 {
+			ready.Message = fmt.Sprintf("nodes not running: %s", strings.Join(notReady, ", "))
+		}
+	}
+	meta.SetStatusCondition(&s.Conditions, ready)
+	return s
+}
+
+// conditionReason returns reason as a CamelCase condition reason, or the
+// capitalized state if reason is empty.
+func conditionReason(reason, state string) string {
+	words := strings.FieldsFunc(reason, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	var b strings.Builder
+	for _, w := range words {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	if reason = b.String(); reason == "" || !unicode.IsLetter(rune(reason[0])) {
+		return strings.ToUpper(state[:1]) + strings.ToLower(state[1:])
+	}
+	return reason
+}
+
+// enqueue adds the key of the Topology object of a watch event.
+func (r *reconciler) enqueue(e watch.Event) {
+	u, ok := e.Object.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(u)
+	if err != nil {
+		log.Warningf("Invalid topology object: %v", err)
+		return
+	}
+	r.queue.Add(key)
+}
+
+// enqueuePod adds the key of the Topology object of a deleted pod.
+func (r *reconciler) enqueuePod(e watch.Event) {
+	p, ok := e.Object.(*corev1.Pod)
+	if !ok || e.Type != watch.Deleted {
+		return
+	}
+	r.mu.Lock()
+	key, ok := r.keys[p.Labels["topo"]]
+	r.mu.Unlock()
+	if ok {
+		log.Infof("Pod %s/%s of topology %s deleted", p.Namespace, p.Name, key)
+		r.queue.Add(key)
+	}
+}
+
+// resync adds the keys of all Topology objects.
+func (r *reconciler) resync(ctx context.Context) {
+	l, err := r.client.Topology(r.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Warningf("Failed to list topologies: %v", err)
+		return
+	}
+	for _, t := range l.Items {
+		r.queue.Add(t.Namespace + "/" + t.Name)
+	}
+}
+
+// watchLoop calls fn with the events of the watch returned by w until ctx is
+// canceled, restarting the watch when it ends.
+func watchLoop(ctx context.Context, w func(context.Context) (watch.Interface, error), fn func(watch.Event)) {
+	for ctx.Err() == nil {
+		wi, err := w(ctx)
+		if err != nil {
+			log.Warningf("Failed to watch: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+		for e := range wi.ResultChan() {
+			fn(e)
+		}
+		wi.Stop()
+	}
+}
+
+// run reconciles Topology objects with workers until ctx is canceled. All
+// objects are reconciled every resync, recreating the resources of the
+// topologies deleted from the cluster.
+func (r *reconciler) run(ctx context.Context, workers int, resync time.Duration) {
+	defer r.queue.ShutDown()
+	go watchLoop(ctx, func(ctx context.Context) (watch.Interface, error) {
+		return r.client.Topology(r.namespace).Watch(ctx, metav1.ListOptions{})
+	}, r.enqueue)
+	go watchLoop(ctx, func(ctx context.Context) (watch.Interface, error) {
+		return r.kClient.CoreV1().Pods("").Watch(ctx, metav1.ListOptions{LabelSelector: "topo"})
+	}, r.enqueuePod)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for r.processNext(ctx) {
+			}
+		}()
+	}
+	ticker := time.NewTicker(resync)
+	defer ticker.Stop()
+	for {
+		r.resync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext reconciles the next key of the queue and returns false once
+// the queue is shut down.
+func (r *reconciler) processNext(ctx context.Context) bool {
+	key, shutdown := r.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.queue.Done(key)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		r.queue.Forget(key)
+		return true
+	}
+	if err := r.reconcile(ctx, namespace, name); err != nil {
+		log.Warningf("Failed to reconcile topology %s: %v", key, err)
+		r.queue.AddRateLimited(key)
+		return true
+	}
+	r.queue.Forget(key)
+	return true
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/kne/api/topology/clientset/v1alpha1/fake"
+	topologyv1 "github.com/openconfig/kne/api/topology/v1alpha1"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func setPodsRunning(t *testing.T, kClient *kfake.Clientset, namespace string, names ...string) {
+	t.Helper()
+	ctx := context.Background()
+	for _, name := range names {
+		p, err := kClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get pod %s: %v", name, err)
+		}
+		p.Status.Phase = corev1.PodRunning
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		if _, err := kClient.CoreV1().Pods(namespace).UpdateStatus(ctx, p, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update pod %s: %v", name, err)
+		}
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	obj := &topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: "lab", Namespace: "default", Generation: 1},
+		Spec: topologyv1.TopologySpec{Topology: &tpb.Topology{
+			Nodes: []*tpb.Node{
+				{Name: "r1", Vendor: tpb.Vendor_HOST},
+				{Name: "r2", Vendor: tpb.Vendor_HOST},
+			},
+			Links: []*tpb.Link{{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1"}},
+		}},
+	}
+	client, err := fake.NewSimpleClientset(obj)
+	if err != nil {
+		t.Fatalf("failed to create fake topology clientset: %v", err)
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("failed to create fake meshnet clientset: %v", err)
+	}
+	kf := kfake.NewSimpleClientset()
+	r := newReconciler(client, kf, "", topo.WithClusterConfig(&rest.Config{}), topo.WithTopoClient(tf))
+
+	get := func() *topologyv1.Topology {
+		t.Helper()
+		got, err := client.Topology("default").Get(ctx, "lab", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get topology: %v", err)
+		}
+		return got
+	}
+	ignoreConditionFields := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")
+
+	if err := r.reconcile(ctx, "default", "lab"); err != nil {
+		t.Fatalf("reconcile() unexpected error: %v", err)
+	}
+	got := get()
+	if !hasFinalizer(got) {
+		t.Errorf("reconcile() did not add finalizer, got %v", got.Finalizers)
+	}
+	for _, name := range []string{"r1", "r2"} {
+		if _, err := kf.CoreV1().Pods("lab").Get(ctx, name, metav1.GetOptions{}); err != nil {
+			t.Errorf("reconcile() did not create pod %s: %v", name, err)
+		}
+	}
+	want := []topologyv1.NodeStatus{{
+		Name:       "r1",
+		State:      "PENDING",
+		Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Pending"}},
+	}, {
+		Name:       "r2",
+		State:      "PENDING",
+		Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Pending"}},
+	}}
+	if s := cmp.Diff(want, got.Status.Nodes, ignoreConditionFields); s != "" {
+		t.Errorf("reconcile() unexpected node statuses (-want +got):\n%s", s)
+	}
+	if got.Status.State != "CREATING" {
+		t.Errorf("reconcile() got state %q, want CREATING", got.Status.State)
+	}
+
+	setPodsRunning(t, kf, "lab", "r1", "r2")
+	if err := r.reconcile(ctx, "default", "lab"); err != nil {
+		t.Fatalf("reconcile() unexpected error: %v", err)
+	}
+	got = get()
+	if got.Status.State != "RUNNING" {
+		t.Errorf("reconcile() got state %q, want RUNNING", got.Status.State)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, topologyv1.ConditionReady) {
+		t.Errorf("reconcile() got conditions %+v, want Ready", got.Status.Conditions)
+	}
+
+	if err := kf.CoreV1().Pods("lab").Delete(ctx, "r1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	r.enqueuePod(watch.Event{Type: watch.Deleted, Object: &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "lab", Labels: map[string]string{"topo": "lab"}},
+	}})
+	if key, _ := r.queue.Get(); key != "default/lab" {
+		t.Errorf("enqueuePod() queued %q, want default/lab", key)
+	}
+	if err := r.reconcile(ctx, "default", "lab"); err != nil {
+		t.Fatalf("reconcile() unexpected error: %v", err)
+	}
+	if _, err := kf.CoreV1().Pods("lab").Get(ctx, "r1", metav1.GetOptions{}); err != nil {
+		t.Errorf("reconcile() did not recreate pod r1: %v", err)
+	}
+	if got := get(); got.Status.State != "CREATING" {
+		t.Errorf("reconcile() got state %q after pod deletion, want CREATING", got.Status.State)
+	}
+
+	got = get()
+	now := metav1.Now()
+	got.DeletionTimestamp = &now
+	if _, err := client.Topology("default").Update(ctx, got, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update topology: %v", err)
+	}
+	if err := r.reconcile(ctx, "default", "lab"); err != nil {
+		t.Fatalf("reconcile() of deleted topology unexpected error: %v", err)
+	}
+	if got := get(); hasFinalizer(got) {
+		t.Errorf("reconcile() of deleted topology did not remove finalizer, got %v", got.Finalizers)
+	}
+	if _, err := kf.CoreV1().Namespaces().Get(ctx, "lab", metav1.GetOptions{}); err == nil {
+		t.Errorf("reconcile() of deleted topology did not delete namespace")
+	}
+
+	if err := r.reconcile(ctx, "default", "missing"); err != nil {
+		t.Errorf("reconcile() of missing topology unexpected error: %v", err)
+	}
+}
+
+// blockingNode is a node whose creation blocks until its context is done,
+// such as a node waiting for IPs which are never assigned.
+type blockingNode struct {
+	*node.Impl
+}
+
+func (n *blockingNode) Create(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReconcileTimeout(t *testing.T) {
+	ctx := context.Background()
+	node.Vendor(tpb.Vendor(1001), func(impl *node.Impl) (node.Node, error) {
+		return &blockingNode{Impl: impl}, nil
+	})
+	obj := &topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: "lab", Namespace: "default", Generation: 1},
+		Spec: topologyv1.TopologySpec{Topology: &tpb.Topology{
+			Nodes: []*tpb.Node{{Name: "r1", Vendor: tpb.Vendor(1001)}},
+		}},
+	}
+	client, err := fake.NewSimpleClientset(obj)
+	if err != nil {
+		t.Fatalf("failed to create fake topology clientset: %v", err)
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("failed to create fake meshnet clientset: %v", err)
+	}
+	r := newReconciler(client, kfake.NewSimpleClientset(), "", topo.WithClusterConfig(&rest.Config{}), topo.WithTopoClient(tf))
+	r.timeout = 10 * time.Millisecond
+
+	err = r.reconcile(ctx, "default", "lab")
+	if s := errdiff.Substring(err, context.DeadlineExceeded.Error()); s != "" {
+		t.Fatalf("reconcile() unexpected error: %s", s)
+	}
+	got, err := client.Topology("default").Get(ctx, "lab", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get topology: %v", err)
+	}
+	if c := meta.FindStatusCondition(got.Status.Conditions, topologyv1.ConditionReconciled); c == nil || c.Reason != "ReconcileError" {
+		t.Errorf("reconcile() got conditions %+v, want ReconcileError", got.Status.Conditions)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		desc           string
+		statuses       []*cpb.NodeStatus
+		err            error
+		wantState      string
+		wantConditions []metav1.Condition
+		wantNodes      []topologyv1.NodeStatus
+	}{{
+		desc: "running",
+		statuses: []*cpb.NodeStatus{
+			{Name: "r1", State: cpb.NodeState_NODE_STATE_RUNNING},
+		},
+		wantState: "RUNNING",
+		wantConditions: []metav1.Condition{
+			{Type: "Reconciled", Status: metav1.ConditionTrue, Reason: "Reconciled"},
+			{Type: "Ready", Status: metav1.ConditionTrue, Reason: "NodesRunning"},
+		},
+		wantNodes: []topologyv1.NodeStatus{{
+			Name:       "r1",
+			State:      "RUNNING",
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Running"}},
+		}},
+	}, {
+		desc: "failed node",
+		statuses: []*cpb.NodeStatus{
+			{Name: "r1", State: cpb.NodeState_NODE_STATE_RUNNING},
+			{
+				Name:    "r2",
+				State:   cpb.NodeState_NODE_STATE_FAILED,
+				Reason:  "OOMKilled",
+				Message: "container r2: exited with code 137",
+				Details: map[string]string{"r2.restarts": "1"},
+			},
+		},
+		wantState: "ERROR",
+		wantConditions: []metav1.Condition{
+			{Type: "Reconciled", Status: metav1.ConditionTrue, Reason: "Reconciled"},
+			{Type: "Ready", Status: metav1.ConditionFalse, Reason: "NodesNotRunning", Message: "nodes not running: r2"},
+		},
+		wantNodes: []topologyv1.NodeStatus{{
+			Name:       "r1",
+			State:      "RUNNING",
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Running"}},
+		}, {
+			Name:  "r2",
+			State: "FAILED",
+			Conditions: []metav1.Condition{{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "OOMKilled",
+				Message: "container r2: exited with code 137",
+			}},
+			Details: map[string]string{"r2.restarts": "1"},
+		}},
+	}, {
+		desc: "reconcile error",
+		err:  errors.New("failed to create namespace"),
+		statuses: []*cpb.NodeStatus{
+			{Name: "r1", State: cpb.NodeState_NODE_STATE_UNKNOWN, Reason: "StatusError"},
+		},
+		wantState: "CREATING",
+		wantConditions: []metav1.Condition{
+			{Type: "Reconciled", Status: metav1.ConditionFalse, Reason: "ReconcileError", Message: "failed to create namespace"},
+			{Type: "Ready", Status: metav1.ConditionFalse, Reason: "NodesNotRunning", Message: "nodes not running: r1"},
+		},
+		wantNodes: []topologyv1.NodeStatus{{
+			Name:       "r1",
+			State:      "UNKNOWN",
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "StatusError"}},
+		}},
+	}, {
+		desc:      "invalid topology",
+		err:       errors.New("failed to load topology"),
+		wantState: "ERROR",
+		wantConditions: []metav1.Condition{
+			{Type: "Reconciled", Status: metav1.ConditionFalse, Reason: "ReconcileError", Message: "failed to load topology"},
+			{Type: "Ready", Status: metav1.ConditionFalse, Reason: "NodesNotRunning"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := status(&topologyv1.Topology{}, tt.statuses, tt.err)
+			if got.State != tt.wantState {
+				t.Errorf("status() got state %q, want %q", got.State, tt.wantState)
+			}
+			ignore := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+			if s := cmp.Diff(tt.wantConditions, got.Conditions, ignore); s != "" {
+				t.Errorf("status() unexpected conditions (-want +got):\n%s", s)
+			}
+			if s := cmp.Diff(tt.wantNodes, got.Nodes, ignore); s != "" {
+				t.Errorf("status() unexpected nodes (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestConditionReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		state  string
+		want   string
+	}{
+		{reason: "ImagePullBackOff", state: "PENDING", want: "ImagePullBackOff"},
+		{reason: "", state: "PENDING", want: "Pending"},
+		{reason: "Provisioning license", state: "PENDING", want: "ProvisioningLicense"},
+		{reason: "1bad", state: "FAILED", want: "Failed"},
+		{reason: "exit code: 1", state: "FAILED", want: "ExitCode1"},
+	}
+	for _, tt := range tests {
+		if got := conditionReason(tt.reason, tt.state); got != tt.want {
+			t.Errorf("conditionReason(%q, %q) got %q, want %q", tt.reason, tt.state, got, tt.want)
+		}
+	}
+}
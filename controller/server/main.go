@@ -31,6 +31,7 @@ import (
 	"github.com/openconfig/kne/cluster/kubeadm"
 	"github.com/openconfig/kne/deploy"
 	"github.com/openconfig/kne/exec/run"
+	"github.com/openconfig/kne/progress"
 	cpb "github.com/openconfig/kne/proto/controller"
 	tpb "github.com/openconfig/kne/proto/topo"
 	"github.com/openconfig/kne/topo"
@@ -40,6 +41,10 @@ import (
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
@@ -61,6 +66,9 @@ var (
 	reportUsage          = flag.Bool("report_usage", false, "Whether to reporting anonymous usage metrics")
 	reportUsageProjectID = flag.String("report_usage_project_id", "", "Project to report anonymous usage metrics to")
 	reportUsageTopicID   = flag.String("report_usage_topic_id", "", "Topic to report anonymous usage metrics to")
+	stateStoreType       = flag.String("state_store", "file", "Where to record the clusters and topologies created by the server: file or cluster")
+	stateDir             = flag.String("state_dir", "", "Directory of the file state store, defaults to ~/.config/kne/controller")
+	stateNamespace       = flag.String("state_namespace", "kne-controller", "Namespace of the cluster state store")
 )
 
 func init() {
@@ -85,12 +93,14 @@ type server struct {
 	deployments map[string]*deploy.Deployment
 	muTopo      sync.Mutex        // guards topos map
 	topos       map[string][]byte // stores the topology protobuf from the initial topology creation request
+	store       stateStore        // records deployments and topos across restarts
 }
 
-func newServer() *server {
+func newServer(store stateStore) *server {
 	return &server{
 		deployments: map[string]*deploy.Deployment{},
 		topos:       map[string][]byte{},
+		store:       store,
 	}
 }
 
@@ -322,6 +332,11 @@ func newDeployment(req *cpb.CreateClusterRequest) (*deploy.Deployment, error) {
 
 func (s *server) CreateCluster(ctx context.Context, req *cpb.CreateClusterRequest) (*cpb.CreateClusterResponse, error) {
 	log.Infof("Received CreateCluster request: %v", req)
+	return s.createCluster(ctx, req, nil)
+}
+
+// createCluster creates the cluster of req, reporting its progress to f.
+func (s *server) createCluster(ctx context.Context, req *cpb.CreateClusterRequest, f progress.Func) (*cpb.CreateClusterResponse, error) {
 	d, err := newDeployment(req)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "unable to parse request: %v", err)
@@ -332,10 +347,18 @@ func (s *server) CreateCluster(ctx context.Context, req *cpb.CreateClusterReques
 	if _, ok := s.deployments[d.Cluster.GetName()]; ok { // if OK
 		return nil, status.Errorf(codes.AlreadyExists, "cluster %q already exists", d.Cluster.GetName())
 	}
-	if err := d.Deploy(ctx, defaultKubeCfg); err != nil {
+	d.ProgressFunc = f
+	err = d.Deploy(ctx, defaultKubeCfg)
+	d.ProgressFunc = nil
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to deploy cluster: %v", err)
 	}
 	s.deployments[d.Cluster.GetName()] = d
+	if txtPb, err := prototext.Marshal(req); err != nil {
+		log.Errorf("Failed to marshal cluster %q, it will not be recovered on restart: %v", d.Cluster.GetName(), err)
+	} else if err := s.store.Put(ctx, clusterState, d.Cluster.GetName(), txtPb); err != nil {
+		log.Errorf("Failed to record cluster %q, it will not be recovered on restart: %v", d.Cluster.GetName(), err)
+	}
 	log.Infof("Cluster %q deployed and ready for topology", d.Cluster.GetName())
 	resp := &cpb.CreateClusterResponse{
 		Name:  d.Cluster.GetName(),
@@ -356,6 +379,9 @@ func (s *server) DeleteCluster(ctx context.Context, req *cpb.DeleteClusterReques
 		return nil, status.Errorf(codes.Internal, "failed to delete cluster: %v", err)
 	}
 	delete(s.deployments, req.GetName())
+	if err := s.store.Delete(ctx, clusterState, req.GetName()); err != nil {
+		log.Errorf("Failed to forget cluster %q: %v", req.GetName(), err)
+	}
 	log.Infof("Deleted cluster %q", d.Cluster.GetName())
 	return &cpb.DeleteClusterResponse{}, nil
 }
@@ -405,8 +431,23 @@ func (s *server) ApplyCluster(ctx context.Context, req *cpb.ApplyClusterRequest)
 	return &cpb.ApplyClusterResponse{}, d.Cluster.Apply(req.GetConfig())
 }
 
+// stripPasswords returns a copy of req without the inline passwords of the
+// nodes of its topology, so that the request can be logged.
+func stripPasswords(req *cpb.CreateTopologyRequest) *cpb.CreateTopologyRequest {
+	req = proto.Clone(req).(*cpb.CreateTopologyRequest)
+	if req.GetTopology() != nil {
+		req.Topology = topo.StripPasswords(req.GetTopology())
+	}
+	return req
+}
+
 func (s *server) CreateTopology(ctx context.Context, req *cpb.CreateTopologyRequest) (*cpb.CreateTopologyResponse, error) {
-	log.Infof("Received CreateTopology request: %v", req)
+	log.Infof("Received CreateTopology request: %v", stripPasswords(req))
+	return s.createTopology(ctx, req, nil)
+}
+
+// createTopology creates the topology of req, reporting its progress to f.
+func (s *server) createTopology(ctx context.Context, req *cpb.CreateTopologyRequest, f progress.Func) (*cpb.CreateTopologyResponse, error) {
 	topoPb := req.GetTopology()
 	if topoPb == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid request: missing topology protobuf")
@@ -447,11 +488,16 @@ func (s *server) CreateTopology(ctx context.Context, req *cpb.CreateTopologyRequ
 		node.GetConfig().ConfigData = &tpb.Config_File{File: path}
 		log.Infof("node %q: fixed config path to %q", node.Name, path)
 	}
-	// Saves the original topology protobuf.
+	// Saves the original topology protobuf. Inline passwords are kept in
+	// memory for the operations on the topology but are not recorded.
 	txtPb, err := prototext.Marshal(topoPb)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "invalid topology protobuf: %v", err)
 	}
+	recordPb, err := prototext.Marshal(topo.StripPasswords(topoPb))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid topology protobuf: %v", err)
+	}
 	path := defaultKubeCfg
 	if req.Kubecfg != "" {
 		path = req.Kubecfg
@@ -463,6 +509,7 @@ func (s *server) CreateTopology(ctx context.Context, req *cpb.CreateTopologyRequ
 	opts := []topo.Option{
 		topo.WithKubecfg(kcfg),
 		topo.WithUsageReporting(*reportUsage, *reportUsageProjectID, *reportUsageTopicID),
+		topo.WithProgressFunc(f),
 	}
 	tm, err := topo.New(topoPb, opts...)
 	if err != nil {
@@ -473,6 +520,9 @@ func (s *server) CreateTopology(ctx context.Context, req *cpb.CreateTopologyRequ
 	}
 
 	s.topos[topoPb.GetName()] = txtPb
+	if err := s.store.Put(ctx, topologyState, topoPb.GetName(), recordPb); err != nil {
+		log.Errorf("Failed to record topology %q, it will not be recovered on restart: %v", topoPb.GetName(), err)
+	}
 
 	ti, err := tm.Show(ctx)
 	if err != nil {
@@ -510,6 +560,9 @@ func (s *server) DeleteTopology(ctx context.Context, req *cpb.DeleteTopologyRequ
 		return nil, status.Errorf(codes.Internal, "failed to delete topology: %v", err)
 	}
 	delete(s.topos, req.GetTopologyName())
+	if err := s.store.Delete(ctx, topologyState, req.GetTopologyName()); err != nil {
+		log.Errorf("Failed to forget topology %q: %v", req.GetTopologyName(), err)
+	}
 	return &cpb.DeleteTopologyResponse{}, nil
 }
 
@@ -635,8 +688,64 @@ func validatePath(path string) (string, error) {
 	return path, nil
 }
 
+// kubeClient returns a client of the cluster the server runs in, or of the
+// cluster of the default kubecfg.
+func kubeClient() (kubernetes.Interface, error) {
+	rCfg, err := rest.InClusterConfig()
+	if err != nil {
+		kcfg, err := validatePath(defaultKubeCfg)
+		if err != nil {
+			return nil, err
+		}
+		if rCfg, err = clientcmd.BuildConfigFromFlags("", kcfg); err != nil {
+			return nil, err
+		}
+	}
+	kClient, err := kubernetes.NewForConfig(rCfg)
+	if err != nil {
+		return nil, err
+	}
+	return kClient, nil
+}
+
+// newStateStore returns the state store selected by the flags.
+func newStateStore(kClient kubernetes.Interface) (stateStore, error) {
+	switch *stateStoreType {
+	case "file":
+		dir := *stateDir
+		if dir == "" {
+			home := homedir.HomeDir()
+			if home == "" {
+				return nil, fmt.Errorf("no home directory, set --state_dir")
+			}
+			dir = filepath.Join(home, ".config", "kne", "controller")
+		}
+		return newFileStore(dir), nil
+	case "cluster":
+		if kClient == nil {
+			return nil, fmt.Errorf("cluster state store requires a cluster")
+		}
+		return newClusterStore(kClient, *stateNamespace), nil
+	default:
+		return nil, fmt.Errorf("unknown state store %q", *stateStoreType)
+	}
+}
+
 func main() {
 	flag.Parse()
+	ctx := context.Background()
+	kClient, err := kubeClient()
+	if err != nil {
+		log.Warningf("No cluster to recover topologies from: %v", err)
+	}
+	store, err := newStateStore(kClient)
+	if err != nil {
+		log.Fatalf("failed to create state store: %v", err)
+	}
+	srv := newServer(store)
+	if err := srv.recoverState(ctx, kClient); err != nil {
+		log.Fatalf("failed to recover state: %v", err)
+	}
 	addr := fmt.Sprintf(":%d", *port)
 	lis, err := net.Listen("tcp6", addr)
 	if err != nil {
@@ -652,7 +761,7 @@ func main() {
 		grpc.MaxRecvMsgSize(math.MaxInt32),
 		grpc.MaxSendMsgSize(math.MaxInt32),
 	)
-	cpb.RegisterTopologyManagerServer(s, newServer())
+	cpb.RegisterTopologyManagerServer(s, srv)
 	log.Infof("Controller server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
@@ -9,6 +9,8 @@ import (
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/kne/deploy"
 	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestNewDeployment(t *testing.T) {
@@ -1413,3 +1415,33 @@ func TestNewDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestStripPasswords(t *testing.T) {
+	req := &cpb.CreateTopologyRequest{
+		Topology: &tpb.Topology{
+			Name: "test",
+			Nodes: []*tpb.Node{{
+				Name:        "r1",
+				Credentials: &tpb.Credentials{Username: "admin", Password: "secret"},
+			}},
+		},
+	}
+	want := &cpb.CreateTopologyRequest{
+		Topology: &tpb.Topology{
+			Name: "test",
+			Nodes: []*tpb.Node{{
+				Name:        "r1",
+				Credentials: &tpb.Credentials{Username: "admin"},
+			}},
+		},
+	}
+	if s := cmp.Diff(want, stripPasswords(req), protocmp.Transform()); s != "" {
+		t.Errorf("stripPasswords() unexpected diff (-want +got):\n%s", s)
+	}
+	if got := req.GetTopology().GetNodes()[0].GetCredentials().GetPassword(); got != "secret" {
+		t.Errorf("stripPasswords() modified the request password: got %q, want %q", got, "secret")
+	}
+	if s := cmp.Diff(&cpb.CreateTopologyRequest{}, stripPasswords(&cpb.CreateTopologyRequest{}), protocmp.Transform()); s != "" {
+		t.Errorf("stripPasswords() unexpected diff (-want +got):\n%s", s)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	log "github.com/golang/glog"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+// topoOptions returns the options of the topology managers of the server. It
+// can be set to a fake for unit testing.
+var topoOptions = func() ([]topo.Option, error) {
+	kcfg, err := validatePath(defaultKubeCfg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "default kubecfg %q does not exist: %v", defaultKubeCfg, err)
+	}
+	return []topo.Option{topo.WithKubecfg(kcfg)}, nil
+}
+
+// topoManager returns the topology manager of a topology created by the
+// server.
+func (s *server) topoManager(name string) (*topo.Manager, error) {
+	s.muTopo.Lock()
+	txtPb, ok := s.topos[name]
+	s.muTopo.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "topology %q not found", name)
+	}
+	topoPb := &tpb.Topology{}
+	if err := prototext.Unmarshal(txtPb, topoPb); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid topology protobuf: %v", err)
+	}
+	opts, err := topoOptions()
+	if err != nil {
+		return nil, err
+	}
+	tm, err := topo.New(topoPb, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create topology manager for %s: %v", topoPb.Name, err)
+	}
+	return tm, nil
+}
+
+// operationError returns err with its code if it has one, such as
+// codes.Unimplemented for operations not supported by the device, and
+// codes.Internal otherwise.
+func operationError(err error, format string, args ...any) error {
+	code := status.Code(err)
+	if code == codes.Unknown {
+		code = codes.Internal
+	}
+	return status.Errorf(code, format+": %v", append(args, err)...)
+}
+
+func (s *server) ListOperations(ctx context.Context, req *cpb.ListOperationsRequest) (*cpb.ListOperationsResponse, error) {
+	log.Infof("Received ListOperations request: %v", req)
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	ops, err := tm.Operations(req.GetDeviceName())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	resp := &cpb.ListOperationsResponse{}
+	for _, op := range ops {
+		resp.Operations = append(resp.Operations, string(op))
+	}
+	return resp, nil
+}
+
+func (s *server) RebootDevice(ctx context.Context, req *cpb.RebootDeviceRequest) (*cpb.RebootDeviceResponse, error) {
+	log.Infof("Received RebootDevice request: %v", req)
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	if err := tm.Reboot(ctx, req.GetDeviceName(), &node.RebootOptions{
+		Warm:    req.GetWarm(),
+		Delay:   time.Duration(req.GetDelay()),
+		Message: req.GetMessage(),
+		Force:   req.GetForce(),
+	}); err != nil {
+		return nil, operationError(err, "failed to reboot device %q", req.GetDeviceName())
+	}
+	return &cpb.RebootDeviceResponse{}, nil
+}
+
+func (s *server) PutFile(ctx context.Context, req *cpb.PutFileRequest) (*cpb.PutFileResponse, error) {
+	log.Infof("Received PutFile request for %q on device %q", req.GetRemoteFile(), req.GetDeviceName())
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	perm := os.FileMode(req.GetPermissions()) & os.ModePerm
+	if perm == 0 {
+		perm = 0644
+	}
+	if err := tm.PutFile(ctx, req.GetDeviceName(), req.GetRemoteFile(), bytes.NewReader(req.GetContents()), perm); err != nil {
+		return nil, operationError(err, "failed to put file %q on device %q", req.GetRemoteFile(), req.GetDeviceName())
+	}
+	return &cpb.PutFileResponse{}, nil
+}
+
+func (s *server) GetFile(ctx context.Context, req *cpb.GetFileRequest) (*cpb.GetFileResponse, error) {
+	log.Infof("Received GetFile request: %v", req)
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tm.GetFile(ctx, req.GetDeviceName(), req.GetRemoteFile(), &buf); err != nil {
+		return nil, operationError(err, "failed to get file %q from device %q", req.GetRemoteFile(), req.GetDeviceName())
+	}
+	return &cpb.GetFileResponse{Contents: buf.Bytes()}, nil
+}
+
+func (s *server) InstallCertificate(ctx context.Context, req *cpb.InstallCertificateRequest) (*cpb.InstallCertificateResponse, error) {
+	log.Infof("Received InstallCertificate request: %v", req)
+	if req.GetCertificateId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "certificate_id must be set")
+	}
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	if err := tm.InstallCertificate(ctx, req.GetDeviceName(), req.GetCertificateId()); err != nil {
+		return nil, operationError(err, "failed to install certificate on device %q", req.GetDeviceName())
+	}
+	return &cpb.InstallCertificateResponse{}, nil
+}
+
+func (s *server) PingDevice(ctx context.Context, req *cpb.PingDeviceRequest) (*cpb.PingDeviceResponse, error) {
+	log.Infof("Received PingDevice request: %v", req)
+	if req.GetDestination() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "destination must be set")
+	}
+	tm, err := s.topoManager(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	res, err := tm.Ping(ctx, req.GetDeviceName(), &node.PingOptions{
+		Destination:     req.GetDestination(),
+		Source:          req.GetSource(),
+		Count:           req.GetCount(),
+		Interval:        time.Duration(req.GetInterval()),
+		Size:            req.GetSize(),
+		NetworkInstance: req.GetNetworkInstance(),
+	})
+	if err != nil {
+		return nil, operationError(err, "failed to ping %q from device %q", req.GetDestination(), req.GetDeviceName())
+	}
+	resp := &cpb.PingDeviceResponse{
+		Sent:     res.Sent,
+		Received: res.Received,
+		MinTime:  res.MinTime.Nanoseconds(),
+		AvgTime:  res.AvgTime.Nanoseconds(),
+		MaxTime:  res.MaxTime.Nanoseconds(),
+		StdDev:   res.StdDev.Nanoseconds(),
+	}
+	for _, r := range res.Replies {
+		resp.Replies = append(resp.Replies, &cpb.PingReply{
+			Source:   r.Source,
+			Sequence: r.Sequence,
+			Bytes:    r.Bytes,
+			Ttl:      r.TTL,
+			Time:     r.Time.Nanoseconds(),
+		})
+	}
+	return resp, nil
+}
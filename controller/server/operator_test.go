@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// fakeOperator supports all operations unless its model is "reboot-only".
+type fakeOperator struct {
+	*node.Impl
+}
+
+func newFakeOperator(impl *node.Impl) (node.Node, error) {
+	return &fakeOperator{Impl: impl}, nil
+}
+
+func (f *fakeOperator) Operations() []node.Operation {
+	if f.GetProto().GetModel() == "reboot-only" {
+		return []node.Operation{node.OperationReboot}
+	}
+	return node.AllOperations
+}
+
+func (f *fakeOperator) Reboot(_ context.Context, _ *node.RebootOptions) error {
+	return nil
+}
+
+func (f *fakeOperator) PutFile(_ context.Context, _ string, r io.Reader, _ os.FileMode) error {
+	_, err := io.ReadAll(r)
+	return err
+}
+
+func (f *fakeOperator) GetFile(_ context.Context, remote string, w io.Writer) error {
+	_, err := io.WriteString(w, "contents of "+remote)
+	return err
+}
+
+func (f *fakeOperator) InstallCertificate(_ context.Context, _ string, _ *node.Cert) error {
+	return nil
+}
+
+func (f *fakeOperator) Ping(_ context.Context, opts *node.PingOptions) (*node.PingResult, error) {
+	return &node.PingResult{Sent: opts.Count, Received: opts.Count}, nil
+}
+
+func TestOperatorRPCs(t *testing.T) {
+	ctx := context.Background()
+	node.Vendor(tpb.Vendor(1001), newFakeOperator)
+	origTopoOptions := topoOptions
+	defer func() {
+		topoOptions = origTopoOptions
+	}()
+	topoOptions = func() ([]topo.Option, error) {
+		tf, err := tfake.NewSimpleClientset()
+		if err != nil {
+			return nil, err
+		}
+		kf := kfake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "test"},
+			Status:     corev1.PodStatus{PodIP: "10.244.0.5"},
+		})
+		return []topo.Option{topo.WithClusterConfig(&rest.Config{}), topo.WithKubeClient(kf), topo.WithTopoClient(tf)}, nil
+	}
+	txtPb, err := prototext.Marshal(&tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{
+			{Name: "r1", Vendor: tpb.Vendor(1001)},
+			{Name: "r2", Vendor: tpb.Vendor(1001), Model: "reboot-only"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal topology: %v", err)
+	}
+	s := newServer(newFileStore(t.TempDir()))
+	s.topos["test"] = txtPb
+
+	tests := []struct {
+		desc     string
+		call     func() (proto.Message, error)
+		want     proto.Message
+		wantCode codes.Code
+	}{{
+		desc: "list operations",
+		call: func() (proto.Message, error) {
+			return s.ListOperations(ctx, &cpb.ListOperationsRequest{TopologyName: "test", DeviceName: "r2"})
+		},
+		want: &cpb.ListOperationsResponse{Operations: []string{"reboot"}},
+	}, {
+		desc: "list operations device not found",
+		call: func() (proto.Message, error) {
+			return s.ListOperations(ctx, &cpb.ListOperationsRequest{TopologyName: "test", DeviceName: "r3"})
+		},
+		wantCode: codes.NotFound,
+	}, {
+		desc: "reboot",
+		call: func() (proto.Message, error) {
+			return s.RebootDevice(ctx, &cpb.RebootDeviceRequest{TopologyName: "test", DeviceName: "r2", Warm: true})
+		},
+		want: &cpb.RebootDeviceResponse{},
+	}, {
+		desc: "reboot topology not found",
+		call: func() (proto.Message, error) {
+			return s.RebootDevice(ctx, &cpb.RebootDeviceRequest{TopologyName: "dne", DeviceName: "r1"})
+		},
+		wantCode: codes.NotFound,
+	}, {
+		desc: "put file",
+		call: func() (proto.Message, error) {
+			return s.PutFile(ctx, &cpb.PutFileRequest{TopologyName: "test", DeviceName: "r1", RemoteFile: "/tmp/f", Contents: []byte("data")})
+		},
+		want: &cpb.PutFileResponse{},
+	}, {
+		desc: "put file unimplemented",
+		call: func() (proto.Message, error) {
+			return s.PutFile(ctx, &cpb.PutFileRequest{TopologyName: "test", DeviceName: "r2", RemoteFile: "/tmp/f"})
+		},
+		wantCode: codes.Unimplemented,
+	}, {
+		desc: "get file",
+		call: func() (proto.Message, error) {
+			return s.GetFile(ctx, &cpb.GetFileRequest{TopologyName: "test", DeviceName: "r1", RemoteFile: "/tmp/f"})
+		},
+		want: &cpb.GetFileResponse{Contents: []byte("contents of /tmp/f")},
+	}, {
+		desc: "get file unimplemented",
+		call: func() (proto.Message, error) {
+			return s.GetFile(ctx, &cpb.GetFileRequest{TopologyName: "test", DeviceName: "r2", RemoteFile: "/tmp/f"})
+		},
+		wantCode: codes.Unimplemented,
+	}, {
+		desc: "install certificate",
+		call: func() (proto.Message, error) {
+			return s.InstallCertificate(ctx, &cpb.InstallCertificateRequest{TopologyName: "test", DeviceName: "r1", CertificateId: "kne"})
+		},
+		want: &cpb.InstallCertificateResponse{},
+	}, {
+		desc: "install certificate without id",
+		call: func() (proto.Message, error) {
+			return s.InstallCertificate(ctx, &cpb.InstallCertificateRequest{TopologyName: "test", DeviceName: "r1"})
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "install certificate unimplemented",
+		call: func() (proto.Message, error) {
+			return s.InstallCertificate(ctx, &cpb.InstallCertificateRequest{TopologyName: "test", DeviceName: "r2", CertificateId: "kne"})
+		},
+		wantCode: codes.Unimplemented,
+	}, {
+		desc: "ping",
+		call: func() (proto.Message, error) {
+			return s.PingDevice(ctx, &cpb.PingDeviceRequest{TopologyName: "test", DeviceName: "r1", Destination: "10.0.0.2", Count: 3})
+		},
+		want: &cpb.PingDeviceResponse{Sent: 3, Received: 3},
+	}, {
+		desc: "ping without destination",
+		call: func() (proto.Message, error) {
+			return s.PingDevice(ctx, &cpb.PingDeviceRequest{TopologyName: "test", DeviceName: "r1"})
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "ping unimplemented",
+		call: func() (proto.Message, error) {
+			return s.PingDevice(ctx, &cpb.PingDeviceRequest{TopologyName: "test", DeviceName: "r2", Destination: "10.0.0.2"})
+		},
+		wantCode: codes.Unimplemented,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tt.call()
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("got error %v, want code %s", err, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("unexpected response (-want +got):\n%s", s)
+			}
+		})
+	}
+}
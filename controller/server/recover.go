@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/golang/glog"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"google.golang.org/protobuf/encoding/prototext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// recoverState loads the clusters and topologies recorded in the store of
+// the server. If kClient is set, it then re-adopts the topology namespaces
+// of the cluster which are not recorded, such as topologies created before
+// the state was lost, and forgets the recorded topologies whose namespace
+// no longer exists. Failing to reach the cluster is not an error, as the
+// server may be started before the cluster it will create.
+func (s *server) recoverState(ctx context.Context, kClient kubernetes.Interface) error {
+	if err := s.recoverClusters(ctx); err != nil {
+		return err
+	}
+	if err := s.recoverTopologies(ctx); err != nil {
+		return err
+	}
+	if kClient == nil {
+		return nil
+	}
+	if err := s.adoptTopologies(ctx, kClient); err != nil {
+		log.Warningf("Failed to adopt the topologies of the cluster: %v", err)
+	}
+	return nil
+}
+
+func (s *server) recoverClusters(ctx context.Context) error {
+	states, err := s.store.List(ctx, clusterState)
+	if err != nil {
+		return err
+	}
+	s.muDeploy.Lock()
+	defer s.muDeploy.Unlock()
+	for name, b := range states {
+		req := &cpb.CreateClusterRequest{}
+		if err := prototext.Unmarshal(b, req); err != nil {
+			log.Warningf("Skipping invalid state of cluster %q: %v", name, err)
+			continue
+		}
+		d, err := newDeployment(req)
+		if err != nil {
+			log.Warningf("Skipping cluster %q: %v", name, err)
+			continue
+		}
+		s.deployments[name] = d
+		log.Infof("Recovered cluster %q", name)
+	}
+	return nil
+}
+
+func (s *server) recoverTopologies(ctx context.Context) error {
+	states, err := s.store.List(ctx, topologyState)
+	if err != nil {
+		return err
+	}
+	s.muTopo.Lock()
+	defer s.muTopo.Unlock()
+	for name, b := range states {
+		if err := prototext.Unmarshal(b, &tpb.Topology{}); err != nil {
+			log.Warningf("Skipping invalid state of topology %q: %v", name, err)
+			continue
+		}
+		s.topos[name] = b
+		log.Infof("Recovered topology %q", name)
+	}
+	return nil
+}
+
+func (s *server) adoptTopologies(ctx context.Context, kClient kubernetes.Interface) error {
+	nss, err := kClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", topo.TopologyLabel),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list topology namespaces: %w", err)
+	}
+	exists := map[string]bool{}
+	s.muTopo.Lock()
+	defer s.muTopo.Unlock()
+	for _, ns := range nss.Items {
+		exists[ns.Name] = true
+		if _, ok := s.topos[ns.Name]; ok {
+			continue
+		}
+		topoPb, err := topo.LoadTopology(ctx, kClient, ns.Name)
+		if err != nil {
+			log.Warningf("Cannot adopt topology %q: %v", ns.Name, err)
+			continue
+		}
+		txtPb, err := prototext.Marshal(topoPb)
+		if err != nil {
+			log.Warningf("Cannot adopt topology %q: %v", ns.Name, err)
+			continue
+		}
+		if err := s.store.Put(ctx, topologyState, ns.Name, txtPb); err != nil {
+			return err
+		}
+		s.topos[ns.Name] = txtPb
+		log.Infof("Adopted topology %q", ns.Name)
+	}
+	for name := range s.topos {
+		if exists[name] {
+			continue
+		}
+		if err := s.store.Delete(ctx, topologyState, name); err != nil {
+			return err
+		}
+		delete(s.topos, name)
+		log.Infof("Forgot topology %q which no longer exists", name)
+	}
+	return nil
+}
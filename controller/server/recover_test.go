@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo"
+	"google.golang.org/protobuf/encoding/prototext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func keys[V any](m map[string]V) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+func TestRecoverState(t *testing.T) {
+	ctx := context.Background()
+	clusterReq, err := prototext.Marshal(&cpb.CreateClusterRequest{
+		ClusterSpec: &cpb.CreateClusterRequest_Kind{Kind: &cpb.KindSpec{Name: "kne"}},
+		IngressSpec: &cpb.CreateClusterRequest_Metallb{Metallb: &cpb.MetallbSpec{
+			Manifest: &cpb.Manifest{ManifestData: &cpb.Manifest_Data{Data: []byte("metallb")}},
+		}},
+		CniSpec: &cpb.CreateClusterRequest_Meshnet{Meshnet: &cpb.MeshnetSpec{
+			Manifest: &cpb.Manifest{ManifestData: &cpb.Manifest_Data{Data: []byte("meshnet")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal cluster request: %v", err)
+	}
+	store := newFileStore(t.TempDir())
+	for _, st := range []struct {
+		kind  stateKind
+		name  string
+		value string
+	}{
+		{clusterState, "kne", string(clusterReq)},
+		{clusterState, "invalid", "cluster_spec: {"},
+		{topologyState, "recorded", `name: "recorded"`},
+		{topologyState, "deleted", `name: "deleted"`},
+		{topologyState, "invalid", "nodes: {"},
+	} {
+		if err := store.Put(ctx, st.kind, st.name, []byte(st.value)); err != nil {
+			t.Fatalf("Put() unexpected error: %v", err)
+		}
+	}
+	topoNamespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{topo.TopologyLabel: "true"},
+		}}
+	}
+	kClient := kfake.NewSimpleClientset(
+		topoNamespace("recorded"),
+		topoNamespace("adopted"),
+		topoNamespace("unrecorded"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: topo.TopologyConfigMap, Namespace: "adopted"},
+			Data:       map[string]string{"topology.textproto": `name: "adopted" nodes: { name: "r1" }`},
+		},
+	)
+
+	s := newServer(store)
+	if err := s.recoverState(ctx, kClient); err != nil {
+		t.Fatalf("recoverState() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]string{"kne"}, keys(s.deployments)); s != "" {
+		t.Errorf("recoverState() unexpected clusters (-want +got):\n%s", s)
+	}
+	if s := cmp.Diff([]string{"adopted", "recorded"}, keys(s.topos)); s != "" {
+		t.Errorf("recoverState() unexpected topologies (-want +got):\n%s", s)
+	}
+	topoPb := &tpb.Topology{}
+	if err := prototext.Unmarshal(s.topos["adopted"], topoPb); err != nil {
+		t.Fatalf("invalid adopted topology: %v", err)
+	}
+	if got := topoPb.GetNodes()[0].GetName(); got != "r1" {
+		t.Errorf("recoverState() adopted node %q, want r1", got)
+	}
+	states, err := store.List(ctx, topologyState)
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]string{"adopted", "invalid", "recorded"}, keys(states)); s != "" {
+		t.Errorf("recoverState() unexpected recorded topologies (-want +got):\n%s", s)
+	}
+
+	// Without a cluster only the recorded state is recovered.
+	s = newServer(store)
+	if err := s.recoverState(ctx, nil); err != nil {
+		t.Fatalf("recoverState() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]string{"adopted", "recorded"}, keys(s.topos)); s != "" {
+		t.Errorf("recoverState() without cluster unexpected topologies (-want +got):\n%s", s)
+	}
+}
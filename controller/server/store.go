@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// stateKind is a kind of state recorded by the server.
+type stateKind string
+
+const (
+	// topologyState is the prototext of the topology protobuf of the
+	// topologies created by the server.
+	topologyState stateKind = "topology"
+	// clusterState is the prototext of the CreateClusterRequest of the
+	// clusters created by the server.
+	clusterState stateKind = "cluster"
+)
+
+// stateStore records the state of the server, so that the topologies and
+// clusters it created can still be managed after a restart.
+type stateStore interface {
+	// Put records value as the state of name, replacing any previous state.
+	Put(ctx context.Context, kind stateKind, name string, value []byte) error
+	// Delete removes the state of name. It is not an error if there is none.
+	Delete(ctx context.Context, kind stateKind, name string) error
+	// List returns the recorded states of kind by name.
+	List(ctx context.Context, kind stateKind) (map[string][]byte, error)
+}
+
+// fileStore records each state in a file of a local directory.
+type fileStore struct {
+	dir string
+}
+
+const stateFileExt = ".textproto"
+
+func newFileStore(dir string) *fileStore {
+	return &fileStore{dir: dir}
+}
+
+func (s *fileStore) path(kind stateKind, name string) string {
+	return filepath.Join(s.dir, string(kind), url.PathEscape(name)+stateFileExt)
+}
+
+// Put writes the state to a temporary file which replaces the previous
+// state, so the state is never partially written.
+func (s *fileStore) Put(_ context.Context, kind stateKind, name string, value []byte) error {
+	dir := filepath.Join(s.dir, string(kind))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(value); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write state of %s %q: %w", kind, name, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write state of %s %q: %w", kind, name, err)
+	}
+	if err := os.Rename(f.Name(), s.path(kind, name)); err != nil {
+		return fmt.Errorf("failed to write state of %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Delete(_ context.Context, kind stateKind, name string) error {
+	if err := os.Remove(s.path(kind, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete state of %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+func (s *fileStore) List(_ context.Context, kind stateKind) (map[string][]byte, error) {
+	dir := filepath.Join(s.dir, string(kind))
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		return map[string][]byte{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read state directory: %w", err)
+	}
+	states := map[string][]byte{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), stateFileExt) {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(e.Name(), stateFileExt))
+		if err != nil {
+			return nil, fmt.Errorf("invalid state file %q: %w", e.Name(), err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state of %s %q: %w", kind, name, err)
+		}
+		states[name] = b
+	}
+	return states, nil
+}
+
+// clusterStore records each state in a config map of a namespace of the
+// cluster, so that the state is shared by the replicas of a server running
+// in the cluster and survives the loss of the host of the server.
+type clusterStore struct {
+	kClient   kubernetes.Interface
+	namespace string
+}
+
+const (
+	// stateKindLabel is the label of the config maps of clusterStore with
+	// the kind of their state.
+	stateKindLabel = "kne-controller-state"
+	stateNameKey   = "name"
+	stateValueKey  = "value"
+)
+
+func newClusterStore(kClient kubernetes.Interface, namespace string) *clusterStore {
+	return &clusterStore{kClient: kClient, namespace: namespace}
+}
+
+// configMapName returns the name of the config map of a state. Names of
+// topologies and kind clusters are DNS labels, so they are valid in config
+// map names.
+func configMapName(kind stateKind, name string) string {
+	return fmt.Sprintf("%s-%s", kind, name)
+}
+
+func (s *clusterStore) createNamespace(ctx context.Context) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.namespace}}
+	if _, err := s.kClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create state namespace %q: %w", s.namespace, err)
+	}
+	return nil
+}
+
+func (s *clusterStore) Put(ctx context.Context, kind stateKind, name string, value []byte) error {
+	if err := s.createNamespace(ctx); err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   configMapName(kind, name),
+			Labels: map[string]string{stateKindLabel: string(kind)},
+		},
+		Data: map[string]string{
+			stateNameKey:  name,
+			stateValueKey: string(value),
+		},
+	}
+	cms := s.kClient.CoreV1().ConfigMaps(s.namespace)
+	_, err := cms.Create(ctx, cm, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write state of %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+func (s *clusterStore) Delete(ctx context.Context, kind stateKind, name string) error {
+	err := s.kClient.CoreV1().ConfigMaps(s.namespace).Delete(ctx, configMapName(kind, name), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete state of %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+func (s *clusterStore) List(ctx context.Context, kind stateKind) (map[string][]byte, error) {
+	cms, err := s.kClient.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", stateKindLabel, kind),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list states of %s: %w", kind, err)
+	}
+	states := map[string][]byte{}
+	for _, cm := range cms.Items {
+		states[cm.Data[stateNameKey]] = []byte(cm.Data[stateValueKey])
+	}
+	return states, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStateStore(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		desc  string
+		store func(t *testing.T) stateStore
+	}{{
+		desc: "file",
+		store: func(t *testing.T) stateStore {
+			return newFileStore(filepath.Join(t.TempDir(), "state"))
+		},
+	}, {
+		desc: "cluster",
+		store: func(t *testing.T) stateStore {
+			return newClusterStore(kfake.NewSimpleClientset(), "kne-controller")
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := tt.store(t)
+			got, err := s.List(ctx, topologyState)
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("List() of empty store got %v, want none", got)
+			}
+			for name, v := range map[string]string{"t1": "old", "t2": "t2"} {
+				if err := s.Put(ctx, topologyState, name, []byte(v)); err != nil {
+					t.Fatalf("Put(%q) unexpected error: %v", name, err)
+				}
+			}
+			if err := s.Put(ctx, topologyState, "t1", []byte("t1")); err != nil {
+				t.Fatalf("Put() of existing state unexpected error: %v", err)
+			}
+			if err := s.Put(ctx, clusterState, "kne", []byte("kne")); err != nil {
+				t.Fatalf("Put() unexpected error: %v", err)
+			}
+			if err := s.Delete(ctx, topologyState, "t2"); err != nil {
+				t.Fatalf("Delete() unexpected error: %v", err)
+			}
+			if err := s.Delete(ctx, topologyState, "missing"); err != nil {
+				t.Fatalf("Delete() of missing state unexpected error: %v", err)
+			}
+			for kind, want := range map[stateKind]map[string][]byte{
+				topologyState: {"t1": []byte("t1")},
+				clusterState:  {"kne": []byte("kne")},
+			} {
+				got, err := s.List(ctx, kind)
+				if err != nil {
+					t.Fatalf("List(%q) unexpected error: %v", kind, err)
+				}
+				if s := cmp.Diff(want, got); s != "" {
+					t.Errorf("List(%q) unexpected diff (-want +got):\n%s", kind, s)
+				}
+			}
+		})
+	}
+}
+
+func TestFileStoreEscapesNames(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newFileStore(dir)
+	name := "../escape"
+	if err := s.Put(ctx, clusterState, name, []byte("v")); err != nil {
+		t.Fatalf("Put() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape"+stateFileExt)); err == nil {
+		t.Errorf("Put() wrote state outside of its directory")
+	}
+	got, err := s.List(ctx, clusterState)
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if s := cmp.Diff(map[string][]byte{name: []byte("v")}, got); s != "" {
+		t.Errorf("List() unexpected diff (-want +got):\n%s", s)
+	}
+}
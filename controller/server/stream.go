@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"sync"
+
+	log "github.com/golang/glog"
+	cpb "github.com/openconfig/kne/proto/controller"
+)
+
+// progressSender sends the progress of an operation on a server stream. The
+// progress is reported by several goroutines, such as the pod and event
+// watchers, while a stream can only be sent on by one at a time.
+type progressSender struct {
+	mu   sync.Mutex
+	done bool
+	send func(*cpb.Progress) error
+}
+
+// progress sends p unless the operation finished. Failing to send progress,
+// such as when the client is gone, does not fail the operation.
+func (ps *progressSender) progress(p *cpb.Progress) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.done {
+		return
+	}
+	if err := ps.send(p); err != nil {
+		log.Warningf("Failed to send progress: %v", err)
+	}
+}
+
+// finish stops sending progress and returns the result of last, such as
+// sending the result of the operation.
+func (ps *progressSender) finish(last func() error) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.done = true
+	return last()
+}
+
+func (s *server) CreateTopologyStream(req *cpb.CreateTopologyRequest, stream cpb.TopologyManager_CreateTopologyStreamServer) error {
+	log.Infof("Received CreateTopologyStream request: %v", stripPasswords(req))
+	ps := &progressSender{send: func(p *cpb.Progress) error {
+		return stream.Send(&cpb.CreateTopologyProgress{Update: &cpb.CreateTopologyProgress_Progress{Progress: p}})
+	}}
+	resp, err := s.createTopology(stream.Context(), req, ps.progress)
+	return ps.finish(func() error {
+		if err != nil {
+			return err
+		}
+		return stream.Send(&cpb.CreateTopologyProgress{Update: &cpb.CreateTopologyProgress_Result{Result: resp}})
+	})
+}
+
+func (s *server) CreateClusterStream(req *cpb.CreateClusterRequest, stream cpb.TopologyManager_CreateClusterStreamServer) error {
+	log.Infof("Received CreateClusterStream request: %v", req)
+	ps := &progressSender{send: func(p *cpb.Progress) error {
+		return stream.Send(&cpb.CreateClusterProgress{Update: &cpb.CreateClusterProgress_Progress{Progress: p}})
+	}}
+	resp, err := s.createCluster(stream.Context(), req, ps.progress)
+	return ps.finish(func() error {
+		if err != nil {
+			return err
+		}
+		return stream.Send(&cpb.CreateClusterProgress{Update: &cpb.CreateClusterProgress_Result{Result: resp}})
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/kne/proto/controller"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStream[T any] struct {
+	grpc.ServerStream
+	sent []*T
+}
+
+func (f *fakeStream[T]) Context() context.Context { return context.Background() }
+
+func (f *fakeStream[T]) Send(m *T) error {
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+func TestProgressSender(t *testing.T) {
+	var sent []string
+	ps := &progressSender{send: func(p *cpb.Progress) error {
+		sent = append(sent, p.GetPhase())
+		return nil
+	}}
+	ps.progress(&cpb.Progress{Update: &cpb.Progress_Phase{Phase: "Creating nodes"}})
+	if err := ps.finish(func() error {
+		sent = append(sent, "result")
+		return nil
+	}); err != nil {
+		t.Fatalf("finish() unexpected error: %v", err)
+	}
+	ps.progress(&cpb.Progress{Update: &cpb.Progress_Phase{Phase: "Too late"}})
+	if s := cmp.Diff([]string{"Creating nodes", "result"}, sent); s != "" {
+		t.Errorf("progressSender sent unexpected messages (-want +got):\n%s", s)
+	}
+}
+
+func TestCreateTopologyStream(t *testing.T) {
+	s := newServer(newFileStore(t.TempDir()))
+	stream := &fakeStream[cpb.CreateTopologyProgress]{}
+	err := s.CreateTopologyStream(&cpb.CreateTopologyRequest{}, stream)
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("CreateTopologyStream() got error %v, want code %s", err, want)
+	}
+	if len(stream.sent) != 0 {
+		t.Errorf("CreateTopologyStream() sent %v on error, want nothing", stream.sent)
+	}
+}
+
+func TestCreateClusterStream(t *testing.T) {
+	s := newServer(newFileStore(t.TempDir()))
+	stream := &fakeStream[cpb.CreateClusterProgress]{}
+	err := s.CreateClusterStream(&cpb.CreateClusterRequest{}, stream)
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("CreateClusterStream() got error %v, want code %s", err, want)
+	}
+	if len(stream.sent) != 0 {
+		t.Errorf("CreateClusterStream() sent %v on error, want nothing", stream.sent)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/openconfig/kne/load"
 	"github.com/openconfig/kne/metrics"
 	"github.com/openconfig/kne/pods"
+	"github.com/openconfig/kne/progress"
 	epb "github.com/openconfig/kne/proto/event"
 	"github.com/pborman/uuid"
 	metallbv1 "go.universe.tf/metallb/api/v1beta1"
@@ -93,6 +94,9 @@ type Deployment struct {
 	// If Progress is true then deployment status updates will be sent to
 	// standard output.
 	Progress bool
+	// ProgressFunc, if set, is called with the phases, pod and container
+	// state changes and warning events of Deploy.
+	ProgressFunc progress.Func
 
 	// If ReportUsage is true then anonymous usage metrics will be
 	// published using Cloud PubSub.
@@ -194,6 +198,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 		return fmt.Errorf("failed to check for dependencies: %w", err)
 	}
 	log.Infof("Deploying cluster...")
+	d.ProgressFunc.Phase("Deploying cluster")
 	if err := d.Cluster.Deploy(ctx); err != nil {
 		return fmt.Errorf("failed to deploy cluster: %w", err)
 	}
@@ -230,6 +235,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 		log.Warningf("Failed to start pod watcher: %v", err)
 	} else {
 		w.SetProgress(d.Progress)
+		w.SetUpdateFunc(d.ProgressFunc.Pod)
 		defer func() {
 			cancel()
 			rerr = w.Cleanup(rerr)
@@ -240,6 +246,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 		log.Warningf("Failed to start event watcher: %v", err)
 	} else {
 		w.SetProgress(d.Progress)
+		w.SetWarningFunc(d.ProgressFunc.Event)
 		defer func() {
 			cancel()
 			rerr = w.Cleanup(rerr)
@@ -251,6 +258,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 	d.Ingress.SetDockerNetworkResourceName(d.Cluster.GetDockerNetworkResourceName())
 
 	log.Infof("Deploying ingress...")
+	d.ProgressFunc.Phase("Deploying ingress")
 	if err := d.Ingress.Deploy(ctx); err != nil {
 		return fmt.Errorf("failed to deploy ingress: %w", err)
 	}
@@ -261,6 +269,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 	}
 	log.Infof("Ingress healthy")
 	log.Infof("Deploying CNI...")
+	d.ProgressFunc.Phase("Deploying CNI")
 	if err := d.CNI.Deploy(ctx); err != nil {
 		return fmt.Errorf("failed to deploy CNI: %w", err)
 	}
@@ -271,6 +280,9 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 		return fmt.Errorf("failed to check if CNI is healthy: %w", err)
 	}
 	log.Infof("CNI healthy")
+	if len(d.Controllers) > 0 {
+		d.ProgressFunc.Phase("Deploying controllers")
+	}
 	for _, c := range d.Controllers {
 		log.Infof("Deploying controller...")
 		if err := c.Deploy(ctx); err != nil {
@@ -284,6 +296,7 @@ func (d *Deployment) Deploy(ctx context.Context, kubecfg string) (rerr error) {
 		}
 	}
 	log.Infof("Controllers deployed and healthy")
+	d.ProgressFunc.Phase("Cluster deployed")
 	return nil
 }
 
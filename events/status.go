@@ -25,8 +25,9 @@ type Watcher struct {
 	stdout      io.Writer
 	warningf    func(string, ...any)
 
-	mu       sync.Mutex
-	progress bool
+	mu        sync.Mutex
+	progress  bool
+	onWarning func(*EventStatus)
 }
 
 var errorMsgs = [2]string{"Insufficient memory", "Insufficient cpu"}
@@ -70,6 +71,14 @@ func (w *Watcher) SetProgress(value bool) {
 	w.mu.Unlock()
 }
 
+// SetWarningFunc sets a function called with the warning events seen while
+// watching, whether or not progress output is displayed.
+func (w *Watcher) SetWarningFunc(f func(*EventStatus)) {
+	w.mu.Lock()
+	w.onWarning = f
+	w.mu.Unlock()
+}
+
 func (w *Watcher) stop() {
 	w.mu.Lock()
 	stop := w.wstop
@@ -121,6 +130,14 @@ func (w *Watcher) display(format string, v ...any) {
 
 func (w *Watcher) isEventNormal(s *EventStatus) bool {
 	w.display("NS: %s Event name: %s Type: %s Message: %s", s.Namespace, s.Name, s.Type, s.Message)
+	if s.Type == EventWarning {
+		w.mu.Lock()
+		f := w.onWarning
+		w.mu.Unlock()
+		if f != nil {
+			f(s)
+		}
+	}
 
 	message := s.Message
 	for _, m := range errorMsgs {
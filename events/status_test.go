@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	"k8s.io/apimachinery/pkg/types"
 	kfake "k8s.io/client-go/kubernetes/fake"
@@ -309,3 +310,18 @@ func TestIsEventNormal(t *testing.T) {
 		})
 	}
 }
+
+func TestWarningFunc(t *testing.T) {
+	w := newWatcher(context.TODO(), func() {}, nil, func() {})
+	var got []string
+	w.SetWarningFunc(func(s *EventStatus) { got = append(got, s.Name) })
+	for _, s := range []*EventStatus{
+		{Name: "event1", UID: "uid1", Namespace: "ns1", Type: EventNormal, Message: "normal event"},
+		{Name: "event2", UID: "uid2", Namespace: "ns1", Type: EventWarning, Message: "Back-off pulling image"},
+	} {
+		w.isEventNormal(s)
+	}
+	if want := []string{"event2"}; !cmp.Equal(want, got) {
+		t.Errorf("isEventNormal() reported warnings %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// kne-node-linux is the reference KNE node plugin. It runs nodes as plain
+// Linux containers and pushes config by writing the config file of the node
+// in its container. Pod creation, deletion, status and the meshnet topology
+// are left to KNE.
+//
+// Install it in a plugin directory and select it with the plugin field of a
+// node:
+//
+//	go build -o ~/.config/kne/plugins/ ./examples/plugin/kne-node-linux
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	ppb "github.com/openconfig/kne/proto/plugin"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node/plugin"
+	"google.golang.org/protobuf/proto"
+)
+
+const name = "linux"
+
+var (
+	defaultNode = tpb.Node{
+		Config: &tpb.Config{
+			Image:      "alpine:latest",
+			Command:    []string{"/bin/sh", "-c", "sleep infinity"},
+			ConfigPath: "/etc",
+			ConfigFile: "config",
+		},
+	}
+	kubectl = "kubectl"
+)
+
+func defaults(_ context.Context, req *ppb.Request) (*ppb.Response, error) {
+	n := proto.Clone(req.GetNode()).(*tpb.Node)
+	def := proto.Clone(&defaultNode).(*tpb.Node)
+	if n.Config == nil {
+		n.Config = &tpb.Config{}
+	}
+	if n.Config.Image == "" {
+		n.Config.Image = def.Config.Image
+	}
+	if len(n.Config.Command) == 0 {
+		n.Config.Command = def.Config.Command
+	}
+	if n.Config.ConfigPath == "" {
+		n.Config.ConfigPath = def.Config.ConfigPath
+	}
+	if n.Config.ConfigFile == "" {
+		n.Config.ConfigFile = def.Config.ConfigFile
+	}
+	if n.Config.EntryCommand == "" {
+		n.Config.EntryCommand = fmt.Sprintf("kubectl exec -it %s -- sh", n.GetName())
+	}
+	return &ppb.Response{Node: n}, nil
+}
+
+// writeConfig writes cfg to the config file of the node in its container.
+func writeConfig(ctx context.Context, req *ppb.Request, cfg []byte) error {
+	n := req.GetNode()
+	var args []string
+	if req.GetKubecfg() != "" {
+		args = append(args, "--kubeconfig", req.GetKubecfg())
+	}
+	path := filepath.Join(n.GetConfig().GetConfigPath(), n.GetConfig().GetConfigFile())
+	args = append(args, "exec", "-i", "-n", req.GetNamespace(), n.GetName(), "--", "sh", "-c", `cat > "$0"`, path)
+	cmd := exec.CommandContext(ctx, kubectl, args...)
+	cmd.Stdin = bytes.NewReader(cfg)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to write %s on node %q: %w", path, n.GetName(), err)
+	}
+	return nil
+}
+
+func configPush(ctx context.Context, req *ppb.Request) (*ppb.Response, error) {
+	return &ppb.Response{}, writeConfig(ctx, req, req.GetConfig())
+}
+
+// resetCfg writes the startup config of the node back, if any.
+func resetCfg(ctx context.Context, req *ppb.Request) (*ppb.Response, error) {
+	cfg := req.GetNode().GetConfig()
+	var b []byte
+	switch {
+	case cfg.GetData() != nil:
+		b = cfg.GetData()
+	case cfg.GetFile() != "":
+		var err error
+		if b, err = os.ReadFile(filepath.Join(req.GetBasePath(), cfg.GetFile())); err != nil {
+			return nil, err
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "no startup config for node %q, skipping reset\n", req.GetNode().GetName())
+		return &ppb.Response{}, nil
+	}
+	return &ppb.Response{}, writeConfig(ctx, req, b)
+}
+
+func main() {
+	plugin.Serve(name, plugin.Handlers{
+		ppb.Method_DEFAULTS:    defaults,
+		ppb.Method_CONFIG_PUSH: configPush,
+		ppb.Method_RESET_CFG:   resetCfg,
+	})
+}
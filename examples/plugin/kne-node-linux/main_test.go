@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/kne/topo/node/plugin"
+	"github.com/openconfig/kne/topo/node/plugin/conformance"
+)
+
+const serveEnv = "KNE_NODE_LINUX_SERVE"
+
+func TestMain(m *testing.M) {
+	// The test binary acts as the plugin when run by the conformance suite.
+	if os.Getenv(serveEnv) != "" {
+		main()
+	}
+	os.Exit(m.Run())
+}
+
+func TestConformance(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to get test executable: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), plugin.Prefix+name)
+	if err := os.Symlink(exe, path); err != nil {
+		t.Fatalf("failed to link plugin: %v", err)
+	}
+	t.Setenv(serveEnv, "1")
+	conformance.Run(t, path, nil)
+}
@@ -33,6 +33,7 @@ type Watcher struct {
 
 	mu               sync.Mutex
 	progress         bool
+	onUpdate         func(*Update)
 	currentNamespace string
 	currentPod       types.UID
 }
@@ -78,6 +79,32 @@ func (w *Watcher) SetProgress(value bool) {
 	w.mu.Unlock()
 }
 
+// An Update is a state change of a pod or of one of its containers.
+type Update struct {
+	Namespace string
+	Pod       string
+	Container string // empty if the state of the pod changed
+	State     string
+	Message   string
+}
+
+// SetUpdateFunc sets a function called with the state changes displayed
+// while watching, whether or not progress output is displayed.
+func (w *Watcher) SetUpdateFunc(f func(*Update)) {
+	w.mu.Lock()
+	w.onUpdate = f
+	w.mu.Unlock()
+}
+
+func (w *Watcher) update(u *Update) {
+	w.mu.Lock()
+	f := w.onUpdate
+	w.mu.Unlock()
+	if f != nil {
+		f(u)
+	}
+}
+
 func (w *Watcher) stop() {
 	w.mu.Lock()
 	stop := w.wstop
@@ -168,12 +195,14 @@ func (w *Watcher) updatePod(s *PodStatus) bool {
 			showPodState(s, "", "")
 			w.cStates[id] = state
 			w.display("         CONTAINER: %s is now %s", c.Name, state)
+			w.update(&Update{Namespace: s.Namespace, Pod: s.Name, Container: c.Name, State: state, Message: c.Message})
 		}
 	}
 
 	if oldState := w.podStates[s.UID]; oldState != newState {
 		showPodState(s, oldState, newState)
 		w.podStates[s.UID] = newState
+		w.update(&Update{Namespace: s.Namespace, Pod: s.Name, State: newState})
 	}
 	if newState == "failed" {
 		w.errCh <- fmt.Errorf("Pod %s failed to deploy", s.Name)
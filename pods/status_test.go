@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	"k8s.io/apimachinery/pkg/types"
 	kfake "k8s.io/client-go/kubernetes/fake"
@@ -427,3 +428,25 @@ func TestNewWatcher(t *testing.T) {
 		t.Errorf("Watcher did not make cStates")
 	}
 }
+
+func TestUpdateFunc(t *testing.T) {
+	w := newWatcher(context.TODO(), func() {}, nil, func() {})
+	var got []Update
+	w.SetUpdateFunc(func(u *Update) { got = append(got, *u) })
+	for _, s := range []*PodStatus{
+		{Name: "pod1", UID: "uid1", Namespace: "ns1", Phase: PodPending, Containers: []ContainerStatus{{Name: "c1", Reason: "ErrImagePull", Message: "pulling"}}},
+		{Name: "pod1", UID: "uid1", Namespace: "ns1", Phase: PodPending, Containers: []ContainerStatus{{Name: "c1", Reason: "ErrImagePull", Message: "pulling"}}},
+		{Name: "pod1", UID: "uid1", Namespace: "ns1", Phase: PodRunning, Ready: true, Containers: []ContainerStatus{{Name: "c1", Ready: true}}},
+	} {
+		w.updatePod(s)
+	}
+	want := []Update{
+		{Namespace: "ns1", Pod: "pod1", State: "pending"},
+		{Namespace: "ns1", Pod: "pod1", Container: "c1", State: "ErrImagePull", Message: "pulling"},
+		{Namespace: "ns1", Pod: "pod1", State: "READY"},
+		{Namespace: "ns1", Pod: "pod1", Container: "c1", State: "READY"},
+	}
+	if s := cmp.Diff(want, got); s != "" {
+		t.Errorf("updatePod() unexpected updates (-want +got):\n%s", s)
+	}
+}
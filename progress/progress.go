@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package progress reports the progress of long running operations, such as
+// creating a topology or deploying a cluster, as controller Progress
+// messages.
+package progress
+
+import (
+	"fmt"
+
+	"github.com/openconfig/kne/events"
+	"github.com/openconfig/kne/pods"
+	cpb "github.com/openconfig/kne/proto/controller"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A Func is called with each update of the progress of an operation. A nil
+// Func discards the updates. A Func may be called concurrently.
+type Func func(*cpb.Progress)
+
+var now = timestamppb.Now
+
+func (f Func) report(p *cpb.Progress) {
+	if f == nil {
+		return
+	}
+	p.Time = now()
+	f(p)
+}
+
+// Phase reports that the operation entered a phase, such as "Creating nodes".
+func (f Func) Phase(format string, v ...any) {
+	f.report(&cpb.Progress{Update: &cpb.Progress_Phase{Phase: fmt.Sprintf(format, v...)}})
+}
+
+// Pod reports a state change of a pod or of one of its containers.
+func (f Func) Pod(u *pods.Update) {
+	f.report(&cpb.Progress{Update: &cpb.Progress_Pod{Pod: &cpb.PodProgress{
+		Namespace: u.Namespace,
+		Pod:       u.Pod,
+		Container: u.Container,
+		State:     u.State,
+		Message:   u.Message,
+	}}})
+}
+
+// Event reports a warning event.
+func (f Func) Event(s *events.EventStatus) {
+	e := &cpb.EventProgress{
+		Namespace: s.Namespace,
+		Name:      s.Name,
+		Reason:    s.Event.Reason,
+		Message:   s.Message,
+	}
+	if o := s.Event.InvolvedObject; o.Name != "" {
+		e.Object = fmt.Sprintf("%s/%s", o.Kind, o.Name)
+	}
+	f.report(&cpb.Progress{Update: &cpb.Progress_Event{Event: e}})
+}
+
+// Node reports a status change of a node.
+func (f Func) Node(s *cpb.NodeStatus) {
+	f.report(&cpb.Progress{Update: &cpb.Progress_Node{Node: s}})
+}
@@ -0,0 +1,83 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/kne/events"
+	"github.com/openconfig/kne/pods"
+	cpb "github.com/openconfig/kne/proto/controller"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFunc(t *testing.T) {
+	ts := timestamppb.Now()
+	origNow := now
+	defer func() {
+		now = origNow
+	}()
+	now = func() *timestamppb.Timestamp { return ts }
+
+	tests := []struct {
+		desc   string
+		report func(Func)
+		want   *cpb.Progress
+	}{{
+		desc:   "phase",
+		report: func(f Func) { f.Phase("Creating %s", "nodes") },
+		want:   &cpb.Progress{Time: ts, Update: &cpb.Progress_Phase{Phase: "Creating nodes"}},
+	}, {
+		desc: "pod",
+		report: func(f Func) {
+			f.Pod(&pods.Update{Namespace: "ns1", Pod: "r1", Container: "r1", State: "ErrImagePull", Message: "not found"})
+		},
+		want: &cpb.Progress{Time: ts, Update: &cpb.Progress_Pod{Pod: &cpb.PodProgress{
+			Namespace: "ns1",
+			Pod:       "r1",
+			Container: "r1",
+			State:     "ErrImagePull",
+			Message:   "not found",
+		}}},
+	}, {
+		desc: "event",
+		report: func(f Func) {
+			f.Event(&events.EventStatus{
+				Name:      "r1.1",
+				Namespace: "ns1",
+				Message:   "0/1 nodes are available",
+				Type:      events.EventWarning,
+				Event: corev1.Event{
+					Reason:         "FailedScheduling",
+					InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "r1"},
+				},
+			})
+		},
+		want: &cpb.Progress{Time: ts, Update: &cpb.Progress_Event{Event: &cpb.EventProgress{
+			Namespace: "ns1",
+			Name:      "r1.1",
+			Reason:    "FailedScheduling",
+			Message:   "0/1 nodes are available",
+			Object:    "Pod/r1",
+		}}},
+	}, {
+		desc:   "node",
+		report: func(f Func) { f.Node(&cpb.NodeStatus{Name: "r1", State: cpb.NodeState_NODE_STATE_RUNNING}) },
+		want: &cpb.Progress{Time: ts, Update: &cpb.Progress_Node{Node: &cpb.NodeStatus{
+			Name:  "r1",
+			State: cpb.NodeState_NODE_STATE_RUNNING,
+		}}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []*cpb.Progress
+			tt.report(func(p *cpb.Progress) { got = append(got, p) })
+			if s := cmp.Diff([]*cpb.Progress{tt.want}, got, protocmp.Transform()); s != "" {
+				t.Errorf("unexpected progress (-want +got):\n%s", s)
+			}
+			// A nil Func discards the progress.
+			tt.report(nil)
+		})
+	}
+}
@@ -33,16 +33,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TopologyManager_CreateTopology_FullMethodName = "/controller.TopologyManager/CreateTopology"
-	TopologyManager_DeleteTopology_FullMethodName = "/controller.TopologyManager/DeleteTopology"
-	TopologyManager_ShowTopology_FullMethodName   = "/controller.TopologyManager/ShowTopology"
-	TopologyManager_CreateCluster_FullMethodName  = "/controller.TopologyManager/CreateCluster"
-	TopologyManager_DeleteCluster_FullMethodName  = "/controller.TopologyManager/DeleteCluster"
-	TopologyManager_ShowCluster_FullMethodName    = "/controller.TopologyManager/ShowCluster"
-	TopologyManager_PushConfig_FullMethodName     = "/controller.TopologyManager/PushConfig"
-	TopologyManager_ResetConfig_FullMethodName    = "/controller.TopologyManager/ResetConfig"
-	TopologyManager_ApplyCluster_FullMethodName   = "/controller.TopologyManager/ApplyCluster"
-	TopologyManager_JoinCluster_FullMethodName    = "/controller.TopologyManager/JoinCluster"
+	TopologyManager_CreateTopology_FullMethodName       = "/controller.TopologyManager/CreateTopology"
+	TopologyManager_DeleteTopology_FullMethodName       = "/controller.TopologyManager/DeleteTopology"
+	TopologyManager_ShowTopology_FullMethodName         = "/controller.TopologyManager/ShowTopology"
+	TopologyManager_CreateCluster_FullMethodName        = "/controller.TopologyManager/CreateCluster"
+	TopologyManager_DeleteCluster_FullMethodName        = "/controller.TopologyManager/DeleteCluster"
+	TopologyManager_ShowCluster_FullMethodName          = "/controller.TopologyManager/ShowCluster"
+	TopologyManager_PushConfig_FullMethodName           = "/controller.TopologyManager/PushConfig"
+	TopologyManager_ResetConfig_FullMethodName          = "/controller.TopologyManager/ResetConfig"
+	TopologyManager_ApplyCluster_FullMethodName         = "/controller.TopologyManager/ApplyCluster"
+	TopologyManager_JoinCluster_FullMethodName          = "/controller.TopologyManager/JoinCluster"
+	TopologyManager_ListOperations_FullMethodName       = "/controller.TopologyManager/ListOperations"
+	TopologyManager_RebootDevice_FullMethodName         = "/controller.TopologyManager/RebootDevice"
+	TopologyManager_PutFile_FullMethodName              = "/controller.TopologyManager/PutFile"
+	TopologyManager_GetFile_FullMethodName              = "/controller.TopologyManager/GetFile"
+	TopologyManager_InstallCertificate_FullMethodName   = "/controller.TopologyManager/InstallCertificate"
+	TopologyManager_PingDevice_FullMethodName           = "/controller.TopologyManager/PingDevice"
+	TopologyManager_CreateTopologyStream_FullMethodName = "/controller.TopologyManager/CreateTopologyStream"
+	TopologyManager_CreateClusterStream_FullMethodName  = "/controller.TopologyManager/CreateClusterStream"
 )
 
 // TopologyManagerClient is the client API for TopologyManager service.
@@ -71,6 +79,25 @@ type TopologyManagerClient interface {
 	ApplyCluster(ctx context.Context, in *ApplyClusterRequest, opts ...grpc.CallOption) (*ApplyClusterResponse, error)
 	// Joins host into an existing Kubeadm cluster.
 	JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*JoinClusterResponse, error)
+	// Lists the operations supported by a device in a topology.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// Reboots a device in a topology.
+	RebootDevice(ctx context.Context, in *RebootDeviceRequest, opts ...grpc.CallOption) (*RebootDeviceResponse, error)
+	// Writes a file on a device in a topology.
+	PutFile(ctx context.Context, in *PutFileRequest, opts ...grpc.CallOption) (*PutFileResponse, error)
+	// Reads a file from a device in a topology.
+	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error)
+	// Installs a certificate issued by the topology CA on a device in a
+	// topology.
+	InstallCertificate(ctx context.Context, in *InstallCertificateRequest, opts ...grpc.CallOption) (*InstallCertificateResponse, error)
+	// Pings a destination from a device in a topology.
+	PingDevice(ctx context.Context, in *PingDeviceRequest, opts ...grpc.CallOption) (*PingDeviceResponse, error)
+	// Creates a topology like CreateTopology, streaming its progress. The last
+	// message carries the result.
+	CreateTopologyStream(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateTopologyProgress], error)
+	// Creates a cluster like CreateCluster, streaming its progress. The last
+	// message carries the result.
+	CreateClusterStream(ctx context.Context, in *CreateClusterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateClusterProgress], error)
 }
 
 type topologyManagerClient struct {
@@ -181,6 +208,104 @@ func (c *topologyManagerClient) JoinCluster(ctx context.Context, in *JoinCluster
 	return out, nil
 }
 
+func (c *topologyManagerClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) RebootDevice(ctx context.Context, in *RebootDeviceRequest, opts ...grpc.CallOption) (*RebootDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebootDeviceResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_RebootDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) PutFile(ctx context.Context, in *PutFileRequest, opts ...grpc.CallOption) (*PutFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutFileResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_PutFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFileResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_GetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) InstallCertificate(ctx context.Context, in *InstallCertificateRequest, opts ...grpc.CallOption) (*InstallCertificateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InstallCertificateResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_InstallCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) PingDevice(ctx context.Context, in *PingDeviceRequest, opts ...grpc.CallOption) (*PingDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingDeviceResponse)
+	err := c.cc.Invoke(ctx, TopologyManager_PingDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) CreateTopologyStream(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateTopologyProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TopologyManager_ServiceDesc.Streams[0], TopologyManager_CreateTopologyStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateTopologyRequest, CreateTopologyProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TopologyManager_CreateTopologyStreamClient = grpc.ServerStreamingClient[CreateTopologyProgress]
+
+func (c *topologyManagerClient) CreateClusterStream(ctx context.Context, in *CreateClusterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CreateClusterProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TopologyManager_ServiceDesc.Streams[1], TopologyManager_CreateClusterStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateClusterRequest, CreateClusterProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TopologyManager_CreateClusterStreamClient = grpc.ServerStreamingClient[CreateClusterProgress]
+
 // TopologyManagerServer is the server API for TopologyManager service.
 // All implementations must embed UnimplementedTopologyManagerServer
 // for forward compatibility.
@@ -207,6 +332,25 @@ type TopologyManagerServer interface {
 	ApplyCluster(context.Context, *ApplyClusterRequest) (*ApplyClusterResponse, error)
 	// Joins host into an existing Kubeadm cluster.
 	JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error)
+	// Lists the operations supported by a device in a topology.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// Reboots a device in a topology.
+	RebootDevice(context.Context, *RebootDeviceRequest) (*RebootDeviceResponse, error)
+	// Writes a file on a device in a topology.
+	PutFile(context.Context, *PutFileRequest) (*PutFileResponse, error)
+	// Reads a file from a device in a topology.
+	GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error)
+	// Installs a certificate issued by the topology CA on a device in a
+	// topology.
+	InstallCertificate(context.Context, *InstallCertificateRequest) (*InstallCertificateResponse, error)
+	// Pings a destination from a device in a topology.
+	PingDevice(context.Context, *PingDeviceRequest) (*PingDeviceResponse, error)
+	// Creates a topology like CreateTopology, streaming its progress. The last
+	// message carries the result.
+	CreateTopologyStream(*CreateTopologyRequest, grpc.ServerStreamingServer[CreateTopologyProgress]) error
+	// Creates a cluster like CreateCluster, streaming its progress. The last
+	// message carries the result.
+	CreateClusterStream(*CreateClusterRequest, grpc.ServerStreamingServer[CreateClusterProgress]) error
 	mustEmbedUnimplementedTopologyManagerServer()
 }
 
@@ -247,6 +391,30 @@ func (UnimplementedTopologyManagerServer) ApplyCluster(context.Context, *ApplyCl
 func (UnimplementedTopologyManagerServer) JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JoinCluster not implemented")
 }
+func (UnimplementedTopologyManagerServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedTopologyManagerServer) RebootDevice(context.Context, *RebootDeviceRequest) (*RebootDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebootDevice not implemented")
+}
+func (UnimplementedTopologyManagerServer) PutFile(context.Context, *PutFileRequest) (*PutFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutFile not implemented")
+}
+func (UnimplementedTopologyManagerServer) GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedTopologyManagerServer) InstallCertificate(context.Context, *InstallCertificateRequest) (*InstallCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallCertificate not implemented")
+}
+func (UnimplementedTopologyManagerServer) PingDevice(context.Context, *PingDeviceRequest) (*PingDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingDevice not implemented")
+}
+func (UnimplementedTopologyManagerServer) CreateTopologyStream(*CreateTopologyRequest, grpc.ServerStreamingServer[CreateTopologyProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateTopologyStream not implemented")
+}
+func (UnimplementedTopologyManagerServer) CreateClusterStream(*CreateClusterRequest, grpc.ServerStreamingServer[CreateClusterProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateClusterStream not implemented")
+}
 func (UnimplementedTopologyManagerServer) mustEmbedUnimplementedTopologyManagerServer() {}
 func (UnimplementedTopologyManagerServer) testEmbeddedByValue()                         {}
 
@@ -448,6 +616,136 @@ func _TopologyManager_JoinCluster_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TopologyManager_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_RebootDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebootDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).RebootDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_RebootDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).RebootDevice(ctx, req.(*RebootDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_PutFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).PutFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_PutFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).PutFile(ctx, req.(*PutFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).GetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_GetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).GetFile(ctx, req.(*GetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_InstallCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).InstallCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_InstallCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).InstallCertificate(ctx, req.(*InstallCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_PingDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).PingDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopologyManager_PingDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).PingDevice(ctx, req.(*PingDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_CreateTopologyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateTopologyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TopologyManagerServer).CreateTopologyStream(m, &grpc.GenericServerStream[CreateTopologyRequest, CreateTopologyProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TopologyManager_CreateTopologyStreamServer = grpc.ServerStreamingServer[CreateTopologyProgress]
+
+func _TopologyManager_CreateClusterStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateClusterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TopologyManagerServer).CreateClusterStream(m, &grpc.GenericServerStream[CreateClusterRequest, CreateClusterProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TopologyManager_CreateClusterStreamServer = grpc.ServerStreamingServer[CreateClusterProgress]
+
 // TopologyManager_ServiceDesc is the grpc.ServiceDesc for TopologyManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,7 +793,42 @@ var TopologyManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "JoinCluster",
 			Handler:    _TopologyManager_JoinCluster_Handler,
 		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _TopologyManager_ListOperations_Handler,
+		},
+		{
+			MethodName: "RebootDevice",
+			Handler:    _TopologyManager_RebootDevice_Handler,
+		},
+		{
+			MethodName: "PutFile",
+			Handler:    _TopologyManager_PutFile_Handler,
+		},
+		{
+			MethodName: "GetFile",
+			Handler:    _TopologyManager_GetFile_Handler,
+		},
+		{
+			MethodName: "InstallCertificate",
+			Handler:    _TopologyManager_InstallCertificate_Handler,
+		},
+		{
+			MethodName: "PingDevice",
+			Handler:    _TopologyManager_PingDevice_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateTopologyStream",
+			Handler:       _TopologyManager_CreateTopologyStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CreateClusterStream",
+			Handler:       _TopologyManager_CreateClusterStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller.proto",
 }
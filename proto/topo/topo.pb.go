@@ -57,6 +57,8 @@ const (
 	Vendor_IN_CLUSTER_PROXY Vendor = 14
 	Vendor_SONIC            Vendor = 15
 	Vendor_CIENA            Vendor = 16
+	// Data driven vendor configured by the generic.GenericConfig vendor data.
+	Vendor_GENERIC Vendor = 17
 )
 
 // Enum value maps for Vendor.
@@ -79,6 +81,7 @@ var (
 		14: "IN_CLUSTER_PROXY",
 		15: "SONIC",
 		16: "CIENA",
+		17: "GENERIC",
 	}
 	Vendor_value = map[string]int32{
 		"UNKNOWN":          0,
@@ -98,6 +101,7 @@ var (
 		"IN_CLUSTER_PROXY": 14,
 		"SONIC":            15,
 		"CIENA":            16,
+		"GENERIC":          17,
 	}
 )
 
@@ -128,6 +132,102 @@ func (Vendor) EnumDescriptor() ([]byte, []int) {
 	return file_topo_proto_rawDescGZIP(), []int{0}
 }
 
+// Protocol of a service port.
+type Protocol int32
+
+const (
+	Protocol_TCP  Protocol = 0
+	Protocol_UDP  Protocol = 1
+	Protocol_SCTP Protocol = 2
+)
+
+// Enum value maps for Protocol.
+var (
+	Protocol_name = map[int32]string{
+		0: "TCP",
+		1: "UDP",
+		2: "SCTP",
+	}
+	Protocol_value = map[string]int32{
+		"TCP":  0,
+		"UDP":  1,
+		"SCTP": 2,
+	}
+)
+
+func (x Protocol) Enum() *Protocol {
+	p := new(Protocol)
+	*p = x
+	return p
+}
+
+func (x Protocol) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Protocol) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[1].Descriptor()
+}
+
+func (Protocol) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[1]
+}
+
+func (x Protocol) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Protocol.Descriptor instead.
+func (Protocol) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{1}
+}
+
+type PodPatch_Type int32
+
+const (
+	PodPatch_STRATEGIC_MERGE PodPatch_Type = 0 // Kubernetes strategic merge patch.
+	PodPatch_JSON            PodPatch_Type = 1 // RFC 6902 JSON patch.
+)
+
+// Enum value maps for PodPatch_Type.
+var (
+	PodPatch_Type_name = map[int32]string{
+		0: "STRATEGIC_MERGE",
+		1: "JSON",
+	}
+	PodPatch_Type_value = map[string]int32{
+		"STRATEGIC_MERGE": 0,
+		"JSON":            1,
+	}
+)
+
+func (x PodPatch_Type) Enum() *PodPatch_Type {
+	p := new(PodPatch_Type)
+	*p = x
+	return p
+}
+
+func (x PodPatch_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PodPatch_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[2].Descriptor()
+}
+
+func (PodPatch_Type) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[2]
+}
+
+func (x PodPatch_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PodPatch_Type.Descriptor instead.
+func (PodPatch_Type) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{3, 0}
+}
+
 type Node_Type int32
 
 const (
@@ -203,11 +303,11 @@ func (x Node_Type) String() string {
 }
 
 func (Node_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_topo_proto_enumTypes[1].Descriptor()
+	return file_topo_proto_enumTypes[3].Descriptor()
 }
 
 func (Node_Type) Type() protoreflect.EnumType {
-	return &file_topo_proto_enumTypes[1]
+	return &file_topo_proto_enumTypes[3]
 }
 
 func (x Node_Type) Number() protoreflect.EnumNumber {
@@ -216,7 +316,111 @@ func (x Node_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Node_Type.Descriptor instead.
 func (Node_Type) EnumDescriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{1, 0}
+	return file_topo_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type LabelRequirement_Operator int32
+
+const (
+	LabelRequirement_IN             LabelRequirement_Operator = 0
+	LabelRequirement_NOT_IN         LabelRequirement_Operator = 1
+	LabelRequirement_EXISTS         LabelRequirement_Operator = 2
+	LabelRequirement_DOES_NOT_EXIST LabelRequirement_Operator = 3
+	LabelRequirement_GT             LabelRequirement_Operator = 4 // Only valid for node affinity.
+	LabelRequirement_LT             LabelRequirement_Operator = 5 // Only valid for node affinity.
+)
+
+// Enum value maps for LabelRequirement_Operator.
+var (
+	LabelRequirement_Operator_name = map[int32]string{
+		0: "IN",
+		1: "NOT_IN",
+		2: "EXISTS",
+		3: "DOES_NOT_EXIST",
+		4: "GT",
+		5: "LT",
+	}
+	LabelRequirement_Operator_value = map[string]int32{
+		"IN":             0,
+		"NOT_IN":         1,
+		"EXISTS":         2,
+		"DOES_NOT_EXIST": 3,
+		"GT":             4,
+		"LT":             5,
+	}
+)
+
+func (x LabelRequirement_Operator) Enum() *LabelRequirement_Operator {
+	p := new(LabelRequirement_Operator)
+	*p = x
+	return p
+}
+
+func (x LabelRequirement_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LabelRequirement_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[4].Descriptor()
+}
+
+func (LabelRequirement_Operator) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[4]
+}
+
+func (x LabelRequirement_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LabelRequirement_Operator.Descriptor instead.
+func (LabelRequirement_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type Toleration_Operator int32
+
+const (
+	Toleration_EQUAL  Toleration_Operator = 0
+	Toleration_EXISTS Toleration_Operator = 1
+)
+
+// Enum value maps for Toleration_Operator.
+var (
+	Toleration_Operator_name = map[int32]string{
+		0: "EQUAL",
+		1: "EXISTS",
+	}
+	Toleration_Operator_value = map[string]int32{
+		"EQUAL":  0,
+		"EXISTS": 1,
+	}
+)
+
+func (x Toleration_Operator) Enum() *Toleration_Operator {
+	p := new(Toleration_Operator)
+	*p = x
+	return p
+}
+
+func (x Toleration_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Toleration_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[5].Descriptor()
+}
+
+func (Toleration_Operator) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[5]
+}
+
+func (x Toleration_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Toleration_Operator.Descriptor instead.
+func (Toleration_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type Interface_InterfaceType int32
@@ -255,11 +459,11 @@ func (x Interface_InterfaceType) String() string {
 }
 
 func (Interface_InterfaceType) Descriptor() protoreflect.EnumDescriptor {
-	return file_topo_proto_enumTypes[2].Descriptor()
+	return file_topo_proto_enumTypes[6].Descriptor()
 }
 
 func (Interface_InterfaceType) Type() protoreflect.EnumType {
-	return &file_topo_proto_enumTypes[2]
+	return &file_topo_proto_enumTypes[6]
 }
 
 func (x Interface_InterfaceType) Number() protoreflect.EnumNumber {
@@ -268,7 +472,200 @@ func (x Interface_InterfaceType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Interface_InterfaceType.Descriptor instead.
 func (Interface_InterfaceType) EnumDescriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{5, 0}
+	return file_topo_proto_rawDescGZIP(), []int{17, 0}
+}
+
+type Mirror_Direction int32
+
+const (
+	Mirror_BOTH    Mirror_Direction = 0
+	Mirror_INGRESS Mirror_Direction = 1 // Traffic received on the source interfaces.
+	Mirror_EGRESS  Mirror_Direction = 2 // Traffic sent on the source interfaces.
+)
+
+// Enum value maps for Mirror_Direction.
+var (
+	Mirror_Direction_name = map[int32]string{
+		0: "BOTH",
+		1: "INGRESS",
+		2: "EGRESS",
+	}
+	Mirror_Direction_value = map[string]int32{
+		"BOTH":    0,
+		"INGRESS": 1,
+		"EGRESS":  2,
+	}
+)
+
+func (x Mirror_Direction) Enum() *Mirror_Direction {
+	p := new(Mirror_Direction)
+	*p = x
+	return p
+}
+
+func (x Mirror_Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mirror_Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[7].Descriptor()
+}
+
+func (Mirror_Direction) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[7]
+}
+
+func (x Mirror_Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mirror_Direction.Descriptor instead.
+func (Mirror_Direction) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{20, 0}
+}
+
+type GNMIOptions_Encoding int32
+
+const (
+	GNMIOptions_JSON_IETF GNMIOptions_Encoding = 0
+	GNMIOptions_JSON      GNMIOptions_Encoding = 1
+)
+
+// Enum value maps for GNMIOptions_Encoding.
+var (
+	GNMIOptions_Encoding_name = map[int32]string{
+		0: "JSON_IETF",
+		1: "JSON",
+	}
+	GNMIOptions_Encoding_value = map[string]int32{
+		"JSON_IETF": 0,
+		"JSON":      1,
+	}
+)
+
+func (x GNMIOptions_Encoding) Enum() *GNMIOptions_Encoding {
+	p := new(GNMIOptions_Encoding)
+	*p = x
+	return p
+}
+
+func (x GNMIOptions_Encoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GNMIOptions_Encoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[8].Descriptor()
+}
+
+func (GNMIOptions_Encoding) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[8]
+}
+
+func (x GNMIOptions_Encoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GNMIOptions_Encoding.Descriptor instead.
+func (GNMIOptions_Encoding) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{22, 0}
+}
+
+type PersistentVolume_Policy int32
+
+const (
+	// The volume is deleted with the topology.
+	PersistentVolume_DELETE PersistentVolume_Policy = 0
+	// The volume is kept when the topology is deleted and reattached to the
+	// node when the topology is created again.
+	PersistentVolume_RETAIN PersistentVolume_Policy = 1
+)
+
+// Enum value maps for PersistentVolume_Policy.
+var (
+	PersistentVolume_Policy_name = map[int32]string{
+		0: "DELETE",
+		1: "RETAIN",
+	}
+	PersistentVolume_Policy_value = map[string]int32{
+		"DELETE": 0,
+		"RETAIN": 1,
+	}
+)
+
+func (x PersistentVolume_Policy) Enum() *PersistentVolume_Policy {
+	p := new(PersistentVolume_Policy)
+	*p = x
+	return p
+}
+
+func (x PersistentVolume_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PersistentVolume_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[9].Descriptor()
+}
+
+func (PersistentVolume_Policy) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[9]
+}
+
+func (x PersistentVolume_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PersistentVolume_Policy.Descriptor instead.
+func (PersistentVolume_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{23, 0}
+}
+
+type ServiceOptions_Type int32
+
+const (
+	ServiceOptions_LOAD_BALANCER ServiceOptions_Type = 0
+	ServiceOptions_NODE_PORT     ServiceOptions_Type = 1
+	ServiceOptions_CLUSTER_IP    ServiceOptions_Type = 2
+)
+
+// Enum value maps for ServiceOptions_Type.
+var (
+	ServiceOptions_Type_name = map[int32]string{
+		0: "LOAD_BALANCER",
+		1: "NODE_PORT",
+		2: "CLUSTER_IP",
+	}
+	ServiceOptions_Type_value = map[string]int32{
+		"LOAD_BALANCER": 0,
+		"NODE_PORT":     1,
+		"CLUSTER_IP":    2,
+	}
+)
+
+func (x ServiceOptions_Type) Enum() *ServiceOptions_Type {
+	p := new(ServiceOptions_Type)
+	*p = x
+	return p
+}
+
+func (x ServiceOptions_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceOptions_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_topo_proto_enumTypes[10].Descriptor()
+}
+
+func (ServiceOptions_Type) Type() protoreflect.EnumType {
+	return &file_topo_proto_enumTypes[10]
+}
+
+func (x ServiceOptions_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceOptions_Type.Descriptor instead.
+func (ServiceOptions_Type) EnumDescriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{30, 0}
 }
 
 // Topology message defines what nodes and links will be created
@@ -278,9 +675,17 @@ type Topology struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Name of the topology - will be linked to the cluster name
-	Nodes []*Node `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"` // List of nodes in the topology
-	Links []*Link `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"` // connections between Nodes.
+	Name    string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Name of the topology - will be linked to the cluster name
+	Nodes   []*Node   `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`     // List of nodes in the topology
+	Links   []*Link   `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`     // connections between Nodes.
+	Mirrors []*Mirror `protobuf:"bytes,4,rep,name=mirrors,proto3" json:"mirrors,omitempty"` // Traffic mirroring sessions.
+	// Pod patches applied to all nodes of a vendor, before the pod patches of
+	// the node.
+	VendorPodPatches []*VendorPodPatches `protobuf:"bytes,5,rep,name=vendor_pod_patches,json=vendorPodPatches,proto3" json:"vendor_pod_patches,omitempty"`
+	// Certificate authority issuing the certificates of the nodes with a
+	// ca_issued cert config. If not set and a node requests a CA issued
+	// certificate, a CA is created with the defaults.
+	Ca *CertificateAuthority `protobuf:"bytes,6,opt,name=ca,proto3" json:"ca,omitempty"`
 }
 
 func (x *Topology) Reset() {
@@ -336,42 +741,52 @@ func (x *Topology) GetLinks() []*Link {
 	return nil
 }
 
-// Node is a single container inside the topology
-type Node struct {
+func (x *Topology) GetMirrors() []*Mirror {
+	if x != nil {
+		return x.Mirrors
+	}
+	return nil
+}
+
+func (x *Topology) GetVendorPodPatches() []*VendorPodPatches {
+	if x != nil {
+		return x.VendorPodPatches
+	}
+	return nil
+}
+
+func (x *Topology) GetCa() *CertificateAuthority {
+	if x != nil {
+		return x.Ca
+	}
+	return nil
+}
+
+// CertificateAuthority is the CA of a topology. The CA is created, or imported
+// from local files, when the first certificate is issued and stored in the
+// kne-ca Secret of the topology namespace. The CA certificate is published in
+// the ca.crt key of the kne-ca-bundle Secret and in a local file.
+type CertificateAuthority struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Name of the node in the topology. Must be unique.
-	// Deprecated: Marked as deprecated in topo.proto.
-	Type   Node_Type         `protobuf:"varint,2,opt,name=type,proto3,enum=topo.Node_Type" json:"type,omitempty"`
-	Labels map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata labels describing the node.
-	Config *Config           `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                                                                                         // Pod specific configuration of the node.
-	// Map of services to enable on the node.
-	// The key is the external load balancer port to be used by the service.
-	// Multiple external services can be mapped to a single internal service port
-	// by reusing the inside_port in the service definition.
-	Services    map[uint32]*Service `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Constraints map[string]string   `protobuf:"bytes,7,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Any k8s constraints required by node.
-	Vendor      Vendor              `protobuf:"varint,8,opt,name=vendor,proto3,enum=topo.Vendor" json:"vendor,omitempty"`                                                                                 // Vendor enum replaces type.
-	Model       string              `protobuf:"bytes,9,opt,name=model,proto3" json:"model,omitempty"`                                                                                                     // Model of the node.
-	Version     string              `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`                                                                                                // Version string used to identify a software release.
-	Os          string              `protobuf:"bytes,11,opt,name=os,proto3" json:"os,omitempty"`                                                                                                          // Operating system type.
-	// Interfaces is a map of container interfaces used by the node.
-	// If interfaces is empty the interfaces defined in the links portion of the
-	// topology will be populated into the node.
-	Interfaces map[string]*Interface `protobuf:"bytes,12,rep,name=interfaces,proto3" json:"interfaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Any host constraints required by the node. These may not be required by a
-	// vendor, but it can be used to provide requirements for the node.
-	HostConstraints []*HostConstraint `protobuf:"bytes,13,rep,name=host_constraints,json=hostConstraints,proto3" json:"host_constraints,omitempty"`
-	// Assigned by KNE.
-	// Cluster-internal IP assigned by Kubernetes for the pod.
-	// This IP comes from the worker node's IP pool.
-	PodIp string `protobuf:"bytes,14,opt,name=pod_ip,json=podIp,proto3" json:"pod_ip,omitempty"`
-}
-
-func (x *Node) Reset() {
-	*x = Node{}
+	// PEM encoded certificate and key files of an existing CA to import,
+	// relative to the topology configuration file.
+	CertFile string `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile  string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// Common name of a created CA, defaults to "<topology> CA".
+	CommonName string `protobuf:"bytes,3,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	// RSA key size of a created CA, defaults to 2048.
+	KeySize uint32 `protobuf:"varint,4,opt,name=key_size,json=keySize,proto3" json:"key_size,omitempty"`
+	// Local file the CA certificate is written to, relative to the topology
+	// configuration file. Defaults to <topology>-ca.pem in the temporary
+	// directory.
+	BundleFile string `protobuf:"bytes,5,opt,name=bundle_file,json=bundleFile,proto3" json:"bundle_file,omitempty"`
+}
+
+func (x *CertificateAuthority) Reset() {
+	*x = CertificateAuthority{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topo_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -379,13 +794,13 @@ func (x *Node) Reset() {
 	}
 }
 
-func (x *Node) String() string {
+func (x *CertificateAuthority) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*CertificateAuthority) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
+func (x *CertificateAuthority) ProtoReflect() protoreflect.Message {
 	mi := &file_topo_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -397,117 +812,58 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
+// Deprecated: Use CertificateAuthority.ProtoReflect.Descriptor instead.
+func (*CertificateAuthority) Descriptor() ([]byte, []int) {
 	return file_topo_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Node) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-// Deprecated: Marked as deprecated in topo.proto.
-func (x *Node) GetType() Node_Type {
-	if x != nil {
-		return x.Type
-	}
-	return Node_UNKNOWN
-}
-
-func (x *Node) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
-func (x *Node) GetConfig() *Config {
-	if x != nil {
-		return x.Config
-	}
-	return nil
-}
-
-func (x *Node) GetServices() map[uint32]*Service {
-	if x != nil {
-		return x.Services
-	}
-	return nil
-}
-
-func (x *Node) GetConstraints() map[string]string {
-	if x != nil {
-		return x.Constraints
-	}
-	return nil
-}
-
-func (x *Node) GetVendor() Vendor {
-	if x != nil {
-		return x.Vendor
-	}
-	return Vendor_UNKNOWN
-}
-
-func (x *Node) GetModel() string {
+func (x *CertificateAuthority) GetCertFile() string {
 	if x != nil {
-		return x.Model
+		return x.CertFile
 	}
 	return ""
 }
 
-func (x *Node) GetVersion() string {
+func (x *CertificateAuthority) GetKeyFile() string {
 	if x != nil {
-		return x.Version
+		return x.KeyFile
 	}
 	return ""
 }
 
-func (x *Node) GetOs() string {
+func (x *CertificateAuthority) GetCommonName() string {
 	if x != nil {
-		return x.Os
+		return x.CommonName
 	}
 	return ""
 }
 
-func (x *Node) GetInterfaces() map[string]*Interface {
-	if x != nil {
-		return x.Interfaces
-	}
-	return nil
-}
-
-func (x *Node) GetHostConstraints() []*HostConstraint {
+func (x *CertificateAuthority) GetKeySize() uint32 {
 	if x != nil {
-		return x.HostConstraints
+		return x.KeySize
 	}
-	return nil
+	return 0
 }
 
-func (x *Node) GetPodIp() string {
+func (x *CertificateAuthority) GetBundleFile() string {
 	if x != nil {
-		return x.PodIp
+		return x.BundleFile
 	}
 	return ""
 }
 
-// HostConstraint is a constraint on the host where the node is running.
-type HostConstraint struct {
+// VendorPodPatches are the default pod patches of the nodes of a vendor.
+type VendorPodPatches struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Constraint:
-	//
-	//	*HostConstraint_KernelConstraint
-	Constraint isHostConstraint_Constraint `protobuf_oneof:"constraint"`
+	Vendor  Vendor      `protobuf:"varint,1,opt,name=vendor,proto3,enum=topo.Vendor" json:"vendor,omitempty"`
+	Patches []*PodPatch `protobuf:"bytes,2,rep,name=patches,proto3" json:"patches,omitempty"`
 }
 
-func (x *HostConstraint) Reset() {
-	*x = HostConstraint{}
+func (x *VendorPodPatches) Reset() {
+	*x = VendorPodPatches{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topo_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -515,13 +871,13 @@ func (x *HostConstraint) Reset() {
 	}
 }
 
-func (x *HostConstraint) String() string {
+func (x *VendorPodPatches) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HostConstraint) ProtoMessage() {}
+func (*VendorPodPatches) ProtoMessage() {}
 
-func (x *HostConstraint) ProtoReflect() protoreflect.Message {
+func (x *VendorPodPatches) ProtoReflect() protoreflect.Message {
 	mi := &file_topo_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -533,52 +889,43 @@ func (x *HostConstraint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HostConstraint.ProtoReflect.Descriptor instead.
-func (*HostConstraint) Descriptor() ([]byte, []int) {
+// Deprecated: Use VendorPodPatches.ProtoReflect.Descriptor instead.
+func (*VendorPodPatches) Descriptor() ([]byte, []int) {
 	return file_topo_proto_rawDescGZIP(), []int{2}
 }
 
-func (m *HostConstraint) GetConstraint() isHostConstraint_Constraint {
-	if m != nil {
-		return m.Constraint
+func (x *VendorPodPatches) GetVendor() Vendor {
+	if x != nil {
+		return x.Vendor
 	}
-	return nil
+	return Vendor_UNKNOWN
 }
 
-func (x *HostConstraint) GetKernelConstraint() *KernelParam {
-	if x, ok := x.GetConstraint().(*HostConstraint_KernelConstraint); ok {
-		return x.KernelConstraint
+func (x *VendorPodPatches) GetPatches() []*PodPatch {
+	if x != nil {
+		return x.Patches
 	}
 	return nil
 }
 
-type isHostConstraint_Constraint interface {
-	isHostConstraint_Constraint()
-}
-
-type HostConstraint_KernelConstraint struct {
-	// Includes the required kernel parameters that need to be configured.
-	KernelConstraint *KernelParam `protobuf:"bytes,1,opt,name=kernel_constraint,json=kernelConstraint,proto3,oneof"`
-}
-
-func (*HostConstraint_KernelConstraint) isHostConstraint_Constraint() {}
-
-// KernelParam is a parameter on the host kernel.
-type KernelParam struct {
+// PodPatch is a patch applied to the pod of a node before it is created.
+type PodPatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// MIB style name of the kernel state to constrain.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Types that are assignable to ConstraintType:
+	Type PodPatch_Type `protobuf:"varint,1,opt,name=type,proto3,enum=topo.PodPatch_Type" json:"type,omitempty"`
+	// The patch in JSON or YAML.
 	//
-	//	*KernelParam_BoundedInteger
-	ConstraintType isKernelParam_ConstraintType `protobuf_oneof:"ConstraintType"`
+	// Types that are assignable to Source:
+	//
+	//	*PodPatch_Patch
+	//	*PodPatch_File
+	Source isPodPatch_Source `protobuf_oneof:"source"`
 }
 
-func (x *KernelParam) Reset() {
-	*x = KernelParam{}
+func (x *PodPatch) Reset() {
+	*x = PodPatch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topo_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -586,13 +933,13 @@ func (x *KernelParam) Reset() {
 	}
 }
 
-func (x *KernelParam) String() string {
+func (x *PodPatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KernelParam) ProtoMessage() {}
+func (*PodPatch) ProtoMessage() {}
 
-func (x *KernelParam) ProtoReflect() protoreflect.Message {
+func (x *PodPatch) ProtoReflect() protoreflect.Message {
 	mi := &file_topo_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -604,55 +951,103 @@ func (x *KernelParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KernelParam.ProtoReflect.Descriptor instead.
-func (*KernelParam) Descriptor() ([]byte, []int) {
+// Deprecated: Use PodPatch.ProtoReflect.Descriptor instead.
+func (*PodPatch) Descriptor() ([]byte, []int) {
 	return file_topo_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *KernelParam) GetName() string {
+func (x *PodPatch) GetType() PodPatch_Type {
 	if x != nil {
-		return x.Name
+		return x.Type
 	}
-	return ""
+	return PodPatch_STRATEGIC_MERGE
 }
 
-func (m *KernelParam) GetConstraintType() isKernelParam_ConstraintType {
+func (m *PodPatch) GetSource() isPodPatch_Source {
 	if m != nil {
-		return m.ConstraintType
+		return m.Source
 	}
 	return nil
 }
 
-func (x *KernelParam) GetBoundedInteger() *BoundedInteger {
-	if x, ok := x.GetConstraintType().(*KernelParam_BoundedInteger); ok {
-		return x.BoundedInteger
+func (x *PodPatch) GetPatch() string {
+	if x, ok := x.GetSource().(*PodPatch_Patch); ok {
+		return x.Patch
 	}
-	return nil
+	return ""
 }
 
-type isKernelParam_ConstraintType interface {
-	isKernelParam_ConstraintType()
+func (x *PodPatch) GetFile() string {
+	if x, ok := x.GetSource().(*PodPatch_File); ok {
+		return x.File
+	}
+	return ""
 }
 
-type KernelParam_BoundedInteger struct {
-	BoundedInteger *BoundedInteger `protobuf:"bytes,2,opt,name=bounded_integer,json=boundedInteger,proto3,oneof"`
+type isPodPatch_Source interface {
+	isPodPatch_Source()
 }
 
-func (*KernelParam_BoundedInteger) isKernelParam_ConstraintType() {}
+type PodPatch_Patch struct {
+	Patch string `protobuf:"bytes,2,opt,name=patch,proto3,oneof"` // Inline patch.
+}
 
-// BoundedInteger to capture the range of max or min for a particular
-// constraint.
-type BoundedInteger struct {
+type PodPatch_File struct {
+	File string `protobuf:"bytes,3,opt,name=file,proto3,oneof"` // File containing the patch.
+}
+
+func (*PodPatch_Patch) isPodPatch_Source() {}
+
+func (*PodPatch_File) isPodPatch_Source() {}
+
+// Node is a single container inside the topology
+type Node struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MaxValue int64 `protobuf:"varint,1,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"`
-	MinValue int64 `protobuf:"varint,2,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Name of the node in the topology. Must be unique.
+	// Deprecated: Marked as deprecated in topo.proto.
+	Type   Node_Type         `protobuf:"varint,2,opt,name=type,proto3,enum=topo.Node_Type" json:"type,omitempty"`
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata labels describing the node.
+	Config *Config           `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                                                                                         // Pod specific configuration of the node.
+	// Map of services to enable on the node.
+	// The key is the external load balancer port to be used by the service.
+	// Multiple external services can be mapped to a single internal service port
+	// by reusing the inside_port in the service definition.
+	Services    map[uint32]*Service `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Constraints map[string]string   `protobuf:"bytes,7,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Any k8s constraints required by node.
+	Vendor      Vendor              `protobuf:"varint,8,opt,name=vendor,proto3,enum=topo.Vendor" json:"vendor,omitempty"`                                                                                 // Vendor enum replaces type.
+	Model       string              `protobuf:"bytes,9,opt,name=model,proto3" json:"model,omitempty"`                                                                                                     // Model of the node.
+	Version     string              `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`                                                                                                // Version string used to identify a software release.
+	Os          string              `protobuf:"bytes,11,opt,name=os,proto3" json:"os,omitempty"`                                                                                                          // Operating system type.
+	// Interfaces is a map of container interfaces used by the node.
+	// If interfaces is empty the interfaces defined in the links portion of the
+	// topology will be populated into the node.
+	Interfaces map[string]*Interface `protobuf:"bytes,12,rep,name=interfaces,proto3" json:"interfaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Any host constraints required by the node. These may not be required by a
+	// vendor, but it can be used to provide requirements for the node.
+	HostConstraints []*HostConstraint `protobuf:"bytes,13,rep,name=host_constraints,json=hostConstraints,proto3" json:"host_constraints,omitempty"`
+	// Assigned by KNE.
+	// Cluster-internal IP assigned by Kubernetes for the pod.
+	// This IP comes from the worker node's IP pool.
+	PodIp string `protobuf:"bytes,14,opt,name=pod_ip,json=podIp,proto3" json:"pod_ip,omitempty"`
+	// Placement of the pod of the node on the nodes of the cluster.
+	Placement *Placement `protobuf:"bytes,15,opt,name=placement,proto3" json:"placement,omitempty"`
+	// Additional containers run in the pod of the node.
+	Sidecars []*Sidecar `protobuf:"bytes,16,rep,name=sidecars,proto3" json:"sidecars,omitempty"`
+	// Options of the Service exposing the services of the node.
+	ServiceOptions *ServiceOptions `protobuf:"bytes,17,opt,name=service_options,json=serviceOptions,proto3" json:"service_options,omitempty"`
+	// Credentials used to log into the node. If not set, CLI sessions bypass
+	// authentication and rely on the vendor default credentials.
+	Credentials *Credentials `protobuf:"bytes,18,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	// Name of an out-of-tree node plugin implementing the node. If set, the
+	// plugin is used instead of the built-in implementation of the vendor.
+	Plugin string `protobuf:"bytes,19,opt,name=plugin,proto3" json:"plugin,omitempty"`
 }
 
-func (x *BoundedInteger) Reset() {
-	*x = BoundedInteger{}
+func (x *Node) Reset() {
+	*x = Node{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topo_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -660,13 +1055,13 @@ func (x *BoundedInteger) Reset() {
 	}
 }
 
-func (x *BoundedInteger) String() string {
+func (x *Node) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BoundedInteger) ProtoMessage() {}
+func (*Node) ProtoMessage() {}
 
-func (x *BoundedInteger) ProtoReflect() protoreflect.Message {
+func (x *Node) ProtoReflect() protoreflect.Message {
 	mi := &file_topo_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -678,71 +1073,1477 @@ func (x *BoundedInteger) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BoundedInteger.ProtoReflect.Descriptor instead.
-func (*BoundedInteger) Descriptor() ([]byte, []int) {
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
 	return file_topo_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *BoundedInteger) GetMaxValue() int64 {
+func (x *Node) GetName() string {
 	if x != nil {
-		return x.MaxValue
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *BoundedInteger) GetMinValue() int64 {
+// Deprecated: Marked as deprecated in topo.proto.
+func (x *Node) GetType() Node_Type {
 	if x != nil {
-		return x.MinValue
+		return x.Type
 	}
-	return 0
-}
-
-// Interface keys must be the same as the links a,z int.
-type Interface struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Name of the interface provided by Node implementation. This will be used
-	// to map the int_name into a vendor specific interface name. Vendor operators
-	// should provide the name mapping from the node interface key to this vendor
-	// specific name.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Internal device name. This name matches the key in the Node interface map.
-	// It will be returned by the operator. Assigned by KNE.
-	IntName string `protobuf:"bytes,2,opt,name=int_name,json=intName,proto3" json:"int_name,omitempty"`
-	Mtu     uint32 `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"` // Desired MTU for the interface.
-	// Peer node name. Assigned by KNE.
-	PeerName string `protobuf:"bytes,4,opt,name=peer_name,json=peerName,proto3" json:"peer_name,omitempty"`
-	// Peer interface name. Assigned by KNE.
-	PeerIntName string `protobuf:"bytes,5,opt,name=peer_int_name,json=peerIntName,proto3" json:"peer_int_name,omitempty"`
-	// Uid is the internal link identifier used by Meshnet.
-	Uid int64 `protobuf:"varint,6,opt,name=uid,proto3" json:"uid,omitempty"`
-	// Name of group to which this interface belongs
-	Group string `protobuf:"bytes,7,opt,name=group,proto3" json:"group,omitempty"`
-	// Type of the interface - Loopback, Management , Data etc.
-	Type Interface_InterfaceType `protobuf:"varint,8,opt,name=type,proto3,enum=topo.Interface_InterfaceType" json:"type,omitempty"`
-	// ip_address associated with the interface.
-	IpAddress string `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	return Node_UNKNOWN
 }
 
-func (x *Interface) Reset() {
-	*x = Interface{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
 	}
+	return nil
 }
 
-func (x *Interface) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Node) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Node) GetServices() map[uint32]*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Node) GetConstraints() map[string]string {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *Node) GetVendor() Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return Vendor_UNKNOWN
+}
+
+func (x *Node) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Node) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Node) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Node) GetInterfaces() map[string]*Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *Node) GetHostConstraints() []*HostConstraint {
+	if x != nil {
+		return x.HostConstraints
+	}
+	return nil
+}
+
+func (x *Node) GetPodIp() string {
+	if x != nil {
+		return x.PodIp
+	}
+	return ""
+}
+
+func (x *Node) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *Node) GetSidecars() []*Sidecar {
+	if x != nil {
+		return x.Sidecars
+	}
+	return nil
+}
+
+func (x *Node) GetServiceOptions() *ServiceOptions {
+	if x != nil {
+		return x.ServiceOptions
+	}
+	return nil
+}
+
+func (x *Node) GetCredentials() *Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *Node) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+// Credentials of a node. The username and password are either set inline or
+// read from a Secret or a local file. Inline values take precedence over the
+// values read from the source.
+type Credentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Types that are assignable to Source:
+	//
+	//	*Credentials_Secret
+	//	*Credentials_File
+	Source isCredentials_Source `protobuf_oneof:"source"`
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Credentials) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Credentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (m *Credentials) GetSource() isCredentials_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Credentials) GetSecret() string {
+	if x, ok := x.GetSource().(*Credentials_Secret); ok {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Credentials) GetFile() string {
+	if x, ok := x.GetSource().(*Credentials_File); ok {
+		return x.File
+	}
+	return ""
+}
+
+type isCredentials_Source interface {
+	isCredentials_Source()
+}
+
+type Credentials_Secret struct {
+	// Name of a Secret with username and password keys, such as a
+	// kubernetes.io/basic-auth Secret, in the topology namespace.
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3,oneof"`
+}
+
+type Credentials_File struct {
+	// Local YAML or JSON file with username and password keys, relative to
+	// the topology configuration file.
+	File string `protobuf:"bytes,4,opt,name=file,proto3,oneof"`
+}
+
+func (*Credentials_Secret) isCredentials_Source() {}
+
+func (*Credentials_File) isCredentials_Source() {}
+
+// Sidecar is a helper container run next to the node container, such as a
+// telemetry collector or a packet capture agent. All containers of a pod share
+// its network namespace, including the data interfaces of the node.
+type Sidecar struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Name of the container, unique in the pod.
+	Image   string            `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Command []string          `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`
+	Args    []string          `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	Env     map[string]string `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Ports of the container by name.
+	Ports   map[string]uint32 `protobuf:"bytes,6,rep,name=ports,proto3" json:"ports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Volumes []*SidecarVolume  `protobuf:"bytes,7,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// Share the process namespace of the pod between its containers.
+	ShareProcessNamespace bool `protobuf:"varint,8,opt,name=share_process_namespace,json=shareProcessNamespace,proto3" json:"share_process_namespace,omitempty"`
+	Privileged            bool `protobuf:"varint,9,opt,name=privileged,proto3" json:"privileged,omitempty"`
+}
+
+func (x *Sidecar) Reset() {
+	*x = Sidecar{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sidecar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sidecar) ProtoMessage() {}
+
+func (x *Sidecar) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sidecar.ProtoReflect.Descriptor instead.
+func (*Sidecar) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Sidecar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sidecar) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Sidecar) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Sidecar) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Sidecar) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Sidecar) GetPorts() map[string]uint32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Sidecar) GetVolumes() []*SidecarVolume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Sidecar) GetShareProcessNamespace() bool {
+	if x != nil {
+		return x.ShareProcessNamespace
+	}
+	return false
+}
+
+func (x *Sidecar) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+// SidecarVolume is a volume mounted into a sidecar container.
+type SidecarVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of a volume of the pod, such as a Secret, file mount or persistent
+	// volume of the node. If the pod has no such volume an empty directory is
+	// created and shared with the node container, mounted at the same path.
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MountPath string `protobuf:"bytes,2,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	ReadOnly  bool   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
+
+func (x *SidecarVolume) Reset() {
+	*x = SidecarVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SidecarVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SidecarVolume) ProtoMessage() {}
+
+func (x *SidecarVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SidecarVolume.ProtoReflect.Descriptor instead.
+func (*SidecarVolume) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SidecarVolume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SidecarVolume) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+func (x *SidecarVolume) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// Placement controls where the pod of a node is scheduled in the cluster.
+type Placement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Labels the cluster node must have to run the pod.
+	NodeSelector map[string]string `protobuf:"bytes,1,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Node affinity terms. Required terms are ORed, a pod is scheduled on a
+	// cluster node matching any of them.
+	NodeAffinity []*NodeAffinityTerm `protobuf:"bytes,2,rep,name=node_affinity,json=nodeAffinity,proto3" json:"node_affinity,omitempty"`
+	// Pods the pod of the node should be scheduled with.
+	PodAffinity []*PodAffinityTerm `protobuf:"bytes,3,rep,name=pod_affinity,json=podAffinity,proto3" json:"pod_affinity,omitempty"`
+	// Pods the pod of the node should not be scheduled with. If empty, pods of
+	// the topology are preferably spread across cluster nodes.
+	PodAntiAffinity []*PodAffinityTerm `protobuf:"bytes,4,rep,name=pod_anti_affinity,json=podAntiAffinity,proto3" json:"pod_anti_affinity,omitempty"`
+	// Taints of cluster nodes tolerated by the pod.
+	Tolerations []*Toleration `protobuf:"bytes,5,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// Constraints spreading pods across topology domains.
+	TopologySpread []*TopologySpread `protobuf:"bytes,6,rep,name=topology_spread,json=topologySpread,proto3" json:"topology_spread,omitempty"`
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Placement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placement) ProtoMessage() {}
+
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Placement) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *Placement) GetNodeAffinity() []*NodeAffinityTerm {
+	if x != nil {
+		return x.NodeAffinity
+	}
+	return nil
+}
+
+func (x *Placement) GetPodAffinity() []*PodAffinityTerm {
+	if x != nil {
+		return x.PodAffinity
+	}
+	return nil
+}
+
+func (x *Placement) GetPodAntiAffinity() []*PodAffinityTerm {
+	if x != nil {
+		return x.PodAntiAffinity
+	}
+	return nil
+}
+
+func (x *Placement) GetTolerations() []*Toleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *Placement) GetTopologySpread() []*TopologySpread {
+	if x != nil {
+		return x.TopologySpread
+	}
+	return nil
+}
+
+// LabelRequirement is a requirement on the value of a label.
+type LabelRequirement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string                    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator LabelRequirement_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=topo.LabelRequirement_Operator" json:"operator,omitempty"`
+	Values   []string                  `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *LabelRequirement) Reset() {
+	*x = LabelRequirement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelRequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelRequirement) ProtoMessage() {}
+
+func (x *LabelRequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelRequirement.ProtoReflect.Descriptor instead.
+func (*LabelRequirement) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LabelRequirement) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LabelRequirement) GetOperator() LabelRequirement_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return LabelRequirement_IN
+}
+
+func (x *LabelRequirement) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// NodeAffinityTerm selects cluster nodes by their labels.
+type NodeAffinityTerm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// All requirements must be met by the cluster node.
+	MatchExpressions []*LabelRequirement `protobuf:"bytes,1,rep,name=match_expressions,json=matchExpressions,proto3" json:"match_expressions,omitempty"`
+	// Weight of a preferred term, in the range 1-100. If 0 the term is
+	// required.
+	Weight int32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *NodeAffinityTerm) Reset() {
+	*x = NodeAffinityTerm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeAffinityTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAffinityTerm) ProtoMessage() {}
+
+func (x *NodeAffinityTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAffinityTerm.ProtoReflect.Descriptor instead.
+func (*NodeAffinityTerm) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NodeAffinityTerm) GetMatchExpressions() []*LabelRequirement {
+	if x != nil {
+		return x.MatchExpressions
+	}
+	return nil
+}
+
+func (x *NodeAffinityTerm) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// PodAffinityTerm selects pods by their labels. Pods of a topology have the
+// topo label set to the topology name and the app label set to the node name.
+type PodAffinityTerm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchLabels      map[string]string   `protobuf:"bytes,1,rep,name=match_labels,json=matchLabels,proto3" json:"match_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MatchExpressions []*LabelRequirement `protobuf:"bytes,2,rep,name=match_expressions,json=matchExpressions,proto3" json:"match_expressions,omitempty"`
+	// Label of cluster nodes defining the topology domain, defaults to
+	// kubernetes.io/hostname.
+	TopologyKey string `protobuf:"bytes,3,opt,name=topology_key,json=topologyKey,proto3" json:"topology_key,omitempty"`
+	// Weight of a preferred term, in the range 1-100. If 0 the term is
+	// required.
+	Weight int32 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *PodAffinityTerm) Reset() {
+	*x = PodAffinityTerm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodAffinityTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodAffinityTerm) ProtoMessage() {}
+
+func (x *PodAffinityTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodAffinityTerm.ProtoReflect.Descriptor instead.
+func (*PodAffinityTerm) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PodAffinityTerm) GetMatchLabels() map[string]string {
+	if x != nil {
+		return x.MatchLabels
+	}
+	return nil
+}
+
+func (x *PodAffinityTerm) GetMatchExpressions() []*LabelRequirement {
+	if x != nil {
+		return x.MatchExpressions
+	}
+	return nil
+}
+
+func (x *PodAffinityTerm) GetTopologyKey() string {
+	if x != nil {
+		return x.TopologyKey
+	}
+	return ""
+}
+
+func (x *PodAffinityTerm) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// Toleration tolerates taints of cluster nodes.
+type Toleration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string              `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator Toleration_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=topo.Toleration_Operator" json:"operator,omitempty"`
+	Value    string              `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Taint effect to tolerate: NoSchedule, PreferNoSchedule or NoExecute. All
+	// effects are tolerated if empty.
+	Effect string `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	// Seconds a NoExecute taint is tolerated for. Tolerated forever if 0.
+	TolerationSeconds int64 `protobuf:"varint,5,opt,name=toleration_seconds,json=tolerationSeconds,proto3" json:"toleration_seconds,omitempty"`
+}
+
+func (x *Toleration) Reset() {
+	*x = Toleration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Toleration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Toleration) ProtoMessage() {}
+
+func (x *Toleration) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Toleration.ProtoReflect.Descriptor instead.
+func (*Toleration) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Toleration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Toleration) GetOperator() Toleration_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return Toleration_EQUAL
+}
+
+func (x *Toleration) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Toleration) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *Toleration) GetTolerationSeconds() int64 {
+	if x != nil {
+		return x.TolerationSeconds
+	}
+	return 0
+}
+
+// TopologySpread spreads pods evenly across topology domains.
+type TopologySpread struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum difference in the number of matching pods between domains,
+	// defaults to 1.
+	MaxSkew int32 `protobuf:"varint,1,opt,name=max_skew,json=maxSkew,proto3" json:"max_skew,omitempty"`
+	// Label of cluster nodes defining the topology domain, defaults to
+	// kubernetes.io/hostname.
+	TopologyKey string `protobuf:"bytes,2,opt,name=topology_key,json=topologyKey,proto3" json:"topology_key,omitempty"`
+	// If set pods are not scheduled when the constraint cannot be satisfied,
+	// otherwise the scheduler only prefers domains reducing the skew.
+	Required bool `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	// Labels of the pods to spread, defaults to the pods of the topology.
+	MatchLabels map[string]string `protobuf:"bytes,4,rep,name=match_labels,json=matchLabels,proto3" json:"match_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *TopologySpread) Reset() {
+	*x = TopologySpread{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologySpread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologySpread) ProtoMessage() {}
+
+func (x *TopologySpread) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologySpread.ProtoReflect.Descriptor instead.
+func (*TopologySpread) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TopologySpread) GetMaxSkew() int32 {
+	if x != nil {
+		return x.MaxSkew
+	}
+	return 0
+}
+
+func (x *TopologySpread) GetTopologyKey() string {
+	if x != nil {
+		return x.TopologyKey
+	}
+	return ""
+}
+
+func (x *TopologySpread) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *TopologySpread) GetMatchLabels() map[string]string {
+	if x != nil {
+		return x.MatchLabels
+	}
+	return nil
+}
+
+// HostConstraint is a constraint on the host where the node is running.
+type HostConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Constraint:
+	//
+	//	*HostConstraint_KernelConstraint
+	Constraint isHostConstraint_Constraint `protobuf_oneof:"constraint"`
+}
+
+func (x *HostConstraint) Reset() {
+	*x = HostConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostConstraint) ProtoMessage() {}
+
+func (x *HostConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostConstraint.ProtoReflect.Descriptor instead.
+func (*HostConstraint) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *HostConstraint) GetConstraint() isHostConstraint_Constraint {
+	if m != nil {
+		return m.Constraint
+	}
+	return nil
+}
+
+func (x *HostConstraint) GetKernelConstraint() *KernelParam {
+	if x, ok := x.GetConstraint().(*HostConstraint_KernelConstraint); ok {
+		return x.KernelConstraint
+	}
+	return nil
+}
+
+type isHostConstraint_Constraint interface {
+	isHostConstraint_Constraint()
+}
+
+type HostConstraint_KernelConstraint struct {
+	// Includes the required kernel parameters that need to be configured.
+	KernelConstraint *KernelParam `protobuf:"bytes,1,opt,name=kernel_constraint,json=kernelConstraint,proto3,oneof"`
+}
+
+func (*HostConstraint_KernelConstraint) isHostConstraint_Constraint() {}
+
+// KernelParam is a parameter on the host kernel.
+type KernelParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// MIB style name of the kernel state to constrain.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to ConstraintType:
+	//
+	//	*KernelParam_BoundedInteger
+	ConstraintType isKernelParam_ConstraintType `protobuf_oneof:"ConstraintType"`
+}
+
+func (x *KernelParam) Reset() {
+	*x = KernelParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KernelParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelParam) ProtoMessage() {}
+
+func (x *KernelParam) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelParam.ProtoReflect.Descriptor instead.
+func (*KernelParam) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KernelParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *KernelParam) GetConstraintType() isKernelParam_ConstraintType {
+	if m != nil {
+		return m.ConstraintType
+	}
+	return nil
+}
+
+func (x *KernelParam) GetBoundedInteger() *BoundedInteger {
+	if x, ok := x.GetConstraintType().(*KernelParam_BoundedInteger); ok {
+		return x.BoundedInteger
+	}
+	return nil
+}
+
+type isKernelParam_ConstraintType interface {
+	isKernelParam_ConstraintType()
+}
+
+type KernelParam_BoundedInteger struct {
+	BoundedInteger *BoundedInteger `protobuf:"bytes,2,opt,name=bounded_integer,json=boundedInteger,proto3,oneof"`
+}
+
+func (*KernelParam_BoundedInteger) isKernelParam_ConstraintType() {}
+
+// BoundedInteger to capture the range of max or min for a particular
+// constraint.
+type BoundedInteger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxValue int64 `protobuf:"varint,1,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"`
+	MinValue int64 `protobuf:"varint,2,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+}
+
+func (x *BoundedInteger) Reset() {
+	*x = BoundedInteger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoundedInteger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundedInteger) ProtoMessage() {}
+
+func (x *BoundedInteger) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundedInteger.ProtoReflect.Descriptor instead.
+func (*BoundedInteger) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BoundedInteger) GetMaxValue() int64 {
+	if x != nil {
+		return x.MaxValue
+	}
+	return 0
+}
+
+func (x *BoundedInteger) GetMinValue() int64 {
+	if x != nil {
+		return x.MinValue
+	}
+	return 0
+}
+
+// Interface keys must be the same as the links a,z int.
+type Interface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface provided by Node implementation. This will be used
+	// to map the int_name into a vendor specific interface name. Vendor operators
+	// should provide the name mapping from the node interface key to this vendor
+	// specific name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Internal device name. This name matches the key in the Node interface map.
+	// It will be returned by the operator. Assigned by KNE.
+	IntName string `protobuf:"bytes,2,opt,name=int_name,json=intName,proto3" json:"int_name,omitempty"`
+	Mtu     uint32 `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"` // Desired MTU for the interface.
+	// Peer node name. Assigned by KNE.
+	PeerName string `protobuf:"bytes,4,opt,name=peer_name,json=peerName,proto3" json:"peer_name,omitempty"`
+	// Peer interface name. Assigned by KNE.
+	PeerIntName string `protobuf:"bytes,5,opt,name=peer_int_name,json=peerIntName,proto3" json:"peer_int_name,omitempty"`
+	// Uid is the internal link identifier used by Meshnet.
+	Uid int64 `protobuf:"varint,6,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Name of group to which this interface belongs
+	Group string `protobuf:"bytes,7,opt,name=group,proto3" json:"group,omitempty"`
+	// Type of the interface - Loopback, Management , Data etc.
+	Type Interface_InterfaceType `protobuf:"varint,8,opt,name=type,proto3,enum=topo.Interface_InterfaceType" json:"type,omitempty"`
+	// ip_address associated with the interface.
+	IpAddress string `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+}
+
+func (x *Interface) Reset() {
+	*x = Interface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interface) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*Interface) ProtoMessage() {}
 
-func (x *Interface) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[5]
+func (x *Interface) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
+func (*Interface) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Interface) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Interface) GetIntName() string {
+	if x != nil {
+		return x.IntName
+	}
+	return ""
+}
+
+func (x *Interface) GetMtu() uint32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *Interface) GetPeerName() string {
+	if x != nil {
+		return x.PeerName
+	}
+	return ""
+}
+
+func (x *Interface) GetPeerIntName() string {
+	if x != nil {
+		return x.PeerIntName
+	}
+	return ""
+}
+
+func (x *Interface) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Interface) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Interface) GetType() Interface_InterfaceType {
+	if x != nil {
+		return x.Type
+	}
+	return Interface_UNKNOWN
+}
+
+func (x *Interface) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+// Link is single link between nodes in the topology.
+// Interfaces must start eth1 - eth0 is the default k8s interface.
+type Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ANode string `protobuf:"bytes,1,opt,name=a_node,json=aNode,proto3" json:"a_node,omitempty"`
+	AInt  string `protobuf:"bytes,2,opt,name=a_int,json=aInt,proto3" json:"a_int,omitempty"`
+	ZNode string `protobuf:"bytes,3,opt,name=z_node,json=zNode,proto3" json:"z_node,omitempty"`
+	ZInt  string `protobuf:"bytes,4,opt,name=z_int,json=zInt,proto3" json:"z_int,omitempty"`
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Link) GetANode() string {
+	if x != nil {
+		return x.ANode
+	}
+	return ""
+}
+
+func (x *Link) GetAInt() string {
+	if x != nil {
+		return x.AInt
+	}
+	return ""
+}
+
+func (x *Link) GetZNode() string {
+	if x != nil {
+		return x.ZNode
+	}
+	return ""
+}
+
+func (x *Link) GetZInt() string {
+	if x != nil {
+		return x.ZInt
+	}
+	return ""
+}
+
+// Endpoint is an interface on a node.
+type Endpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Int  string `protobuf:"bytes,2,opt,name=int,proto3" json:"int,omitempty"`
+}
+
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Endpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Endpoint) ProtoMessage() {}
+
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Endpoint) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *Endpoint) GetInt() string {
+	if x != nil {
+		return x.Int
+	}
+	return ""
+}
+
+// Mirror continuously copies the traffic of one or more links to an interface
+// of a collector node. Sources are link endpoints and must all be on the same
+// node. The destination interface must not be used by a link, KNE connects it
+// to the source node with a dedicated link.
+type Mirror struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Sources     []*Endpoint      `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	Direction   Mirror_Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=topo.Mirror_Direction" json:"direction,omitempty"`
+	Destination *Endpoint        `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+}
+
+func (x *Mirror) Reset() {
+	*x = Mirror{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mirror) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mirror) ProtoMessage() {}
+
+func (x *Mirror) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mirror.ProtoReflect.Descriptor instead.
+func (*Mirror) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Mirror) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mirror) GetSources() []*Endpoint {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *Mirror) GetDirection() Mirror_Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Mirror_BOTH
+}
+
+func (x *Mirror) GetDestination() *Endpoint {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+// Config is the k8s pod specific configuration for a node.
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"` // Command to pass into pod.
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`       // Command args to pass into the pod.
+	Image   string   `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`     // Docker image to use with pod.
+	// Map of environment variables to pass into the pod.
+	Env map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Specific entry point command for accessing the pod.
+	EntryCommand string `protobuf:"bytes,5,opt,name=entry_command,json=entryCommand,proto3" json:"entry_command,omitempty"`
+	// Mount point for configuration inside the pod.
+	ConfigPath string `protobuf:"bytes,6,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	// Default configuration file name for the pod.
+	ConfigFile string `protobuf:"bytes,7,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"`
+	Sleep      uint32 `protobuf:"varint,8,opt,name=sleep,proto3" json:"sleep,omitempty"` // Sleeptime before starting the pod.
+	// Certificate configuration
+	Cert *CertificateCfg `protobuf:"bytes,9,opt,name=cert,proto3" json:"cert,omitempty"`
+	// Types that are assignable to ConfigData:
+	//
+	//	*Config_Data
+	//	*Config_File
+	ConfigData isConfig_ConfigData `protobuf_oneof:"config_data"`
+	// Docker image to use as an init container for the pod.
+	InitImage string `protobuf:"bytes,10,opt,name=init_image,json=initImage,proto3" json:"init_image,omitempty"`
+	// Vendor-specific data
+	VendorData *anypb.Any `protobuf:"bytes,11,opt,name=vendor_data,json=vendorData,proto3" json:"vendor_data,omitempty"`
+	// Kubernetes Secrets, such as licenses and credentials, made available to
+	// the node.
+	Secrets []*SecretRef `protobuf:"bytes,12,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	// If set, the startup configuration is a Go text/template rendered with
+	// the node and the topology before it is used.
+	ConfigTemplate bool `protobuf:"varint,13,opt,name=config_template,json=configTemplate,proto3" json:"config_template,omitempty"`
+	// Additional files mounted into the node containers.
+	FileMounts []*FileMount `protobuf:"bytes,14,rep,name=file_mounts,json=fileMounts,proto3" json:"file_mounts,omitempty"`
+	// Persistent volumes mounted into the node containers.
+	Volumes []*PersistentVolume `protobuf:"bytes,15,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// Patches applied to the pod of the node, after the pod patches of the
+	// vendor of the node.
+	PodPatches []*PodPatch `protobuf:"bytes,16,rep,name=pod_patches,json=podPatches,proto3" json:"pod_patches,omitempty"`
+	// Options of the config push and reset of nodes configured through gNMI.
+	Gnmi *GNMIOptions `protobuf:"bytes,17,opt,name=gnmi,proto3" json:"gnmi,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,104 +2554,205 @@ func (x *Interface) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
-func (*Interface) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Interface) GetName() string {
+func (x *Config) GetCommand() []string {
 	if x != nil {
-		return x.Name
+		return x.Command
 	}
-	return ""
+	return nil
 }
 
-func (x *Interface) GetIntName() string {
+func (x *Config) GetArgs() []string {
 	if x != nil {
-		return x.IntName
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Config) GetImage() string {
+	if x != nil {
+		return x.Image
 	}
 	return ""
 }
 
-func (x *Interface) GetMtu() uint32 {
+func (x *Config) GetEnv() map[string]string {
 	if x != nil {
-		return x.Mtu
+		return x.Env
 	}
-	return 0
+	return nil
 }
 
-func (x *Interface) GetPeerName() string {
+func (x *Config) GetEntryCommand() string {
 	if x != nil {
-		return x.PeerName
+		return x.EntryCommand
 	}
 	return ""
 }
 
-func (x *Interface) GetPeerIntName() string {
+func (x *Config) GetConfigPath() string {
 	if x != nil {
-		return x.PeerIntName
+		return x.ConfigPath
 	}
 	return ""
 }
 
-func (x *Interface) GetUid() int64 {
+func (x *Config) GetConfigFile() string {
 	if x != nil {
-		return x.Uid
+		return x.ConfigFile
+	}
+	return ""
+}
+
+func (x *Config) GetSleep() uint32 {
+	if x != nil {
+		return x.Sleep
 	}
 	return 0
 }
 
-func (x *Interface) GetGroup() string {
+func (x *Config) GetCert() *CertificateCfg {
 	if x != nil {
-		return x.Group
+		return x.Cert
+	}
+	return nil
+}
+
+func (m *Config) GetConfigData() isConfig_ConfigData {
+	if m != nil {
+		return m.ConfigData
+	}
+	return nil
+}
+
+func (x *Config) GetData() []byte {
+	if x, ok := x.GetConfigData().(*Config_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Config) GetFile() string {
+	if x, ok := x.GetConfigData().(*Config_File); ok {
+		return x.File
 	}
 	return ""
 }
 
-func (x *Interface) GetType() Interface_InterfaceType {
+func (x *Config) GetInitImage() string {
 	if x != nil {
-		return x.Type
+		return x.InitImage
 	}
-	return Interface_UNKNOWN
+	return ""
 }
 
-func (x *Interface) GetIpAddress() string {
+func (x *Config) GetVendorData() *anypb.Any {
 	if x != nil {
-		return x.IpAddress
+		return x.VendorData
 	}
-	return ""
+	return nil
 }
 
-// Link is single link between nodes in the topology.
-// Interfaces must start eth1 - eth0 is the default k8s interface.
-type Link struct {
+func (x *Config) GetSecrets() []*SecretRef {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *Config) GetConfigTemplate() bool {
+	if x != nil {
+		return x.ConfigTemplate
+	}
+	return false
+}
+
+func (x *Config) GetFileMounts() []*FileMount {
+	if x != nil {
+		return x.FileMounts
+	}
+	return nil
+}
+
+func (x *Config) GetVolumes() []*PersistentVolume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Config) GetPodPatches() []*PodPatch {
+	if x != nil {
+		return x.PodPatches
+	}
+	return nil
+}
+
+func (x *Config) GetGnmi() *GNMIOptions {
+	if x != nil {
+		return x.Gnmi
+	}
+	return nil
+}
+
+type isConfig_ConfigData interface {
+	isConfig_ConfigData()
+}
+
+type Config_Data struct {
+	// Byte data for the startup configuration file.
+	Data []byte `protobuf:"bytes,101,opt,name=data,proto3,oneof"`
+}
+
+type Config_File struct {
+	// File is always relative to the topology configuration file.
+	File string `protobuf:"bytes,102,opt,name=file,proto3,oneof"`
+}
+
+func (*Config_Data) isConfig_ConfigData() {}
+
+func (*Config_File) isConfig_ConfigData() {}
+
+// GNMIOptions are the options of the config push and reset of nodes
+// configured with gNMI Set requests.
+type GNMIOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ANode string `protobuf:"bytes,1,opt,name=a_node,json=aNode,proto3" json:"a_node,omitempty"`
-	AInt  string `protobuf:"bytes,2,opt,name=a_int,json=aInt,proto3" json:"a_int,omitempty"`
-	ZNode string `protobuf:"bytes,3,opt,name=z_node,json=zNode,proto3" json:"z_node,omitempty"`
-	ZInt  string `protobuf:"bytes,4,opt,name=z_int,json=zInt,proto3" json:"z_int,omitempty"`
+	// Encoding of the configs.
+	Encoding GNMIOptions_Encoding `protobuf:"varint,1,opt,name=encoding,proto3,enum=topo.GNMIOptions_Encoding" json:"encoding,omitempty"`
+	// If set pushed configs are merged with an update instead of replacing the
+	// config at path. Reset always replaces the config.
+	Update bool `protobuf:"varint,2,opt,name=update,proto3" json:"update,omitempty"`
+	// Path of the configs in the gNMI path string format, such as
+	// /interfaces/interface[name=eth1], defaults to the root.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Name of the service of the node used to connect, defaults to gnmi.
+	Service string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
 }
 
-func (x *Link) Reset() {
-	*x = Link{}
+func (x *GNMIOptions) Reset() {
+	*x = GNMIOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[6]
+		mi := &file_topo_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Link) String() string {
+func (x *GNMIOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Link) ProtoMessage() {}
+func (*GNMIOptions) ProtoMessage() {}
 
-func (x *Link) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[6]
+func (x *GNMIOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,87 +2763,162 @@ func (x *Link) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Link.ProtoReflect.Descriptor instead.
-func (*Link) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GNMIOptions.ProtoReflect.Descriptor instead.
+func (*GNMIOptions) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Link) GetANode() string {
+func (x *GNMIOptions) GetEncoding() GNMIOptions_Encoding {
 	if x != nil {
-		return x.ANode
+		return x.Encoding
 	}
-	return ""
+	return GNMIOptions_JSON_IETF
 }
 
-func (x *Link) GetAInt() string {
+func (x *GNMIOptions) GetUpdate() bool {
 	if x != nil {
-		return x.AInt
+		return x.Update
 	}
-	return ""
+	return false
 }
 
-func (x *Link) GetZNode() string {
+func (x *GNMIOptions) GetPath() string {
 	if x != nil {
-		return x.ZNode
+		return x.Path
 	}
 	return ""
 }
 
-func (x *Link) GetZInt() string {
+func (x *GNMIOptions) GetService() string {
 	if x != nil {
-		return x.ZInt
+		return x.Service
 	}
 	return ""
 }
 
-// Config is the k8s pod specific configuration for a node.
-type Config struct {
+// PersistentVolume is storage which outlives the pod of a node, such as logs,
+// core dumps and saved configs. It is backed by a PersistentVolumeClaim named
+// <node>-<name> in the topology namespace.
+type PersistentVolume struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Command []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"` // Command to pass into pod.
-	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`       // Command args to pass into the pod.
-	Image   string   `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`     // Docker image to use with pod.
-	// Map of environment variables to pass into the pod.
-	Env map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Specific entry point command for accessing the pod.
-	EntryCommand string `protobuf:"bytes,5,opt,name=entry_command,json=entryCommand,proto3" json:"entry_command,omitempty"`
-	// Mount point for configuration inside the pod.
-	ConfigPath string `protobuf:"bytes,6,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
-	// Default configuration file name for the pod.
-	ConfigFile string `protobuf:"bytes,7,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"`
-	Sleep      uint32 `protobuf:"varint,8,opt,name=sleep,proto3" json:"sleep,omitempty"` // Sleeptime before starting the pod.
-	// Certificate configuration
-	Cert *CertificateCfg `protobuf:"bytes,9,opt,name=cert,proto3" json:"cert,omitempty"`
-	// Types that are assignable to ConfigData:
-	//
-	//	*Config_Data
-	//	*Config_File
-	ConfigData isConfig_ConfigData `protobuf_oneof:"config_data"`
-	// Docker image to use as an init container for the pod.
-	InitImage string `protobuf:"bytes,10,opt,name=init_image,json=initImage,proto3" json:"init_image,omitempty"`
-	// Vendor-specific data
-	VendorData *anypb.Any `protobuf:"bytes,11,opt,name=vendor_data,json=vendorData,proto3" json:"vendor_data,omitempty"`
+	// Name of the volume, unique per node.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Requested size, e.g. 10Gi.
+	Size string `protobuf:"bytes,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Storage class of the claim. Defaults to the default class of the cluster.
+	StorageClass string `protobuf:"bytes,3,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	// Path the volume is mounted at in the containers.
+	MountPath string                  `protobuf:"bytes,4,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	Policy    PersistentVolume_Policy `protobuf:"varint,5,opt,name=policy,proto3,enum=topo.PersistentVolume_Policy" json:"policy,omitempty"`
+}
+
+func (x *PersistentVolume) Reset() {
+	*x = PersistentVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PersistentVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersistentVolume) ProtoMessage() {}
+
+func (x *PersistentVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersistentVolume.ProtoReflect.Descriptor instead.
+func (*PersistentVolume) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PersistentVolume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PersistentVolume) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *PersistentVolume) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *PersistentVolume) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
 }
 
-func (x *Config) Reset() {
-	*x = Config{}
+func (x *PersistentVolume) GetPolicy() PersistentVolume_Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return PersistentVolume_DELETE
+}
+
+// FileMount is a local file or directory mounted into the node containers.
+// Files are stored in a ConfigMap, or a Secret for sensitive files, and are
+// limited to 1MiB in total per mount.
+type FileMount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Local file or directory, relative to the topology configuration file.
+	// All regular files of a directory are mounted.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Path of the file, or of the directory for a directory source, in the
+	// containers.
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Permission bits of the mounted files, e.g. 0755. Defaults to 0644.
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// If set the files are stored in a Secret instead of a ConfigMap.
+	Secret bool `protobuf:"varint,4,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *FileMount) Reset() {
+	*x = FileMount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[7]
+		mi := &file_topo_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Config) String() string {
+func (x *FileMount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Config) ProtoMessage() {}
+func (*FileMount) ProtoMessage() {}
 
-func (x *Config) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[7]
+func (x *FileMount) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,127 +2929,129 @@ func (x *Config) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Config.ProtoReflect.Descriptor instead.
-func (*Config) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *Config) GetCommand() []string {
-	if x != nil {
-		return x.Command
-	}
-	return nil
+// Deprecated: Use FileMount.ProtoReflect.Descriptor instead.
+func (*FileMount) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *Config) GetArgs() []string {
+func (x *FileMount) GetSource() string {
 	if x != nil {
-		return x.Args
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-func (x *Config) GetImage() string {
+func (x *FileMount) GetDestination() string {
 	if x != nil {
-		return x.Image
+		return x.Destination
 	}
 	return ""
 }
 
-func (x *Config) GetEnv() map[string]string {
+func (x *FileMount) GetMode() uint32 {
 	if x != nil {
-		return x.Env
+		return x.Mode
 	}
-	return nil
+	return 0
 }
 
-func (x *Config) GetEntryCommand() string {
+func (x *FileMount) GetSecret() bool {
 	if x != nil {
-		return x.EntryCommand
+		return x.Secret
 	}
-	return ""
+	return false
 }
 
-func (x *Config) GetConfigPath() string {
-	if x != nil {
-		return x.ConfigPath
-	}
-	return ""
-}
+// SecretRef references a Kubernetes Secret used by a node. The values of the
+// Secret are never logged or included in the topology.
+type SecretRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *Config) GetConfigFile() string {
-	if x != nil {
-		return x.ConfigFile
+	// Name of the Secret in the topology namespace.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Namespace of an existing Secret which is copied into the topology
+	// namespace. If neither namespace nor files are set the Secret must already
+	// exist in the topology namespace.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Local files the Secret is created from, keyed by the Secret key. Files are
+	// always relative to the topology configuration file.
+	Files map[string]string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Directory the Secret is mounted at, with one file per key.
+	MountPath string `protobuf:"bytes,4,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	// Environment variables set from the Secret, mapping the variable name to
+	// the Secret key.
+	Env map[string]string `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SecretRef) Reset() {
+	*x = SecretRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Config) GetSleep() uint32 {
-	if x != nil {
-		return x.Sleep
-	}
-	return 0
+func (x *SecretRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Config) GetCert() *CertificateCfg {
-	if x != nil {
-		return x.Cert
-	}
-	return nil
-}
+func (*SecretRef) ProtoMessage() {}
 
-func (m *Config) GetConfigData() isConfig_ConfigData {
-	if m != nil {
-		return m.ConfigData
+func (x *SecretRef) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Config) GetData() []byte {
-	if x, ok := x.GetConfigData().(*Config_Data); ok {
-		return x.Data
-	}
-	return nil
+// Deprecated: Use SecretRef.ProtoReflect.Descriptor instead.
+func (*SecretRef) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Config) GetFile() string {
-	if x, ok := x.GetConfigData().(*Config_File); ok {
-		return x.File
+func (x *SecretRef) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Config) GetInitImage() string {
+func (x *SecretRef) GetNamespace() string {
 	if x != nil {
-		return x.InitImage
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *Config) GetVendorData() *anypb.Any {
+func (x *SecretRef) GetFiles() map[string]string {
 	if x != nil {
-		return x.VendorData
+		return x.Files
 	}
 	return nil
 }
 
-type isConfig_ConfigData interface {
-	isConfig_ConfigData()
-}
-
-type Config_Data struct {
-	// Byte data for the startup configuration file.
-	Data []byte `protobuf:"bytes,101,opt,name=data,proto3,oneof"`
+func (x *SecretRef) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
 }
 
-type Config_File struct {
-	// File is always relative to the topology configuration file.
-	File string `protobuf:"bytes,102,opt,name=file,proto3,oneof"`
+func (x *SecretRef) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
 }
 
-func (*Config_Data) isConfig_ConfigData() {}
-
-func (*Config_File) isConfig_ConfigData() {}
-
 type CertificateCfg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1081,13 +3060,14 @@ type CertificateCfg struct {
 	// Types that are assignable to Config:
 	//
 	//	*CertificateCfg_SelfSigned
+	//	*CertificateCfg_CaIssued
 	Config isCertificateCfg_Config `protobuf_oneof:"config"`
 }
 
 func (x *CertificateCfg) Reset() {
 	*x = CertificateCfg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[8]
+		mi := &file_topo_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1100,7 +3080,7 @@ func (x *CertificateCfg) String() string {
 func (*CertificateCfg) ProtoMessage() {}
 
 func (x *CertificateCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[8]
+	mi := &file_topo_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +3093,7 @@ func (x *CertificateCfg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateCfg.ProtoReflect.Descriptor instead.
 func (*CertificateCfg) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{8}
+	return file_topo_proto_rawDescGZIP(), []int{26}
 }
 
 func (m *CertificateCfg) GetConfig() isCertificateCfg_Config {
@@ -1130,6 +3110,13 @@ func (x *CertificateCfg) GetSelfSigned() *SelfSignedCertCfg {
 	return nil
 }
 
+func (x *CertificateCfg) GetCaIssued() *CAIssuedCertCfg {
+	if x, ok := x.GetConfig().(*CertificateCfg_CaIssued); ok {
+		return x.CaIssued
+	}
+	return nil
+}
+
 type isCertificateCfg_Config interface {
 	isCertificateCfg_Config()
 }
@@ -1139,8 +3126,101 @@ type CertificateCfg_SelfSigned struct {
 	SelfSigned *SelfSignedCertCfg `protobuf:"bytes,1,opt,name=self_signed,json=selfSigned,proto3,oneof"`
 }
 
+type CertificateCfg_CaIssued struct {
+	// ca_issued will install a certificate issued by the topology CA on the
+	// node.
+	CaIssued *CAIssuedCertCfg `protobuf:"bytes,2,opt,name=ca_issued,json=caIssued,proto3,oneof"`
+}
+
 func (*CertificateCfg_SelfSigned) isCertificateCfg_Config() {}
 
+func (*CertificateCfg_CaIssued) isCertificateCfg_Config() {}
+
+type CAIssuedCertCfg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Certificate name on the node.
+	CertName string `protobuf:"bytes,1,opt,name=cert_name,json=certName,proto3" json:"cert_name,omitempty"`
+	// Key name on the node.
+	KeyName string `protobuf:"bytes,2,opt,name=key_name,json=keyName,proto3" json:"key_name,omitempty"`
+	// RSA keysize to use for key generation, defaults to 2048.
+	KeySize uint32 `protobuf:"varint,3,opt,name=key_size,json=keySize,proto3" json:"key_size,omitempty"`
+	// Common name to set in the cert, defaults to the node name.
+	CommonName string `protobuf:"bytes,4,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	// Additional DNS names or IPs of the cert. The node name, pod IP and
+	// external IP of the node are always included.
+	Sans []string `protobuf:"bytes,5,rep,name=sans,proto3" json:"sans,omitempty"`
+}
+
+func (x *CAIssuedCertCfg) Reset() {
+	*x = CAIssuedCertCfg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CAIssuedCertCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CAIssuedCertCfg) ProtoMessage() {}
+
+func (x *CAIssuedCertCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CAIssuedCertCfg.ProtoReflect.Descriptor instead.
+func (*CAIssuedCertCfg) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CAIssuedCertCfg) GetCertName() string {
+	if x != nil {
+		return x.CertName
+	}
+	return ""
+}
+
+func (x *CAIssuedCertCfg) GetKeyName() string {
+	if x != nil {
+		return x.KeyName
+	}
+	return ""
+}
+
+func (x *CAIssuedCertCfg) GetKeySize() uint32 {
+	if x != nil {
+		return x.KeySize
+	}
+	return 0
+}
+
+func (x *CAIssuedCertCfg) GetCommonName() string {
+	if x != nil {
+		return x.CommonName
+	}
+	return ""
+}
+
+func (x *CAIssuedCertCfg) GetSans() []string {
+	if x != nil {
+		return x.Sans
+	}
+	return nil
+}
+
 type SelfSignedCertCfg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1159,7 +3239,7 @@ type SelfSignedCertCfg struct {
 func (x *SelfSignedCertCfg) Reset() {
 	*x = SelfSignedCertCfg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[9]
+		mi := &file_topo_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1172,7 +3252,7 @@ func (x *SelfSignedCertCfg) String() string {
 func (*SelfSignedCertCfg) ProtoMessage() {}
 
 func (x *SelfSignedCertCfg) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[9]
+	mi := &file_topo_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +3265,7 @@ func (x *SelfSignedCertCfg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelfSignedCertCfg.ProtoReflect.Descriptor instead.
 func (*SelfSignedCertCfg) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{9}
+	return file_topo_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SelfSignedCertCfg) GetCertName() string {
@@ -1240,13 +3320,14 @@ type Service struct {
 	// the unspecified field is ignored.
 	// If both are provided, names in both fields are considered excluding any
 	// duplicate values.
-	Names []string `protobuf:"bytes,7,rep,name=names,proto3" json:"names,omitempty"`
+	Names    []string `protobuf:"bytes,7,rep,name=names,proto3" json:"names,omitempty"`
+	Protocol Protocol `protobuf:"varint,8,opt,name=protocol,proto3,enum=topo.Protocol" json:"protocol,omitempty"` // Protocol of the port, defaults to TCP.
 }
 
 func (x *Service) Reset() {
 	*x = Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[10]
+		mi := &file_topo_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1259,7 +3340,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[10]
+	mi := &file_topo_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1272,7 +3353,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{10}
+	return file_topo_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Service) GetName() string {
@@ -1282,44 +3363,130 @@ func (x *Service) GetName() string {
 	return ""
 }
 
-func (x *Service) GetInside() uint32 {
-	if x != nil {
-		return x.Inside
+func (x *Service) GetInside() uint32 {
+	if x != nil {
+		return x.Inside
+	}
+	return 0
+}
+
+func (x *Service) GetOutside() uint32 {
+	if x != nil {
+		return x.Outside
+	}
+	return 0
+}
+
+func (x *Service) GetOutsideIp() string {
+	if x != nil {
+		return x.OutsideIp
+	}
+	return ""
+}
+
+func (x *Service) GetInsideIp() string {
+	if x != nil {
+		return x.InsideIp
+	}
+	return ""
+}
+
+func (x *Service) GetNodePort() uint32 {
+	if x != nil {
+		return x.NodePort
+	}
+	return 0
+}
+
+func (x *Service) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Service) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_TCP
+}
+
+// ServiceOptions controls the Kubernetes Service exposing the services of a
+// node.
+type ServiceOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type ServiceOptions_Type `protobuf:"varint,1,opt,name=type,proto3,enum=topo.ServiceOptions_Type" json:"type,omitempty"`
+	// External IPs requested from the load balancer, at most one per IP family.
+	// Only valid for the LOAD_BALANCER type.
+	LoadBalancerIps []string `protobuf:"bytes,2,rep,name=load_balancer_ips,json=loadBalancerIps,proto3" json:"load_balancer_ips,omitempty"`
+	// MetalLB address pool to allocate the external IP from. Only valid for the
+	// LOAD_BALANCER type.
+	AddressPool string `protobuf:"bytes,3,opt,name=address_pool,json=addressPool,proto3" json:"address_pool,omitempty"`
+	// Additional annotations of the Service, such as those of other load
+	// balancer implementations.
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServiceOptions) Reset() {
+	*x = ServiceOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOptions) ProtoMessage() {}
+
+func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Service) GetOutside() uint32 {
-	if x != nil {
-		return x.Outside
-	}
-	return 0
+// Deprecated: Use ServiceOptions.ProtoReflect.Descriptor instead.
+func (*ServiceOptions) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *Service) GetOutsideIp() string {
+func (x *ServiceOptions) GetType() ServiceOptions_Type {
 	if x != nil {
-		return x.OutsideIp
+		return x.Type
 	}
-	return ""
+	return ServiceOptions_LOAD_BALANCER
 }
 
-func (x *Service) GetInsideIp() string {
+func (x *ServiceOptions) GetLoadBalancerIps() []string {
 	if x != nil {
-		return x.InsideIp
+		return x.LoadBalancerIps
 	}
-	return ""
+	return nil
 }
 
-func (x *Service) GetNodePort() uint32 {
+func (x *ServiceOptions) GetAddressPool() string {
 	if x != nil {
-		return x.NodePort
+		return x.AddressPool
 	}
-	return 0
+	return ""
 }
 
-func (x *Service) GetNames() []string {
+func (x *ServiceOptions) GetAnnotations() map[string]string {
 	if x != nil {
-		return x.Names
+		return x.Annotations
 	}
 	return nil
 }
@@ -1329,80 +3496,267 @@ var File_topo_proto protoreflect.FileDescriptor
 var file_topo_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x74, 0x6f,
 	0x70, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a,
-	0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
-	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74,
-	0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12,
-	0x20, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a,
-	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b,
-	0x73, 0x22, 0xa6, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x74,
-	0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x02, 0x18,
-	0x01, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e,
-	0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a,
-	0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
-	0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
-	0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
-	0x74, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72,
-	0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
-	0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x10, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6e,
-	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
-	0x61, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x68, 0x6f, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
-	0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x6f, 0x64, 0x5f, 0x69, 0x70, 0x18,
-	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x70, 0x1a, 0x39, 0x0a, 0x0b,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4a, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
-	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
-	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x1a, 0x4e, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0xfe, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x53,
-	0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x52, 0x49, 0x53, 0x54, 0x41, 0x5f, 0x43, 0x45,
-	0x4f, 0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4a, 0x55, 0x4e, 0x49, 0x50, 0x45, 0x52, 0x5f,
-	0x43, 0x45, 0x56, 0x4f, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x49, 0x53, 0x43, 0x4f, 0x5f,
-	0x43, 0x58, 0x52, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x55, 0x41, 0x47, 0x47, 0x41, 0x10,
-	0x05, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x52, 0x52, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x55,
-	0x4e, 0x49, 0x50, 0x45, 0x52, 0x5f, 0x56, 0x4d, 0x58, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x43,
-	0x49, 0x53, 0x43, 0x4f, 0x5f, 0x43, 0x53, 0x52, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f,
-	0x4b, 0x49, 0x41, 0x5f, 0x53, 0x52, 0x4c, 0x10, 0x09, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x58, 0x49,
-	0x41, 0x5f, 0x54, 0x47, 0x10, 0x0a, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4f, 0x42, 0x47, 0x50, 0x10,
-	0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x49, 0x53, 0x43, 0x4f, 0x5f, 0x58, 0x52, 0x44, 0x10, 0x0c,
-	0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x49, 0x53, 0x43, 0x4f, 0x5f, 0x45, 0x38, 0x30, 0x30, 0x30, 0x10,
-	0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45, 0x4d, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x0e, 0x12, 0x14,
-	0x0a, 0x10, 0x49, 0x4e, 0x5f, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x4f,
-	0x58, 0x59, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x49, 0x45, 0x4e, 0x41, 0x5f, 0x53, 0x41,
-	0x4f, 0x53, 0x10, 0x10, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x60, 0x0a, 0x0e, 0x48, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfc, 0x01,
+	0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x12, 0x26, 0x0a, 0x07, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x07, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x44, 0x0a, 0x12, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x56, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x10,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x12, 0x2a, 0x0a, 0x02, 0x63, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
+	0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x02, 0x63, 0x61, 0x22, 0xab, 0x01, 0x0a,
+	0x14, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x62, 0x0a, 0x10, 0x56, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x52, 0x06, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6f, 0x64,
+	0x50, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x92,
+	0x01, 0x0a, 0x08, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x27, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x22, 0x25, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54,
+	0x52, 0x41, 0x54, 0x45, 0x47, 0x49, 0x43, 0x5f, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x22, 0x8c, 0x0a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x27, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x34, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x6f, 0x70,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x56, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x10, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x63,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x68, 0x6f, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x70, 0x6f, 0x64, 0x5f, 0x69,
+	0x70, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x70, 0x12, 0x2d,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a,
+	0x08, 0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x08,
+	0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x73, 0x12, 0x3d, 0x0a, 0x0f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74,
+	0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52,
+	0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x4a, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4e, 0x0a, 0x0f, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfe, 0x01, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41,
+	0x52, 0x49, 0x53, 0x54, 0x41, 0x5f, 0x43, 0x45, 0x4f, 0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c,
+	0x4a, 0x55, 0x4e, 0x49, 0x50, 0x45, 0x52, 0x5f, 0x43, 0x45, 0x56, 0x4f, 0x10, 0x03, 0x12, 0x0d,
+	0x0a, 0x09, 0x43, 0x49, 0x53, 0x43, 0x4f, 0x5f, 0x43, 0x58, 0x52, 0x10, 0x04, 0x12, 0x0a, 0x0a,
+	0x06, 0x51, 0x55, 0x41, 0x47, 0x47, 0x41, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x52, 0x52,
+	0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x55, 0x4e, 0x49, 0x50, 0x45, 0x52, 0x5f, 0x56, 0x4d,
+	0x58, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x49, 0x53, 0x43, 0x4f, 0x5f, 0x43, 0x53, 0x52,
+	0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x4b, 0x49, 0x41, 0x5f, 0x53, 0x52, 0x4c, 0x10,
+	0x09, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x58, 0x49, 0x41, 0x5f, 0x54, 0x47, 0x10, 0x0a, 0x12, 0x09,
+	0x0a, 0x05, 0x47, 0x4f, 0x42, 0x47, 0x50, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x49, 0x53,
+	0x43, 0x4f, 0x5f, 0x58, 0x52, 0x44, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x49, 0x53, 0x43,
+	0x4f, 0x5f, 0x45, 0x38, 0x30, 0x30, 0x30, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45, 0x4d,
+	0x4d, 0x49, 0x4e, 0x47, 0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x5f, 0x43, 0x4c, 0x55,
+	0x53, 0x54, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x4f, 0x58, 0x59, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a,
+	0x43, 0x49, 0x45, 0x4e, 0x41, 0x5f, 0x53, 0x41, 0x4f, 0x53, 0x10, 0x10, 0x4a, 0x04, 0x08, 0x03,
+	0x10, 0x04, 0x22, 0x7f, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x22, 0xb4, 0x03, 0x0a, 0x07, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x69, 0x64, 0x65,
+	0x63, 0x61, 0x72, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e,
+	0x76, 0x12, 0x2e, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x12, 0x2d, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61,
+	0x72, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
+	0x12, 0x36, 0x0a, 0x17, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x15, 0x73, 0x68, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76,
+	0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72,
+	0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x64, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x38, 0x0a, 0x0a, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x0d, 0x53, 0x69,
+	0x64, 0x65, 0x63, 0x61, 0x72, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xc1, 0x03, 0x0a, 0x09,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x46, 0x0a, 0x0d, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x3b, 0x0a, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d,
+	0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x38,
+	0x0a, 0x0c, 0x70, 0x6f, 0x64, 0x5f, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6f, 0x64, 0x41,
+	0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x0b, 0x70, 0x6f, 0x64,
+	0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x11, 0x70, 0x6f, 0x64, 0x5f,
+	0x61, 0x6e, 0x74, 0x69, 0x5f, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6f, 0x64, 0x41, 0x66,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x0f, 0x70, 0x6f, 0x64, 0x41,
+	0x6e, 0x74, 0x69, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x32, 0x0a, 0x0b, 0x74,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3d, 0x0a, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x73, 0x70, 0x72, 0x65,
+	0x61, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
+	0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x52, 0x0e,
+	0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x1a, 0x3f,
+	0x0a, 0x11, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xc9, 0x01, 0x0a, 0x10, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x08, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x45,
+	0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x4f, 0x45, 0x53, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x47,
+	0x54, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x05, 0x22, 0x6f, 0x0a, 0x10, 0x4e,
+	0x6f, 0x64, 0x65, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x12,
+	0x43, 0x0a, 0x11, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70,
+	0x6f, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x10, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x9c, 0x02, 0x0a,
+	0x0f, 0x50, 0x6f, 0x64, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d,
+	0x12, 0x49, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6f,
+	0x64, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x2e, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x43, 0x0a, 0x11, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x10,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x1a, 0x3e, 0x0a, 0x10, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd5, 0x01, 0x0a, 0x0a,
+	0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x08,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x74,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x22, 0x21, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x58, 0x49, 0x53, 0x54,
+	0x53, 0x10, 0x01, 0x22, 0xf4, 0x01, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x6b,
+	0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x6b, 0x65,
+	0x77, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x48, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x2e, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x60, 0x0a, 0x0e, 0x48, 0x6f,
 	0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x11,
 	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4b,
@@ -1447,81 +3801,207 @@ var file_topo_proto_rawDesc = []byte{
 	0x05, 0x61, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x49,
 	0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x7a, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x05, 0x7a, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x7a, 0x5f, 0x69,
-	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x49, 0x6e, 0x74, 0x22, 0xe5,
-	0x03, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
-	0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a,
-	0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
-	0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x6c, 0x65, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6c,
-	0x65, 0x65, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x43, 0x66, 0x67, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x14, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x66, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x69,
-	0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69,
-	0x6e, 0x69, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x76, 0x65, 0x6e, 0x64,
-	0x6f, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x41, 0x6e, 0x79, 0x52, 0x0a, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x1a,
-	0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22, 0x56, 0x0a, 0x0e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x66, 0x67, 0x12, 0x3a, 0x0a, 0x0b, 0x73, 0x65, 0x6c, 0x66,
-	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x43,
-	0x65, 0x72, 0x74, 0x43, 0x66, 0x67, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x66, 0x53, 0x69,
-	0x67, 0x6e, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x87,
-	0x01, 0x0a, 0x11, 0x53, 0x65, 0x6c, 0x66, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x43, 0x65, 0x72,
-	0x74, 0x43, 0x66, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08,
-	0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
-	0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xbe, 0x01, 0x0a, 0x07, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x73, 0x69,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x75,
-	0x74, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x73,
-	0x69, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e,
-	0x73, 0x69, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x50,
-	0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x2a, 0xe0, 0x01, 0x0a, 0x06, 0x56, 0x65,
-	0x6e, 0x64, 0x6f, 0x72, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41,
-	0x52, 0x49, 0x53, 0x54, 0x41, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x49, 0x53, 0x43, 0x4f,
-	0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x55, 0x4e, 0x49, 0x50, 0x45, 0x52, 0x10, 0x04, 0x12,
-	0x0c, 0x0a, 0x08, 0x4b, 0x45, 0x59, 0x53, 0x49, 0x47, 0x48, 0x54, 0x10, 0x05, 0x12, 0x07, 0x0a,
-	0x03, 0x46, 0x52, 0x52, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x55, 0x41, 0x47, 0x47, 0x41,
-	0x10, 0x07, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4f, 0x42, 0x47, 0x50, 0x10, 0x08, 0x12, 0x09, 0x0a,
-	0x05, 0x4e, 0x4f, 0x4b, 0x49, 0x41, 0x10, 0x09, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x45, 0x4e,
-	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4c, 0x50, 0x49,
-	0x4e, 0x45, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x52, 0x49, 0x56, 0x45, 0x4e, 0x45, 0x54,
-	0x53, 0x10, 0x0c, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x10, 0x0d,
-	0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x5f, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50,
-	0x52, 0x4f, 0x58, 0x59, 0x10, 0x0e, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x4f, 0x4e, 0x49, 0x43, 0x10,
-	0x0f, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x49, 0x45, 0x4e, 0x41, 0x10, 0x10, 0x42, 0x26, 0x5a, 0x24,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6b, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x74, 0x6f, 0x70, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x49, 0x6e, 0x74, 0x22, 0x30,
+	0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x6e, 0x74,
+	0x22, 0xde, 0x01, 0x0a, 0x06, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x28, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74,
+	0x6f, 0x70, 0x6f, 0x2e, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x30, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x2e, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08,
+	0x0a, 0x04, 0x42, 0x4f, 0x54, 0x48, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x47, 0x52,
+	0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10,
+	0x02, 0x22, 0xf5, 0x05, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x27, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x65, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x73, 0x6c, 0x65, 0x65, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x66, 0x67, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74,
+	0x12, 0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x66,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x6e, 0x69, 0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x76,
+	0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x0a, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x66, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x66, 0x69,
+	0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x0b, 0x70, 0x6f,
+	0x64, 0x5f, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x0a, 0x70, 0x6f, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x67,
+	0x6e, 0x6d, 0x69, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x47, 0x4e, 0x4d, 0x49, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x67, 0x6e,
+	0x6d, 0x69, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb0, 0x01, 0x0a, 0x0b, 0x47, 0x4e,
+	0x4d, 0x49, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x47, 0x4e, 0x4d, 0x49, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x08, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x0d, 0x0a, 0x09, 0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x45, 0x54, 0x46,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x22, 0xd7, 0x01, 0x0a,
+	0x10, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x35, 0x0a,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e,
+	0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x22, 0x20, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0a,
+	0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45,
+	0x54, 0x41, 0x49, 0x4e, 0x10, 0x01, 0x22, 0x71, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xac, 0x02, 0x0a, 0x09, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2a, 0x0a, 0x03, 0x65, 0x6e,
+	0x76, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8c, 0x01, 0x0a, 0x0e, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x66, 0x67, 0x12, 0x3a, 0x0a, 0x0b, 0x73,
+	0x65, 0x6c, 0x66, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x43, 0x66, 0x67, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65, 0x6c,
+	0x66, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x61, 0x5f, 0x69, 0x73,
+	0x73, 0x75, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70,
+	0x6f, 0x2e, 0x43, 0x41, 0x49, 0x73, 0x73, 0x75, 0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x43, 0x66,
+	0x67, 0x48, 0x00, 0x52, 0x08, 0x63, 0x61, 0x49, 0x73, 0x73, 0x75, 0x65, 0x64, 0x42, 0x08, 0x0a,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x43, 0x41, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x43, 0x66, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x65, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x65, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x61, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73,
+	0x61, 0x6e, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x6c, 0x66, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x43, 0x66, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65,
+	0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xea, 0x01,
+	0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x69,
+	0x6e, 0x73, 0x69, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b,
+	0x0a, 0x09, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x6e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0xd1, 0x02, 0x0a, 0x0e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x11,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x5f, 0x69, 0x70,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x72, 0x49, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x47, 0x0a, 0x0b, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d,
+	0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x52, 0x10, 0x00, 0x12,
+	0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x01, 0x12, 0x0e,
+	0x0a, 0x0a, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x49, 0x50, 0x10, 0x02, 0x2a, 0xed,
+	0x01, 0x0a, 0x06, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x53, 0x54, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x41, 0x52, 0x49, 0x53, 0x54, 0x41, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
+	0x43, 0x49, 0x53, 0x43, 0x4f, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x55, 0x4e, 0x49, 0x50,
+	0x45, 0x52, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x4b, 0x45, 0x59, 0x53, 0x49, 0x47, 0x48, 0x54,
+	0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x52, 0x52, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x51,
+	0x55, 0x41, 0x47, 0x47, 0x41, 0x10, 0x07, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4f, 0x42, 0x47, 0x50,
+	0x10, 0x08, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x4f, 0x4b, 0x49, 0x41, 0x10, 0x09, 0x12, 0x0e, 0x0a,
+	0x0a, 0x4f, 0x50, 0x45, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x10, 0x0a, 0x12, 0x0a, 0x0a,
+	0x06, 0x41, 0x4c, 0x50, 0x49, 0x4e, 0x45, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x52, 0x49,
+	0x56, 0x45, 0x4e, 0x45, 0x54, 0x53, 0x10, 0x0c, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x4f, 0x52, 0x57,
+	0x41, 0x52, 0x44, 0x10, 0x0d, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x5f, 0x43, 0x4c, 0x55, 0x53,
+	0x54, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x4f, 0x58, 0x59, 0x10, 0x0e, 0x12, 0x09, 0x0a, 0x05, 0x53,
+	0x4f, 0x4e, 0x49, 0x43, 0x10, 0x0f, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x49, 0x45, 0x4e, 0x41, 0x10,
+	0x10, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x10, 0x11, 0x2a, 0x26,
+	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43,
+	0x50, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x44, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
+	0x53, 0x43, 0x54, 0x50, 0x10, 0x02, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x6b, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1536,55 +4016,132 @@ func file_topo_proto_rawDescGZIP() []byte {
 	return file_topo_proto_rawDescData
 }
 
-var file_topo_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_topo_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_topo_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_topo_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_topo_proto_goTypes = []any{
-	(Vendor)(0),                  // 0: topo.Vendor
-	(Node_Type)(0),               // 1: topo.Node.Type
-	(Interface_InterfaceType)(0), // 2: topo.Interface.InterfaceType
-	(*Topology)(nil),             // 3: topo.Topology
-	(*Node)(nil),                 // 4: topo.Node
-	(*HostConstraint)(nil),       // 5: topo.HostConstraint
-	(*KernelParam)(nil),          // 6: topo.KernelParam
-	(*BoundedInteger)(nil),       // 7: topo.BoundedInteger
-	(*Interface)(nil),            // 8: topo.Interface
-	(*Link)(nil),                 // 9: topo.Link
-	(*Config)(nil),               // 10: topo.Config
-	(*CertificateCfg)(nil),       // 11: topo.CertificateCfg
-	(*SelfSignedCertCfg)(nil),    // 12: topo.SelfSignedCertCfg
-	(*Service)(nil),              // 13: topo.Service
-	nil,                          // 14: topo.Node.LabelsEntry
-	nil,                          // 15: topo.Node.ServicesEntry
-	nil,                          // 16: topo.Node.ConstraintsEntry
-	nil,                          // 17: topo.Node.InterfacesEntry
-	nil,                          // 18: topo.Config.EnvEntry
-	(*anypb.Any)(nil),            // 19: google.protobuf.Any
+	(Vendor)(0),                    // 0: topo.Vendor
+	(Protocol)(0),                  // 1: topo.Protocol
+	(PodPatch_Type)(0),             // 2: topo.PodPatch.Type
+	(Node_Type)(0),                 // 3: topo.Node.Type
+	(LabelRequirement_Operator)(0), // 4: topo.LabelRequirement.Operator
+	(Toleration_Operator)(0),       // 5: topo.Toleration.Operator
+	(Interface_InterfaceType)(0),   // 6: topo.Interface.InterfaceType
+	(Mirror_Direction)(0),          // 7: topo.Mirror.Direction
+	(GNMIOptions_Encoding)(0),      // 8: topo.GNMIOptions.Encoding
+	(PersistentVolume_Policy)(0),   // 9: topo.PersistentVolume.Policy
+	(ServiceOptions_Type)(0),       // 10: topo.ServiceOptions.Type
+	(*Topology)(nil),               // 11: topo.Topology
+	(*CertificateAuthority)(nil),   // 12: topo.CertificateAuthority
+	(*VendorPodPatches)(nil),       // 13: topo.VendorPodPatches
+	(*PodPatch)(nil),               // 14: topo.PodPatch
+	(*Node)(nil),                   // 15: topo.Node
+	(*Credentials)(nil),            // 16: topo.Credentials
+	(*Sidecar)(nil),                // 17: topo.Sidecar
+	(*SidecarVolume)(nil),          // 18: topo.SidecarVolume
+	(*Placement)(nil),              // 19: topo.Placement
+	(*LabelRequirement)(nil),       // 20: topo.LabelRequirement
+	(*NodeAffinityTerm)(nil),       // 21: topo.NodeAffinityTerm
+	(*PodAffinityTerm)(nil),        // 22: topo.PodAffinityTerm
+	(*Toleration)(nil),             // 23: topo.Toleration
+	(*TopologySpread)(nil),         // 24: topo.TopologySpread
+	(*HostConstraint)(nil),         // 25: topo.HostConstraint
+	(*KernelParam)(nil),            // 26: topo.KernelParam
+	(*BoundedInteger)(nil),         // 27: topo.BoundedInteger
+	(*Interface)(nil),              // 28: topo.Interface
+	(*Link)(nil),                   // 29: topo.Link
+	(*Endpoint)(nil),               // 30: topo.Endpoint
+	(*Mirror)(nil),                 // 31: topo.Mirror
+	(*Config)(nil),                 // 32: topo.Config
+	(*GNMIOptions)(nil),            // 33: topo.GNMIOptions
+	(*PersistentVolume)(nil),       // 34: topo.PersistentVolume
+	(*FileMount)(nil),              // 35: topo.FileMount
+	(*SecretRef)(nil),              // 36: topo.SecretRef
+	(*CertificateCfg)(nil),         // 37: topo.CertificateCfg
+	(*CAIssuedCertCfg)(nil),        // 38: topo.CAIssuedCertCfg
+	(*SelfSignedCertCfg)(nil),      // 39: topo.SelfSignedCertCfg
+	(*Service)(nil),                // 40: topo.Service
+	(*ServiceOptions)(nil),         // 41: topo.ServiceOptions
+	nil,                            // 42: topo.Node.LabelsEntry
+	nil,                            // 43: topo.Node.ServicesEntry
+	nil,                            // 44: topo.Node.ConstraintsEntry
+	nil,                            // 45: topo.Node.InterfacesEntry
+	nil,                            // 46: topo.Sidecar.EnvEntry
+	nil,                            // 47: topo.Sidecar.PortsEntry
+	nil,                            // 48: topo.Placement.NodeSelectorEntry
+	nil,                            // 49: topo.PodAffinityTerm.MatchLabelsEntry
+	nil,                            // 50: topo.TopologySpread.MatchLabelsEntry
+	nil,                            // 51: topo.Config.EnvEntry
+	nil,                            // 52: topo.SecretRef.FilesEntry
+	nil,                            // 53: topo.SecretRef.EnvEntry
+	nil,                            // 54: topo.ServiceOptions.AnnotationsEntry
+	(*anypb.Any)(nil),              // 55: google.protobuf.Any
 }
 var file_topo_proto_depIdxs = []int32{
-	4,  // 0: topo.Topology.nodes:type_name -> topo.Node
-	9,  // 1: topo.Topology.links:type_name -> topo.Link
-	1,  // 2: topo.Node.type:type_name -> topo.Node.Type
-	14, // 3: topo.Node.labels:type_name -> topo.Node.LabelsEntry
-	10, // 4: topo.Node.config:type_name -> topo.Config
-	15, // 5: topo.Node.services:type_name -> topo.Node.ServicesEntry
-	16, // 6: topo.Node.constraints:type_name -> topo.Node.ConstraintsEntry
-	0,  // 7: topo.Node.vendor:type_name -> topo.Vendor
-	17, // 8: topo.Node.interfaces:type_name -> topo.Node.InterfacesEntry
-	5,  // 9: topo.Node.host_constraints:type_name -> topo.HostConstraint
-	6,  // 10: topo.HostConstraint.kernel_constraint:type_name -> topo.KernelParam
-	7,  // 11: topo.KernelParam.bounded_integer:type_name -> topo.BoundedInteger
-	2,  // 12: topo.Interface.type:type_name -> topo.Interface.InterfaceType
-	18, // 13: topo.Config.env:type_name -> topo.Config.EnvEntry
-	11, // 14: topo.Config.cert:type_name -> topo.CertificateCfg
-	19, // 15: topo.Config.vendor_data:type_name -> google.protobuf.Any
-	12, // 16: topo.CertificateCfg.self_signed:type_name -> topo.SelfSignedCertCfg
-	13, // 17: topo.Node.ServicesEntry.value:type_name -> topo.Service
-	8,  // 18: topo.Node.InterfacesEntry.value:type_name -> topo.Interface
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	15, // 0: topo.Topology.nodes:type_name -> topo.Node
+	29, // 1: topo.Topology.links:type_name -> topo.Link
+	31, // 2: topo.Topology.mirrors:type_name -> topo.Mirror
+	13, // 3: topo.Topology.vendor_pod_patches:type_name -> topo.VendorPodPatches
+	12, // 4: topo.Topology.ca:type_name -> topo.CertificateAuthority
+	0,  // 5: topo.VendorPodPatches.vendor:type_name -> topo.Vendor
+	14, // 6: topo.VendorPodPatches.patches:type_name -> topo.PodPatch
+	2,  // 7: topo.PodPatch.type:type_name -> topo.PodPatch.Type
+	3,  // 8: topo.Node.type:type_name -> topo.Node.Type
+	42, // 9: topo.Node.labels:type_name -> topo.Node.LabelsEntry
+	32, // 10: topo.Node.config:type_name -> topo.Config
+	43, // 11: topo.Node.services:type_name -> topo.Node.ServicesEntry
+	44, // 12: topo.Node.constraints:type_name -> topo.Node.ConstraintsEntry
+	0,  // 13: topo.Node.vendor:type_name -> topo.Vendor
+	45, // 14: topo.Node.interfaces:type_name -> topo.Node.InterfacesEntry
+	25, // 15: topo.Node.host_constraints:type_name -> topo.HostConstraint
+	19, // 16: topo.Node.placement:type_name -> topo.Placement
+	17, // 17: topo.Node.sidecars:type_name -> topo.Sidecar
+	41, // 18: topo.Node.service_options:type_name -> topo.ServiceOptions
+	16, // 19: topo.Node.credentials:type_name -> topo.Credentials
+	46, // 20: topo.Sidecar.env:type_name -> topo.Sidecar.EnvEntry
+	47, // 21: topo.Sidecar.ports:type_name -> topo.Sidecar.PortsEntry
+	18, // 22: topo.Sidecar.volumes:type_name -> topo.SidecarVolume
+	48, // 23: topo.Placement.node_selector:type_name -> topo.Placement.NodeSelectorEntry
+	21, // 24: topo.Placement.node_affinity:type_name -> topo.NodeAffinityTerm
+	22, // 25: topo.Placement.pod_affinity:type_name -> topo.PodAffinityTerm
+	22, // 26: topo.Placement.pod_anti_affinity:type_name -> topo.PodAffinityTerm
+	23, // 27: topo.Placement.tolerations:type_name -> topo.Toleration
+	24, // 28: topo.Placement.topology_spread:type_name -> topo.TopologySpread
+	4,  // 29: topo.LabelRequirement.operator:type_name -> topo.LabelRequirement.Operator
+	20, // 30: topo.NodeAffinityTerm.match_expressions:type_name -> topo.LabelRequirement
+	49, // 31: topo.PodAffinityTerm.match_labels:type_name -> topo.PodAffinityTerm.MatchLabelsEntry
+	20, // 32: topo.PodAffinityTerm.match_expressions:type_name -> topo.LabelRequirement
+	5,  // 33: topo.Toleration.operator:type_name -> topo.Toleration.Operator
+	50, // 34: topo.TopologySpread.match_labels:type_name -> topo.TopologySpread.MatchLabelsEntry
+	26, // 35: topo.HostConstraint.kernel_constraint:type_name -> topo.KernelParam
+	27, // 36: topo.KernelParam.bounded_integer:type_name -> topo.BoundedInteger
+	6,  // 37: topo.Interface.type:type_name -> topo.Interface.InterfaceType
+	30, // 38: topo.Mirror.sources:type_name -> topo.Endpoint
+	7,  // 39: topo.Mirror.direction:type_name -> topo.Mirror.Direction
+	30, // 40: topo.Mirror.destination:type_name -> topo.Endpoint
+	51, // 41: topo.Config.env:type_name -> topo.Config.EnvEntry
+	37, // 42: topo.Config.cert:type_name -> topo.CertificateCfg
+	55, // 43: topo.Config.vendor_data:type_name -> google.protobuf.Any
+	36, // 44: topo.Config.secrets:type_name -> topo.SecretRef
+	35, // 45: topo.Config.file_mounts:type_name -> topo.FileMount
+	34, // 46: topo.Config.volumes:type_name -> topo.PersistentVolume
+	14, // 47: topo.Config.pod_patches:type_name -> topo.PodPatch
+	33, // 48: topo.Config.gnmi:type_name -> topo.GNMIOptions
+	8,  // 49: topo.GNMIOptions.encoding:type_name -> topo.GNMIOptions.Encoding
+	9,  // 50: topo.PersistentVolume.policy:type_name -> topo.PersistentVolume.Policy
+	52, // 51: topo.SecretRef.files:type_name -> topo.SecretRef.FilesEntry
+	53, // 52: topo.SecretRef.env:type_name -> topo.SecretRef.EnvEntry
+	39, // 53: topo.CertificateCfg.self_signed:type_name -> topo.SelfSignedCertCfg
+	38, // 54: topo.CertificateCfg.ca_issued:type_name -> topo.CAIssuedCertCfg
+	1,  // 55: topo.Service.protocol:type_name -> topo.Protocol
+	10, // 56: topo.ServiceOptions.type:type_name -> topo.ServiceOptions.Type
+	54, // 57: topo.ServiceOptions.annotations:type_name -> topo.ServiceOptions.AnnotationsEntry
+	40, // 58: topo.Node.ServicesEntry.value:type_name -> topo.Service
+	28, // 59: topo.Node.InterfacesEntry.value:type_name -> topo.Interface
+	60, // [60:60] is the sub-list for method output_type
+	60, // [60:60] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_topo_proto_init() }
@@ -1606,7 +4163,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Node); i {
+			switch v := v.(*CertificateAuthority); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1618,7 +4175,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*HostConstraint); i {
+			switch v := v.(*VendorPodPatches); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1630,7 +4187,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*KernelParam); i {
+			switch v := v.(*PodPatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1642,7 +4199,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*BoundedInteger); i {
+			switch v := v.(*Node); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1654,7 +4211,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*Interface); i {
+			switch v := v.(*Credentials); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1666,7 +4223,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*Link); i {
+			switch v := v.(*Sidecar); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1678,7 +4235,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*Config); i {
+			switch v := v.(*SidecarVolume); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1690,7 +4247,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*CertificateCfg); i {
+			switch v := v.(*Placement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1702,7 +4259,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*SelfSignedCertCfg); i {
+			switch v := v.(*LabelRequirement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1714,6 +4271,234 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*NodeAffinityTerm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*PodAffinityTerm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Toleration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*TopologySpread); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*HostConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*KernelParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*BoundedInteger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*Interface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Link); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*Endpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*Mirror); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GNMIOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*PersistentVolume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*FileMount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*SecretRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*CertificateCfg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*CAIssuedCertCfg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*SelfSignedCertCfg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[29].Exporter = func(v any, i int) any {
 			switch v := v.(*Service); i {
 			case 0:
 				return &v.state
@@ -1725,27 +4510,48 @@ func file_topo_proto_init() {
 				return nil
 			}
 		}
+		file_topo_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_topo_proto_msgTypes[2].OneofWrappers = []any{
+	file_topo_proto_msgTypes[3].OneofWrappers = []any{
+		(*PodPatch_Patch)(nil),
+		(*PodPatch_File)(nil),
+	}
+	file_topo_proto_msgTypes[5].OneofWrappers = []any{
+		(*Credentials_Secret)(nil),
+		(*Credentials_File)(nil),
+	}
+	file_topo_proto_msgTypes[14].OneofWrappers = []any{
 		(*HostConstraint_KernelConstraint)(nil),
 	}
-	file_topo_proto_msgTypes[3].OneofWrappers = []any{
+	file_topo_proto_msgTypes[15].OneofWrappers = []any{
 		(*KernelParam_BoundedInteger)(nil),
 	}
-	file_topo_proto_msgTypes[7].OneofWrappers = []any{
+	file_topo_proto_msgTypes[21].OneofWrappers = []any{
 		(*Config_Data)(nil),
 		(*Config_File)(nil),
 	}
-	file_topo_proto_msgTypes[8].OneofWrappers = []any{
+	file_topo_proto_msgTypes[26].OneofWrappers = []any{
 		(*CertificateCfg_SelfSigned)(nil),
+		(*CertificateCfg_CaIssued)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_topo_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   16,
+			NumEnums:      11,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,7 +22,8 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // +k8s:deepcopy-gen=true
 type TopologySpec struct {
 	metav1.TypeMeta `json:",inline"`
-	Links           []Link `json:"links"`
+	Links           []Link   `json:"links"`
+	Mirrors         []Mirror `json:"mirrors,omitempty"`
 }
 
 // TopologyStatus defines the observed state of Topology.
@@ -56,6 +57,24 @@ type Link struct {
 	UID       int    `json:"uid"`
 }
 
+// Mirror defines a traffic mirroring session terminating in the local pod.
+// Traffic on the source interfaces is copied to the destination interface,
+// which is the local end of a link dedicated to the mirror.
+// +k8s:deepcopy-gen=true
+type Mirror struct {
+	Name      string   `json:"name"`
+	SrcIntfs  []string `json:"src_intfs"`
+	Direction string   `json:"direction"`
+	DstIntf   string   `json:"dst_intf"`
+}
+
+// Mirror directions.
+const (
+	MirrorBoth    = "both"
+	MirrorIngress = "ingress"
+	MirrorEgress  = "egress"
+)
+
 // Topology is the Schema for the topologies API.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Topology struct {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
 	"github.com/openconfig/kne/third_party/meshnet/daemon/grpcwire"
 	mpb "github.com/openconfig/kne/third_party/meshnet/daemon/proto/meshnet/v1beta1"
 	"github.com/openconfig/kne/third_party/meshnet/daemon/vxlan"
@@ -89,6 +90,42 @@ func parsePodLinks(topo *unstructured.Unstructured) ([]wireutil.PodLinkConfig, e
 	return links, nil
 }
 
+// parsePodMirrors extracts all mirroring sessions from spec.mirrors in a Topology resource.
+func parsePodMirrors(topo *unstructured.Unstructured) []wireutil.PodMirrorConfig {
+	if topo == nil {
+		return nil
+	}
+	val, found, err := unstructured.NestedFieldNoCopy(topo.Object, "spec", "mirrors")
+	if err != nil || !found || val == nil {
+		return nil
+	}
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	mirrors := make([]wireutil.PodMirrorConfig, 0, len(items))
+	for _, item := range items {
+		mi, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(mi, "name")
+		srcIntfs, _, _ := unstructured.NestedStringSlice(mi, "src_intfs")
+		direction, _, _ := unstructured.NestedString(mi, "direction")
+		dstIntf, _, _ := unstructured.NestedString(mi, "dst_intf")
+
+		mirrors = append(mirrors, wireutil.PodMirrorConfig{
+			Name:     name,
+			SrcIntfs: srcIntfs,
+			Ingress:  direction != v1beta1.MirrorEgress,
+			Egress:   direction != v1beta1.MirrorIngress,
+			DstIntf:  dstIntf,
+		})
+	}
+	return mirrors
+}
+
 // ReconcilePodLinks reconciles network interface plumbing for an active pod scheduled on this node.
 // It wraps reconcilePodLinksInternal and records any configuration error in the Topology resource's status.
 func (m *Meshnet) ReconcilePodLinks(ctx context.Context, topo *unstructured.Unstructured) error {
@@ -301,6 +338,16 @@ func (m *Meshnet) reconcilePodLinksInternal(ctx context.Context, topo *unstructu
 			return err
 		}
 	}
+
+	// Mirrors are (re)applied on every pass since the source and destination interfaces
+	// are recreated whenever the local or peer pods restart, and even without mirrors
+	// so that the filters of removed sessions are deleted.
+	mirrors := parsePodMirrors(topo)
+	mnetdLogger.Debugf("ReconcilePodLinks: configuring %d mirrors for pod %s (%s)", len(mirrors), topo.GetName(), netNS)
+	if err := wireutil.ConfigurePodMirrors(netNS, mirrors); err != nil {
+		mnetdLogger.Errorf("ReconcilePodLinks: error configuring mirrors for pod %s: %v", topo.GetName(), err)
+		return err
+	}
 	return nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/kne/third_party/meshnet/utils/wireutil"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -146,3 +148,38 @@ func TestReconcilePodLinks_NoLinks(t *testing.T) {
 		t.Fatalf("ReconcilePodLinks failed for pod without links: %v", err)
 	}
 }
+
+func TestParsePodMirrors(t *testing.T) {
+	pod := createFakePodTopology("p1", "default", "10.0.0.1", "/proc/1/ns/net", []string{"p2"})
+	if got := parsePodMirrors(pod); len(got) != 0 {
+		t.Fatalf("expected no mirrors, got %+v", got)
+	}
+	pod.Object["spec"].(map[string]interface{})["mirrors"] = []interface{}{
+		map[string]interface{}{
+			"name":      "m1",
+			"src_intfs": []interface{}{"eth1", "eth2"},
+			"direction": "both",
+			"dst_intf":  "mirror0",
+		},
+		map[string]interface{}{
+			"name":      "m2",
+			"src_intfs": []interface{}{"eth3"},
+			"direction": "ingress",
+			"dst_intf":  "mirror1",
+		},
+		map[string]interface{}{
+			"name":      "m3",
+			"src_intfs": []interface{}{"eth4"},
+			"direction": "egress",
+			"dst_intf":  "mirror2",
+		},
+	}
+	want := []wireutil.PodMirrorConfig{
+		{Name: "m1", SrcIntfs: []string{"eth1", "eth2"}, Ingress: true, Egress: true, DstIntf: "mirror0"},
+		{Name: "m2", SrcIntfs: []string{"eth3"}, Ingress: true, DstIntf: "mirror1"},
+		{Name: "m3", SrcIntfs: []string{"eth4"}, Egress: true, DstIntf: "mirror2"},
+	}
+	if s := cmp.Diff(want, parsePodMirrors(pod)); s != "" {
+		t.Fatalf("parsePodMirrors() unexpected result (-want +got):\n%s", s)
+	}
+}
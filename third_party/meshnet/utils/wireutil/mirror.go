@@ -0,0 +1,229 @@
+package wireutil
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// mirrorPriorityBase is the lowest tc filter priority of mirror sessions in a pod.
+// Each session uses its own priority so filters of different sessions on the same
+// source interface do not replace each other.
+const mirrorPriorityBase = 100
+
+// mirrorPriorities is the number of tc filter priorities available to mirror sessions.
+const mirrorPriorities = 0xffff - mirrorPriorityBase + 1
+
+// mirrorFilterHandle is the fixed u32 handle (800::800) of mirror filters which allows
+// them to be replaced in place.
+const mirrorFilterHandle = 0x80000800
+
+// PodMirrorConfig describes a mirroring session terminating inside a pod network namespace.
+type PodMirrorConfig struct {
+	Name     string   // Session name (e.g. "ids")
+	SrcIntfs []string // Interfaces whose traffic is copied (e.g. ["eth1", "eth2"])
+	Ingress  bool     // Copy traffic received on the source interfaces
+	Egress   bool     // Copy traffic sent on the source interfaces
+	DstIntf  string   // Interface the traffic is copied to (e.g. "mirror0")
+}
+
+// ConfigurePodMirrors idempotently reconciles the tc mirred filters inside the pod
+// network namespace at podNsPath with mirrors. A clsact qdisc is added to every source
+// interface and a u32 filter matching all packets mirrors the traffic to the
+// destination interface. Mirror filters that are no longer wanted, such as those of
+// deleted sessions or of dropped directions, are removed.
+//
+// Mirrors whose source or destination interfaces do not exist yet are skipped, they
+// are expected to be configured on a later call once the links are plumbed. Filters
+// are replaced on every call so that recreated destination interfaces are picked up.
+func ConfigurePodMirrors(podNsPath string, mirrors []PodMirrorConfig) error {
+	podNs, err := ns.GetNS(podNsPath)
+	if err != nil {
+		return fmt.Errorf("could not open netns %s: %w", podNsPath, err)
+	}
+	defer podNs.Close()
+
+	prios := mirrorPriorityMap(mirrors)
+	return podNs.Do(func(_ ns.NetNS) error {
+		// wanted holds the priorities of the wanted filters per source link and hook.
+		wanted := map[mirrorHook]map[uint16]bool{}
+		for i, m := range mirrors {
+			dst, err := netlink.LinkByName(m.DstIntf)
+			if err != nil {
+				log.Debugf("ConfigurePodMirrors: destination %s of mirror %q not found in %s, skipping", m.DstIntf, m.Name, podNsPath)
+				continue
+			}
+			for _, name := range m.SrcIntfs {
+				src, err := netlink.LinkByName(name)
+				if err != nil {
+					log.Debugf("ConfigurePodMirrors: source %s of mirror %q not found in %s, skipping", name, m.Name, podNsPath)
+					continue
+				}
+				if err := mirrorLink(src, dst, prios[i], m.Ingress, m.Egress); err != nil {
+					return fmt.Errorf("failed to mirror %s to %s inside %s: %w", name, m.DstIntf, podNsPath, err)
+				}
+				for parent, enabled := range mirrorHooks(m.Ingress, m.Egress) {
+					if !enabled {
+						continue
+					}
+					h := mirrorHook{link: src.Attrs().Index, parent: parent}
+					if wanted[h] == nil {
+						wanted[h] = map[uint16]bool{}
+					}
+					wanted[h][prios[i]] = true
+				}
+			}
+		}
+		if err := removeStaleMirrors(wanted); err != nil {
+			return fmt.Errorf("failed to remove stale mirrors inside %s: %w", podNsPath, err)
+		}
+		return nil
+	})
+}
+
+// mirrorHook is a clsact hook of a link.
+type mirrorHook struct {
+	link   int
+	parent uint32
+}
+
+// mirrorHooks returns the clsact hooks of the enabled directions of a mirror.
+func mirrorHooks(ingress, egress bool) map[uint32]bool {
+	return map[uint32]bool{
+		netlink.HANDLE_MIN_INGRESS: ingress,
+		netlink.HANDLE_MIN_EGRESS:  egress,
+	}
+}
+
+// mirrorPriorityMap returns the filter priority of each mirror. Priorities are derived
+// from the session names so they do not change when sessions are added, removed or
+// reordered. Sessions whose names hash to the same priority are given the next free
+// one in name order.
+func mirrorPriorityMap(mirrors []PodMirrorConfig) []uint16 {
+	order := make([]int, len(mirrors))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return mirrorKey(mirrors[order[a]]) < mirrorKey(mirrors[order[b]])
+	})
+	prios := make([]uint16, len(mirrors))
+	used := map[uint32]bool{}
+	for _, i := range order {
+		h := fnv.New32a()
+		h.Write([]byte(mirrorKey(mirrors[i])))
+		p := h.Sum32() % mirrorPriorities
+		for used[p] {
+			p = (p + 1) % mirrorPriorities
+		}
+		used[p] = true
+		prios[i] = uint16(mirrorPriorityBase + p)
+	}
+	return prios
+}
+
+// mirrorKey returns the stable key of a mirror session.
+func mirrorKey(m PodMirrorConfig) string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.DstIntf
+}
+
+// removeStaleMirrors deletes the mirror filters in the current network namespace
+// whose priority is not wanted on their link and hook.
+func removeStaleMirrors(wanted map[mirrorHook]map[uint16]bool) error {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	for _, link := range links {
+		for parent := range mirrorHooks(true, true) {
+			filters, err := netlink.FilterList(link, parent)
+			if err != nil {
+				return fmt.Errorf("failed to list filters of %s: %w", link.Attrs().Name, err)
+			}
+			h := mirrorHook{link: link.Attrs().Index, parent: parent}
+			for _, f := range filters {
+				if !isMirrorFilter(f) || wanted[h][f.Attrs().Priority] {
+					continue
+				}
+				// Deleting handle 0 removes the whole u32 filter at the priority.
+				del := &netlink.U32{
+					FilterAttrs: netlink.FilterAttrs{
+						LinkIndex: link.Attrs().Index,
+						Parent:    parent,
+						Priority:  f.Attrs().Priority,
+						Protocol:  f.Attrs().Protocol,
+					},
+				}
+				if err := netlink.FilterDel(del); err != nil {
+					return fmt.Errorf("failed to delete mirred filter of %s with priority %d: %w", link.Attrs().Name, f.Attrs().Priority, err)
+				}
+				log.Debugf("removeStaleMirrors: deleted mirred filter of %s with priority %d", link.Attrs().Name, f.Attrs().Priority)
+			}
+		}
+	}
+	return nil
+}
+
+// isMirrorFilter returns whether f is a filter installed by mirrorLink.
+func isMirrorFilter(f netlink.Filter) bool {
+	u, ok := f.(*netlink.U32)
+	if !ok || u.Handle != mirrorFilterHandle || u.Priority < mirrorPriorityBase {
+		return false
+	}
+	for _, a := range u.Actions {
+		if m, ok := a.(*netlink.MirredAction); ok && m.MirredAction == netlink.TCA_EGRESS_MIRROR {
+			return true
+		}
+	}
+	return false
+}
+
+// mirrorLink mirrors the traffic of src to dst in the current network namespace.
+func mirrorLink(src, dst netlink.Link, prio uint16, ingress, egress bool) error {
+	qdisc := &netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: src.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+	}
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to add clsact qdisc: %w", err)
+	}
+	for parent, enabled := range mirrorHooks(ingress, egress) {
+		if !enabled {
+			continue
+		}
+		action := netlink.NewMirredAction(dst.Attrs().Index)
+		action.MirredAction = netlink.TCA_EGRESS_MIRROR
+		action.Action = netlink.TC_ACT_PIPE
+		filter := &netlink.U32{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: src.Attrs().Index,
+				Parent:    parent,
+				Handle:    mirrorFilterHandle,
+				Priority:  prio,
+				Protocol:  unix.ETH_P_ALL,
+			},
+			Sel: &netlink.TcU32Sel{
+				Flags: nl.TC_U32_TERMINAL,
+				Nkeys: 1,
+				Keys:  []netlink.TcU32Key{{Mask: 0, Val: 0}},
+			},
+			Actions: []netlink.Action{action},
+		}
+		if err := netlink.FilterReplace(filter); err != nil {
+			return fmt.Errorf("failed to add mirred filter: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package wireutil_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/openconfig/kne/third_party/meshnet/utils/wireutil"
+	"github.com/vishvananda/netlink"
+)
+
+func TestConfigurePodMirrors(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("Test requires root privileges. Run test with sudo")
+	}
+
+	podNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatalf("Failed to create netns: %v", err)
+	}
+	defer podNs.Close()
+
+	err = podNs.Do(func(_ ns.NetNS) error {
+		for _, name := range []string{"eth1", "eth2", "mirror0"} {
+			veth := &netlink.Veth{
+				LinkAttrs: netlink.LinkAttrs{Name: name},
+				PeerName:  name + "-peer",
+			}
+			if err := netlink.LinkAdd(veth); err != nil {
+				return fmt.Errorf("failed to create %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	mirrors := []wireutil.PodMirrorConfig{{
+		Name:     "both",
+		SrcIntfs: []string{"eth1"},
+		Ingress:  true,
+		Egress:   true,
+		DstIntf:  "mirror0",
+	}, {
+		Name:     "ingress",
+		SrcIntfs: []string{"eth2", "eth3"},
+		Ingress:  true,
+		DstIntf:  "mirror0",
+	}, {
+		Name:     "missing destination",
+		SrcIntfs: []string{"eth1"},
+		Ingress:  true,
+		DstIntf:  "mirror1",
+	}}
+
+	// Configure twice to check the filters are replaced rather than duplicated.
+	for i := 0; i < 2; i++ {
+		if err := wireutil.ConfigurePodMirrors(podNs.Path(), mirrors); err != nil {
+			t.Fatalf("ConfigurePodMirrors() failed: %v", err)
+		}
+	}
+
+	checkMirrors(t, podNs, map[string][2]int{
+		"eth1":    {1, 1},
+		"eth2":    {1, 0},
+		"mirror0": {0, 0},
+	})
+
+	// Reorder the sessions, drop the egress direction of one and delete another
+	// to check stale filters are removed.
+	mirrors = []wireutil.PodMirrorConfig{{
+		Name:     "missing destination",
+		SrcIntfs: []string{"eth1"},
+		Ingress:  true,
+		DstIntf:  "mirror1",
+	}, {
+		Name:     "both",
+		SrcIntfs: []string{"eth1"},
+		Ingress:  true,
+		DstIntf:  "mirror0",
+	}}
+	if err := wireutil.ConfigurePodMirrors(podNs.Path(), mirrors); err != nil {
+		t.Fatalf("ConfigurePodMirrors() failed: %v", err)
+	}
+	checkMirrors(t, podNs, map[string][2]int{
+		"eth1":    {1, 0},
+		"eth2":    {0, 0},
+		"mirror0": {0, 0},
+	})
+
+	if err := wireutil.ConfigurePodMirrors(podNs.Path(), nil); err != nil {
+		t.Fatalf("ConfigurePodMirrors() failed: %v", err)
+	}
+	checkMirrors(t, podNs, map[string][2]int{
+		"eth1": {0, 0},
+	})
+}
+
+// checkMirrors checks the number of ingress and egress mirror filters of the
+// interfaces in the pod.
+func checkMirrors(t *testing.T, podNs ns.NetNS, want map[string][2]int) {
+	t.Helper()
+	err := podNs.Do(func(_ ns.NetNS) error {
+		for intf, w := range want {
+			link, err := netlink.LinkByName(intf)
+			if err != nil {
+				return err
+			}
+			ingress, err := netlink.FilterList(link, netlink.HANDLE_MIN_INGRESS)
+			if err != nil {
+				return err
+			}
+			egress, err := netlink.FilterList(link, netlink.HANDLE_MIN_EGRESS)
+			if err != nil {
+				return err
+			}
+			gotIngress, gotEgress := mirredFilters(ingress), mirredFilters(egress)
+			if gotIngress != w[0] || gotEgress != w[1] {
+				return fmt.Errorf("%s: got %d ingress and %d egress mirror filters, want %d and %d", intf, gotIngress, gotEgress, w[0], w[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Verification failed: %v", err)
+	}
+}
+
+// mirredFilters returns the number of filters mirroring traffic.
+func mirredFilters(filters []netlink.Filter) int {
+	n := 0
+	for _, f := range filters {
+		u, ok := f.(*netlink.U32)
+		if !ok {
+			continue
+		}
+		for _, a := range u.Actions {
+			if m, ok := a.(*netlink.MirredAction); ok && m.MirredAction == netlink.TCA_EGRESS_MIRROR {
+				n++
+			}
+		}
+	}
+	return n
+}
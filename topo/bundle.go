@@ -0,0 +1,361 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/openconfig/kne/exec/run"
+	tpb "github.com/openconfig/kne/proto/topo"
+	topologyv1 "github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+)
+
+const redacted = "REDACTED"
+
+var (
+	// kubectlVersion returns the output of `kubectl version`. It can be
+	// set to a fake for unit testing.
+	kubectlVersion = func() ([]byte, error) {
+		return run.OutCommand("kubectl", "version", "--client", "--output=yaml")
+	}
+)
+
+// bundleWriter writes files into a gzipped tarball rooted at dir. Errors
+// hit while collecting resources are recorded and written to errors.txt
+// so that a partial bundle is still produced.
+type bundleWriter struct {
+	tw   *tar.Writer
+	dir  string
+	now  time.Time
+	errs []string
+}
+
+func (b *bundleWriter) write(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    path.Join(b.dir, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+	return err
+}
+
+func (b *bundleWriter) writeYAML(name string, v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	return b.write(name, data)
+}
+
+func (b *bundleWriter) addErr(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	log.Warning(msg)
+	b.errs = append(b.errs, msg)
+}
+
+// SupportBundle writes a gzipped tarball to w containing the state of the
+// topology for debugging: pods and their logs, namespace events, services,
+// config maps, secrets, custom resources (meshnet and vendor), the loaded
+// topology proto and version information. If redact is true the contents of
+// secrets, config maps, environment variables, node configs, passwords and
+// the specs of vendor resources are replaced.
+func (m *Manager) SupportBundle(ctx context.Context, w io.Writer, redact bool) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	b := &bundleWriter{
+		tw:  tw,
+		dir: m.topo.GetName(),
+		now: time.Now(),
+	}
+	collectors := []func(context.Context, *bundleWriter, bool) error{
+		m.bundleVersions,
+		m.bundleTopology,
+		m.bundlePods,
+		m.bundleNamespace,
+		m.bundleCustomResources,
+	}
+	for _, c := range collectors {
+		if err := c(ctx, b, redact); err != nil {
+			return err
+		}
+	}
+	if len(b.errs) > 0 {
+		if err := b.write("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+type bundleVersions struct {
+	KNE     string `json:"kne"`
+	Kubectl string `json:"kubectl,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+}
+
+func (m *Manager) bundleVersions(_ context.Context, b *bundleWriter, _ bool) error {
+	v := &bundleVersions{KNE: "unknown"}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		v.KNE = bi.Main.Version
+	}
+	if out, err := kubectlVersion(); err != nil {
+		b.addErr("failed to get kubectl version: %v", err)
+	} else {
+		v.Kubectl = strings.TrimSpace(string(out))
+	}
+	if sv, err := m.kClient.Discovery().ServerVersion(); err != nil {
+		b.addErr("failed to get cluster version: %v", err)
+	} else {
+		v.Cluster = sv.GitVersion
+	}
+	return b.writeYAML("versions.yaml", v)
+}
+
+func (m *Manager) bundleTopology(_ context.Context, b *bundleWriter, redact bool) error {
+	t := proto.Clone(m.topo).(*tpb.Topology)
+	if redact {
+		for _, n := range t.GetNodes() {
+			if n.GetConfig().GetConfigData() != nil {
+				n.Config.ConfigData = &tpb.Config_Data{Data: []byte(redacted)}
+			}
+			redactEnvMap(n.GetConfig().GetEnv())
+			for _, sc := range n.GetSidecars() {
+				redactEnvMap(sc.GetEnv())
+			}
+			if n.GetCredentials().GetPassword() != "" {
+				n.Credentials.Password = redacted
+			}
+		}
+	}
+	return b.write("topology.pb.txt", []byte(prototext.Format(t)))
+}
+
+func redactEnvMap(env map[string]string) {
+	for k := range env {
+		env[k] = redacted
+	}
+}
+
+func (m *Manager) bundlePods(ctx context.Context, b *bundleWriter, redact bool) error {
+	pods, err := m.kClient.CoreV1().Pods(m.topo.GetName()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addErr("failed to list pods: %v", err)
+		return nil
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		p.ManagedFields = nil
+		if redact {
+			redactEnv(p.Spec.InitContainers)
+			redactEnv(p.Spec.Containers)
+		}
+		if err := b.writeYAML(path.Join("pods", p.Name+".yaml"), p); err != nil {
+			return err
+		}
+		restarts := map[string]int32{}
+		for _, cs := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+			restarts[cs.Name] = cs.RestartCount
+		}
+		for _, c := range append(p.Spec.InitContainers, p.Spec.Containers...) {
+			if err := m.bundleLogs(ctx, b, p.Name, c.Name, false); err != nil {
+				return err
+			}
+			if restarts[c.Name] == 0 {
+				continue
+			}
+			if err := m.bundleLogs(ctx, b, p.Name, c.Name, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manager) bundleLogs(ctx context.Context, b *bundleWriter, pod, container string, previous bool) error {
+	name := container + ".log"
+	if previous {
+		name = container + ".previous.log"
+	}
+	data, err := m.kClient.CoreV1().Pods(m.topo.GetName()).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+	}).DoRaw(ctx)
+	if err != nil {
+		b.addErr("failed to get logs for %s/%s: %v", pod, name, err)
+		return nil
+	}
+	return b.write(path.Join("logs", pod, name), data)
+}
+
+func redactEnv(cs []corev1.Container) {
+	for i := range cs {
+		for j := range cs[i].Env {
+			if cs[i].Env[j].Value != "" {
+				cs[i].Env[j].Value = redacted
+			}
+		}
+	}
+}
+
+func (m *Manager) bundleNamespace(ctx context.Context, b *bundleWriter, redact bool) error {
+	ns := m.topo.GetName()
+	if events, err := m.kClient.CoreV1().Events(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		b.addErr("failed to list events: %v", err)
+	} else {
+		sort.Slice(events.Items, func(i, j int) bool {
+			return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+		})
+		for i := range events.Items {
+			events.Items[i].ManagedFields = nil
+		}
+		if err := b.writeYAML("events.yaml", events); err != nil {
+			return err
+		}
+	}
+	if services, err := m.kClient.CoreV1().Services(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		b.addErr("failed to list services: %v", err)
+	} else {
+		for i := range services.Items {
+			services.Items[i].ManagedFields = nil
+		}
+		if err := b.writeYAML("services.yaml", services); err != nil {
+			return err
+		}
+	}
+	if cms, err := m.kClient.CoreV1().ConfigMaps(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		b.addErr("failed to list config maps: %v", err)
+	} else {
+		for i := range cms.Items {
+			cms.Items[i].ManagedFields = nil
+			if !redact {
+				continue
+			}
+			for k := range cms.Items[i].Data {
+				cms.Items[i].Data[k] = redacted
+			}
+			for k := range cms.Items[i].BinaryData {
+				cms.Items[i].BinaryData[k] = []byte(redacted)
+			}
+		}
+		if err := b.writeYAML("configmaps.yaml", cms); err != nil {
+			return err
+		}
+	}
+	if secrets, err := m.kClient.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		b.addErr("failed to list secrets: %v", err)
+	} else {
+		for i := range secrets.Items {
+			secrets.Items[i].ManagedFields = nil
+			if !redact {
+				continue
+			}
+			for k := range secrets.Items[i].Data {
+				secrets.Items[i].Data[k] = []byte(redacted)
+			}
+			for k := range secrets.Items[i].StringData {
+				secrets.Items[i].StringData[k] = redacted
+			}
+		}
+		if err := b.writeYAML("secrets.yaml", secrets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBuiltinGroup returns true for API groups served by Kubernetes itself.
+func isBuiltinGroup(group string) bool {
+	return group == "" || !strings.Contains(group, ".") || strings.HasSuffix(group, ".k8s.io")
+}
+
+// bundleCustomResources writes all namespaced custom resources in the
+// topology namespace. This includes the meshnet Topology and GWireKObj
+// resources as well as any vendor resources created by the operators. If
+// redact is true the specs of the vendor resources are replaced.
+func (m *Manager) bundleCustomResources(ctx context.Context, b *bundleWriter, redact bool) error {
+	_, lists, err := m.kClient.Discovery().ServerGroupsAndResources()
+	if err != nil {
+		// Discovery returns partial results if some groups are unavailable.
+		b.addErr("failed to discover some API resources: %v", err)
+	}
+	seen := map[schema.GroupResource]bool{}
+	for _, l := range lists {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil || isBuiltinGroup(gv.Group) {
+			continue
+		}
+		for _, r := range l.APIResources {
+			gr := schema.GroupResource{Group: gv.Group, Resource: r.Name}
+			if !r.Namespaced || strings.Contains(r.Name, "/") || seen[gr] || !hasVerb(r.Verbs, "list") {
+				continue
+			}
+			seen[gr] = true
+			ul, err := m.dClient.Resource(gv.WithResource(r.Name)).Namespace(m.topo.GetName()).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				b.addErr("failed to list %s: %v", gr, err)
+				continue
+			}
+			if len(ul.Items) == 0 {
+				continue
+			}
+			for i := range ul.Items {
+				ul.Items[i].SetManagedFields(nil)
+				// The specs of vendor resources hold the environment and
+				// configs of the nodes, meshnet resources only the links.
+				if redact && gv.Group != topologyv1.GroupName {
+					if _, ok := ul.Items[i].Object["spec"]; ok {
+						ul.Items[i].Object["spec"] = redacted
+					}
+				}
+			}
+			if err := b.writeYAML(path.Join("resources", gr.String()+".yaml"), ul); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
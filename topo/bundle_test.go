@@ -0,0 +1,228 @@
+package topo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dfake "k8s.io/client-go/dynamic/fake"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func readBundle(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	gr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("failed to read gzip: %v", err)
+	}
+	tr := tar.NewReader(gr)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", hdr.Name, err)
+		}
+		files[hdr.Name] = string(data)
+	}
+	return files
+}
+
+func TestSupportBundle(t *testing.T) {
+	origKubectlVersion := kubectlVersion
+	defer func() {
+		kubectlVersion = origKubectlVersion
+	}()
+	kubectlVersion = func() ([]byte, error) {
+		return nil, fmt.Errorf("kubectl not found")
+	}
+	ns := "test"
+	kClient := kfake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: ns},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init-r1"}},
+				Containers: []corev1.Container{{
+					Name: "r1",
+					Env:  []corev1.EnvVar{{Name: "PASSWORD", Value: "admin"}},
+				}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "r1", RestartCount: 1}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1.1", Namespace: ns},
+			Reason:     "BackOff",
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "service-r1", Namespace: ns},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1-config", Namespace: ns},
+			Data:       map[string]string{"startup-config": "hostname r1"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1-creds", Namespace: ns},
+			Data:       map[string][]byte{"password": []byte("admin")},
+		},
+	)
+	kClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "pods", Namespaced: true, Verbs: []string{"list"}}},
+	}, {
+		GroupVersion: "ceoslab.arista.com/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "ceoslabdevices", Namespaced: true, Verbs: []string{"list"}}},
+	}, {
+		GroupVersion: "networkop.co.uk/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "topologies", Namespaced: true, Verbs: []string{"list"}},
+			{Name: "topologies/status", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "gwirekobjs", Namespaced: true, Verbs: []string{"list"}},
+		},
+	}}
+	topoGVR := schema.GroupVersionResource{Group: "networkop.co.uk", Version: "v1beta1", Resource: "topologies"}
+	gwireGVR := schema.GroupVersionResource{Group: "networkop.co.uk", Version: "v1beta1", Resource: "gwirekobjs"}
+	ceosGVR := schema.GroupVersionResource{Group: "ceoslab.arista.com", Version: "v1alpha1", Resource: "ceoslabdevices"}
+	dClient := dfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			topoGVR:  "TopologyList",
+			gwireGVR: "GWireKObjList",
+			ceosGVR:  "CEosLabDeviceList",
+		},
+		&unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "networkop.co.uk/v1beta1",
+			"kind":       "Topology",
+			"metadata":   map[string]any{"name": "r1", "namespace": ns},
+			"spec":       map[string]any{"links": []any{map[string]any{"uid": int64(1)}}},
+		}},
+		&unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "ceoslab.arista.com/v1alpha1",
+			"kind":       "CEosLabDevice",
+			"metadata":   map[string]any{"name": "r2", "namespace": ns},
+			"spec":       map[string]any{"envvars": map[string]any{"LICENSE": "ceos-license-key"}},
+		}},
+	)
+	m := &Manager{
+		topo: &tpb.Topology{
+			Name: ns,
+			Nodes: []*tpb.Node{{
+				Name: "r1",
+				Config: &tpb.Config{
+					ConfigData: &tpb.Config_Data{Data: []byte("hostname r1")},
+				},
+				Credentials: &tpb.Credentials{Username: "admin", Password: "r1-password"},
+			}, {
+				Name:   "r2",
+				Config: &tpb.Config{Env: map[string]string{"LICENSE": "ceos-license-key"}},
+			}},
+		},
+		kClient: kClient,
+		dClient: dClient,
+	}
+
+	tests := []struct {
+		desc         string
+		redact       bool
+		wantContains map[string]string
+		wantMissing  map[string]string
+	}{{
+		desc: "no redaction",
+		wantContains: map[string]string{
+			"configmaps.yaml": "hostname r1",
+			"pods/r1.yaml":    "value: admin",
+			"topology.pb.txt": "hostname r1",
+			"resources/ceoslabdevices.ceoslab.arista.com.yaml": "ceos-license-key",
+		},
+	}, {
+		desc:   "redaction",
+		redact: true,
+		wantContains: map[string]string{
+			"configmaps.yaml": redacted,
+			"pods/r1.yaml":    "value: " + redacted,
+			"topology.pb.txt": redacted,
+			"resources/topologies.networkop.co.uk.yaml": "uid: 1",
+		},
+		wantMissing: map[string]string{
+			"configmaps.yaml": "hostname r1",
+			"secrets.yaml":    "YWRtaW4=",
+			"topology.pb.txt": "hostname r1",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := m.SupportBundle(context.Background(), &buf, tt.redact); err != nil {
+				t.Fatalf("SupportBundle() failed: %v", err)
+			}
+			files := readBundle(t, buf.Bytes())
+			var got []string
+			for name := range files {
+				got = append(got, name)
+			}
+			want := []string{
+				"test/configmaps.yaml",
+				"test/errors.txt",
+				"test/events.yaml",
+				"test/logs/r1/init-r1.log",
+				"test/logs/r1/r1.log",
+				"test/logs/r1/r1.previous.log",
+				"test/pods/r1.yaml",
+				"test/resources/ceoslabdevices.ceoslab.arista.com.yaml",
+				"test/resources/topologies.networkop.co.uk.yaml",
+				"test/secrets.yaml",
+				"test/services.yaml",
+				"test/topology.pb.txt",
+				"test/versions.yaml",
+			}
+			if s := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); s != "" {
+				t.Errorf("SupportBundle() unexpected files (-want +got):\n%s", s)
+			}
+			if !strings.Contains(files["test/errors.txt"], "kubectl not found") {
+				t.Errorf("SupportBundle() errors.txt missing kubectl error, got:\n%s", files["test/errors.txt"])
+			}
+			for name, s := range tt.wantContains {
+				if !strings.Contains(files[path.Join("test", name)], s) {
+					t.Errorf("SupportBundle() %s does not contain %q, got:\n%s", name, s, files[path.Join("test", name)])
+				}
+			}
+			for name, s := range tt.wantMissing {
+				if strings.Contains(files[path.Join("test", name)], s) {
+					t.Errorf("SupportBundle() %s contains %q, got:\n%s", name, s, files[path.Join("test", name)])
+				}
+			}
+			if !tt.redact {
+				return
+			}
+			// No secret value may appear anywhere in a redacted bundle.
+			for _, secret := range []string{"r1-password", "ceos-license-key", "value: admin", "YWRtaW4="} {
+				for name, data := range files {
+					if strings.Contains(data, secret) {
+						t.Errorf("SupportBundle() %s contains secret %q, got:\n%s", name, secret, data)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+)
+
+// CASecretName is the Secret of the topology namespace storing the
+// certificate and key of the topology CA.
+const CASecretName = "kne-ca"
+
+// certSANsRetryInterval is the time to wait for the IPs of a node to be
+// assigned before issuing its cert.
+var certSANsRetryInterval = 2 * time.Second
+
+// CABundleFile returns the local file the certificate of the topology CA is
+// written to.
+func (m *Manager) CABundleFile() string {
+	p := m.topo.GetCa().GetBundleFile()
+	switch {
+	case p == "":
+		return filepath.Join(os.TempDir(), m.topo.GetName()+"-ca.pem")
+	case filepath.IsAbs(p):
+		return p
+	default:
+		return filepath.Join(m.basePath, p)
+	}
+}
+
+// newCA imports the CA of the topology from local files or creates it.
+func (m *Manager) newCA() (*node.CA, error) {
+	cfg := m.topo.GetCa()
+	if cfg.GetCertFile() == "" && cfg.GetKeyFile() == "" {
+		cn := cfg.GetCommonName()
+		if cn == "" {
+			cn = fmt.Sprintf("%s CA", m.topo.GetName())
+		}
+		log.Infof("Creating CA %q", cn)
+		return node.NewCA(cn, cfg.GetKeySize())
+	}
+	if cfg.GetCertFile() == "" || cfg.GetKeyFile() == "" {
+		return nil, fmt.Errorf("both the cert and key files of the CA must be set")
+	}
+	var pems [][]byte
+	for _, p := range []string{cfg.GetCertFile(), cfg.GetKeyFile()} {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(m.basePath, p)
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		pems = append(pems, b)
+	}
+	log.Infof("Importing CA from %q", cfg.GetCertFile())
+	return node.ParseCA(pems[0], pems[1])
+}
+
+// createOrUpdateSecret creates s in the topology namespace, replacing the data
+// of an existing Secret.
+func (m *Manager) createOrUpdateSecret(ctx context.Context, s *corev1.Secret) error {
+	secrets := m.kClient.CoreV1().Secrets(m.topo.GetName())
+	_, err := secrets.Create(ctx, s, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	cur, err := secrets.Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cur.Data = s.Data
+	_, err = secrets.Update(ctx, cur, metav1.UpdateOptions{})
+	return err
+}
+
+// topologyCA returns the CA of the topology. The CA is loaded from the kne-ca
+// Secret of the topology namespace, or imported or created and stored in the
+// Secret if it does not exist, so that all certs of a topology are issued by
+// the same CA. The CA certificate is published in the kne-ca-bundle Secret
+// and the local bundle file.
+func (m *Manager) topologyCA(ctx context.Context) (*node.CA, error) {
+	m.caMu.Lock()
+	defer m.caMu.Unlock()
+	if m.ca != nil {
+		return m.ca, nil
+	}
+	var ca *node.CA
+	s, err := m.kClient.CoreV1().Secrets(m.topo.GetName()).Get(ctx, CASecretName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if ca, err = node.ParseCA(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey]); err != nil {
+			return nil, fmt.Errorf("invalid CA secret %q: %w", CASecretName, err)
+		}
+	case apierrors.IsNotFound(err):
+		if ca, err = m.newCA(); err != nil {
+			return nil, fmt.Errorf("failed to create CA: %w", err)
+		}
+		labels := map[string]string{"topo": m.topo.GetName()}
+		if err := m.createOrUpdateSecret(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: CASecretName, Labels: labels},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       ca.CertPEM(),
+				corev1.TLSPrivateKeyKey: ca.KeyPEM(),
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create CA secret: %w", err)
+		}
+		if err := m.createOrUpdateSecret(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: node.CABundleSecretName, Labels: labels},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{node.CABundleKey: ca.CertPEM()},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create CA bundle secret: %w", err)
+		}
+	default:
+		return nil, err
+	}
+	p := m.CABundleFile()
+	if err := os.WriteFile(p, ca.CertPEM(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write CA bundle: %w", err)
+	}
+	log.Infof("Wrote CA bundle of topology %q to %s", m.topo.GetName(), p)
+	m.ca = ca
+	return ca, nil
+}
+
+// certSANs returns the DNS names and IPs of the cert of n: the node name, the
+// pod IP, the external IPs of the node and the additional SANs of the cert
+// config. It waits until the pod IP and the load balancer IPs are assigned.
+func (m *Manager) certSANs(ctx context.Context, n node.Node) ([]string, []net.IP, error) {
+	dnsNames := []string{n.Name()}
+	var extra []net.IP
+	for _, san := range n.GetProto().GetConfig().GetCert().GetCaIssued().GetSans() {
+		if ip := net.ParseIP(san); ip != nil {
+			extra = append(extra, ip)
+		} else {
+			dnsNames = append(dnsNames, san)
+		}
+	}
+	for {
+		ips, err := m.nodeIPs(ctx, n)
+		switch {
+		case err == nil:
+			return dnsNames, append(ips, extra...), nil
+		case ctx.Err() != nil:
+			return nil, nil, ctx.Err()
+		}
+		log.V(1).Infof("Waiting for the IPs of node %q: %v", n.Name(), err)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(certSANsRetryInterval):
+		}
+	}
+}
+
+// nodeIPs returns the pod IP and the external IPs of n, or an error if they
+// are not assigned yet.
+func (m *Manager) nodeIPs(ctx context.Context, n node.Node) ([]net.IP, error) {
+	var ips []net.IP
+	pods, err := n.Pods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pods {
+		if p.Status.PodIP == "" {
+			return nil, fmt.Errorf("pod %q has no IP", p.Name)
+		}
+		ips = append(ips, net.ParseIP(p.Status.PodIP))
+	}
+	if len(n.GetProto().GetServices()) == 0 {
+		return ips, nil
+	}
+	services, err := n.Services(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range services {
+		if s.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if len(s.Status.LoadBalancer.Ingress) == 0 {
+			return nil, fmt.Errorf("service %q has no external IP", s.Name)
+		}
+		for _, in := range s.Status.LoadBalancer.Ingress {
+			if ip := net.ParseIP(in.IP); ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return ips, nil
+}
+
+// IssueCert issues a cert signed by the topology CA to the provided node and
+// installs it on the node. If the node does not fulfill CertInstaller then
+// status.Unimplemented error will be returned.
+func (m *Manager) IssueCert(ctx context.Context, nodeName string) error {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	cfg := n.GetProto().GetConfig().GetCert().GetCaIssued()
+	if cfg == nil {
+		return fmt.Errorf("node %q does not request a CA issued cert", nodeName)
+	}
+	ci, ok := n.(node.CertInstaller)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "node %q does not implement CertInstaller interface", nodeName)
+	}
+	cert, err := m.issueCert(ctx, n, cfg.GetCommonName(), cfg.GetKeySize())
+	if err != nil {
+		return err
+	}
+	return ci.InstallCert(ctx, cert)
+}
+
+// issueCert returns a cert for n signed by the topology CA. The common name
+// defaults to the node name.
+func (m *Manager) issueCert(ctx context.Context, n node.Node, cn string, keySize uint32) (*node.Cert, error) {
+	ca, err := m.topologyCA(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dnsNames, ips, err := m.certSANs(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if cn == "" {
+		cn = n.Name()
+	}
+	cert, err := ca.Issue(cn, dnsNames, ips, keySize)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Issued cert %q for node %q with IPs %v", cn, n.Name(), ips)
+	return cert, nil
+}
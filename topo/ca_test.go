@@ -0,0 +1,187 @@
+package topo
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type certInstaller struct {
+	*node.Impl
+	got *node.Cert
+}
+
+func (c *certInstaller) InstallCert(_ context.Context, cert *node.Cert) error {
+	c.got = cert
+	return nil
+}
+
+func TestIssueCert(t *testing.T) {
+	origRetryInterval := certSANsRetryInterval
+	defer func() {
+		certSANsRetryInterval = origRetryInterval
+	}()
+	certSANsRetryInterval = time.Millisecond
+	caIssued := &tpb.Config{
+		Cert: &tpb.CertificateCfg{
+			Config: &tpb.CertificateCfg_CaIssued{
+				CaIssued: &tpb.CAIssuedCertCfg{KeySize: 1024, Sans: []string{"r1.lab", "10.0.0.1"}},
+			},
+		},
+	}
+	tests := []struct {
+		desc      string
+		node      *tpb.Node
+		installer bool
+		podIP     string
+		wantNames []string
+		wantIPs   []string
+		wantErr   string
+		wantCode  codes.Code
+	}{{
+		desc: "issued",
+		node: &tpb.Node{
+			Name:     "r1",
+			Config:   caIssued,
+			Services: map[uint32]*tpb.Service{22: {Name: "ssh", Inside: 22}},
+		},
+		installer: true,
+		podIP:     "10.244.0.5",
+		wantNames: []string{"r1", "r1.lab"},
+		wantIPs:   []string{"10.244.0.5", "192.168.18.100", "10.0.0.1"},
+	}, {
+		desc:      "no cert config",
+		node:      &tpb.Node{Name: "r1"},
+		installer: true,
+		wantErr:   "does not request a CA issued cert",
+	}, {
+		desc:     "not a cert installer",
+		node:     &tpb.Node{Name: "r1", Config: caIssued},
+		podIP:    "10.244.0.5",
+		wantCode: codes.Unimplemented,
+	}, {
+		desc:      "pod IP not assigned",
+		node:      &tpb.Node{Name: "r1", Config: caIssued},
+		installer: true,
+		wantErr:   "context deadline exceeded",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			kClient := kfake.NewSimpleClientset(
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "test"},
+					Status:     corev1.PodStatus{PodIP: tt.podIP},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "service-r1", Namespace: "test"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+					Status: corev1.ServiceStatus{
+						LoadBalancer: corev1.LoadBalancerStatus{
+							Ingress: []corev1.LoadBalancerIngress{{IP: "192.168.18.100"}},
+						},
+					},
+				},
+			)
+			bundle := filepath.Join(t.TempDir(), "ca.pem")
+			impl := &node.Impl{Namespace: "test", KubeClient: kClient, Proto: tt.node}
+			var n node.Node = impl
+			ci := &certInstaller{Impl: impl}
+			if tt.installer {
+				n = ci
+			}
+			m := &Manager{
+				topo:    &tpb.Topology{Name: "test", Ca: &tpb.CertificateAuthority{KeySize: 1024, BundleFile: bundle}},
+				nodes:   map[string]node.Node{"r1": n},
+				kClient: kClient,
+			}
+			err := m.IssueCert(ctx, "r1")
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("IssueCert() unexpected error: got %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("IssueCert() unexpected error: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			b, _ := pem.Decode(ci.got.CertPEM)
+			cert, err := x509.ParseCertificate(b.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse issued cert: %v", err)
+			}
+			if s := cmp.Diff(tt.wantNames, cert.DNSNames); s != "" {
+				t.Errorf("IssueCert() unexpected DNS names (-want +got):\n%s", s)
+			}
+			var ips []string
+			for _, ip := range cert.IPAddresses {
+				ips = append(ips, ip.String())
+			}
+			if s := cmp.Diff(tt.wantIPs, ips); s != "" {
+				t.Errorf("IssueCert() unexpected IPs (-want +got):\n%s", s)
+			}
+			s, err := kClient.CoreV1().Secrets("test").Get(ctx, node.CABundleSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get CA bundle secret: %v", err)
+			}
+			if s := cmp.Diff(string(ci.got.CAPEM), string(s.Data[node.CABundleKey])); s != "" {
+				t.Errorf("IssueCert() unexpected CA bundle secret (-want +got):\n%s", s)
+			}
+			// A new manager of the same topology issues certs with the same CA.
+			m2 := &Manager{topo: m.topo, nodes: m.nodes, kClient: kClient}
+			ca, err := m2.topologyCA(ctx)
+			if err != nil {
+				t.Fatalf("topologyCA() failed: %v", err)
+			}
+			if s := cmp.Diff(string(ci.got.CAPEM), string(ca.CertPEM())); s != "" {
+				t.Errorf("topologyCA() unexpected CA (-want +got):\n%s", s)
+			}
+			if got := m.CABundleFile(); got != bundle {
+				t.Errorf("CABundleFile() = %q, want %q", got, bundle)
+			}
+		})
+	}
+}
+
+func TestNewCAIssuedNotSupported(t *testing.T) {
+	node.Vendor(tpb.Vendor(1012), NewConfigurable)
+	topo := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1012),
+			Config: &tpb.Config{
+				Cert: &tpb.CertificateCfg{
+					Config: &tpb.CertificateCfg_CaIssued{CaIssued: &tpb.CAIssuedCertCfg{}},
+				},
+			},
+		}},
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	_, err = New(topo, WithClusterConfig(&rest.Config{}), WithKubeClient(kfake.NewSimpleClientset()), WithTopoClient(tf))
+	if s := errdiff.Substring(err, `node "r1": ca_issued certs are not supported`); s != "" {
+		t.Errorf("New() unexpected error: %s", s)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/gopacket/pcapgo"
+	topologyv1 "github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
+	"github.com/openconfig/kne/third_party/meshnet/utils/wireutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	meshnetNamespace = "meshnet"
+	meshnetContainer = "meshnet"
+	meshnetLabel     = "name=meshnet"
+
+	// captureScript runs in the meshnet daemon container which shares the
+	// host network and PID namespaces. It captures on the host interface if
+	// present, otherwise it enters the pod network namespace so that the
+	// node container itself is never modified. The arguments are the host
+	// interface, the pod network namespace path, the pod interface and the
+	// capture filter.
+	captureScript = `h=$1; n=$2; i=$3; shift 3
+if [ -e "/sys/class/net/$h" ]; then exec tcpdump -i "$h" -U -w - "$@"; fi
+exec nsenter --net="$n" tcpdump -i "$i" -U -w - "$@"`
+)
+
+// execInPod runs cmd in container of the provided pod without a TTY,
+// streaming its output to stdout and stderr. It can be set to a fake for
+// unit testing.
+var execInPod = func(ctx context.Context, rCfg *rest.Config, kClient kubernetes.Interface, namespace, pod, container string, cmd []string, stdout, stderr io.Writer) error {
+	req := kClient.CoreV1().RESTClient().Post().Resource("pods").Name(pod).Namespace(namespace).SubResource("exec")
+	req.VersionedParams(&corev1.PodExecOptions{
+		Command:   cmd,
+		Container: container,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(rCfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// captureTarget describes where the packets of a node interface can be
+// captured from inside the meshnet daemon.
+type captureTarget struct {
+	meshnetPod string
+	hostIntf   string
+	netNS      string
+	podIntf    string
+}
+
+// captureTarget finds the meshnet daemon on the cluster node running the
+// pod and the interface the link can be captured on. For links carried over
+// grpcwire this is the wire interface on the cluster node. For veth links
+// this is the deterministic host side veth if it is still on the host, or
+// the pod interface in the pod network namespace.
+func (m *Manager) captureTarget(ctx context.Context, nodeName, intf string) (*captureTarget, error) {
+	ns := m.topo.GetName()
+	t, err := m.tClient.Topology(ns).Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meshnet topology for node %q: %w", nodeName, err)
+	}
+	var link *topologyv1.Link
+	for i := range t.Spec.Links {
+		if t.Spec.Links[i].LocalIntf == intf {
+			link = &t.Spec.Links[i]
+			break
+		}
+	}
+	if link == nil {
+		return nil, fmt.Errorf("interface %q of node %q is not connected to a link", intf, nodeName)
+	}
+	p, err := m.kClient.CoreV1().Pods(ns).Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod for node %q: %w", nodeName, err)
+	}
+	if p.Spec.NodeName == "" {
+		return nil, fmt.Errorf("pod for node %q is not scheduled", nodeName)
+	}
+	pods, err := m.kClient.CoreV1().Pods(meshnetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: meshnetLabel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meshnet pods: %w", err)
+	}
+	ct := &captureTarget{
+		netNS:   t.Status.NetNS,
+		podIntf: intf,
+	}
+	for _, mp := range pods.Items {
+		if mp.Spec.NodeName == p.Spec.NodeName {
+			ct.meshnetPod = mp.Name
+			break
+		}
+	}
+	if ct.meshnetPod == "" {
+		return nil, fmt.Errorf("no meshnet daemon found on cluster node %q", p.Spec.NodeName)
+	}
+	wireIntf, err := m.grpcWireIntf(ctx, p.Spec.NodeName, nodeName, int64(link.UID))
+	if err != nil {
+		return nil, err
+	}
+	if wireIntf != "" {
+		ct.hostIntf = wireIntf
+		return ct, nil
+	}
+	ct.hostIntf, _ = wireutil.HostVethNames(ns, nodeName, link.PeerPod, int64(link.UID))
+	return ct, nil
+}
+
+// grpcWireIntf returns the name of the interface on the cluster node
+// carrying the link with the provided UID over grpcwire, or an empty string
+// if the link is not a grpcwire link.
+func (m *Manager) grpcWireIntf(ctx context.Context, clusterNode, nodeName string, uid int64) (string, error) {
+	gvr := topologyv1.SchemeGroupVersion.WithResource(topologyv1.GWireResNamePlural)
+	u, err := m.dClient.Resource(gvr).Namespace(m.topo.GetName()).Get(ctx, clusterNode, metav1.GetOptions{})
+	if err != nil {
+		log.V(1).Infof("No grpcwire state found on %q: %v", clusterNode, err)
+		return "", nil
+	}
+	gw := &topologyv1.GWireKObj{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, gw); err != nil {
+		return "", fmt.Errorf("failed to parse grpcwire state on %q: %w", clusterNode, err)
+	}
+	for _, w := range gw.Status.GWireKItems {
+		if w.LocalPodName == nodeName && w.LinkId == uid {
+			return w.WireIfaceNameOnLocalNode, nil
+		}
+	}
+	return "", nil
+}
+
+// Capture captures packets on the link connected to intf of the provided
+// node and writes them to w in pcapng format until ctx is canceled or the
+// capture ends. The capture runs in the meshnet daemon so the node
+// container is never modified. filter is an optional BPF filter.
+func (m *Manager) Capture(ctx context.Context, nodeName, intf, filter string, w io.Writer) error {
+	if _, ok := m.nodes[nodeName]; !ok {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	ct, err := m.captureTarget(ctx, nodeName, intf)
+	if err != nil {
+		return err
+	}
+	cmd := []string{"sh", "-c", captureScript, "capture", ct.hostIntf, ct.netNS, ct.podIntf}
+	if filter != "" {
+		cmd = append(cmd, filter)
+	}
+	log.Infof("Capturing %s:%s using meshnet daemon %q", nodeName, intf, ct.meshnetPod)
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := execInPod(ctx, m.rCfg, m.kClient, meshnetNamespace, ct.meshnetPod, meshnetContainer, cmd, pw, &logWriter{})
+		pw.CloseWithError(err)
+		errCh <- err
+	}()
+	cErr := writePcapng(pr, w)
+	// Unblock the exec if writing failed.
+	pr.CloseWithError(cErr)
+	eErr := <-errCh
+	switch {
+	case ctx.Err() != nil:
+		return nil
+	case cErr != nil:
+		return cErr
+	}
+	return eErr
+}
+
+// writePcapng converts the pcap stream read from r into pcapng written to
+// w. Each packet is flushed as it is read so the output can be consumed
+// live.
+func writePcapng(r io.Reader, w io.Writer) error {
+	pr, err := pcapgo.NewReader(r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("capture ended before any data was received")
+		}
+		return fmt.Errorf("failed to read capture: %w", err)
+	}
+	nw, err := pcapgo.NewNgWriter(w, pr.LinkType())
+	if err != nil {
+		return err
+	}
+	for {
+		data, ci, err := pr.ReadPacketData()
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nw.Flush()
+			}
+			if fErr := nw.Flush(); fErr != nil {
+				return fErr
+			}
+			return err
+		}
+		ci.InterfaceIndex = 0
+		if err := nw.WritePacket(ci, data); err != nil {
+			return err
+		}
+		if err := nw.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// logWriter writes each line written to it to the log.
+type logWriter struct{}
+
+func (*logWriter) Write(p []byte) (int, error) {
+	log.Info(string(p))
+	return len(p), nil
+}
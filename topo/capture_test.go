@@ -0,0 +1,186 @@
+package topo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	topologyv1 "github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
+	"github.com/openconfig/kne/third_party/meshnet/utils/wireutil"
+	"github.com/openconfig/kne/topo/node"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestCapture(t *testing.T) {
+	ns := "test"
+	packet := []byte{0xde, 0xad, 0xbe, 0xef}
+	origExecInPod := execInPod
+	defer func() {
+		execInPod = origExecInPod
+	}()
+	var gotPod string
+	var gotCmd []string
+	execInPod = func(_ context.Context, _ *rest.Config, _ kubernetes.Interface, namespace, pod, container string, cmd []string, stdout, _ io.Writer) error {
+		gotPod = namespace + "/" + pod + "/" + container
+		gotCmd = cmd
+		w := pcapgo.NewWriter(stdout)
+		if err := w.WriteFileHeader(65535, layers.LinkTypeEthernet); err != nil {
+			return err
+		}
+		return w.WritePacket(gopacket.CaptureInfo{
+			Timestamp:     time.Unix(1, 0),
+			CaptureLength: len(packet),
+			Length:        len(packet),
+		}, packet)
+	}
+	hostVeth, _ := wireutil.HostVethNames(ns, "r1", "r2", 1)
+
+	tests := []struct {
+		desc    string
+		node    string
+		intf    string
+		filter  string
+		gwire   bool
+		wantCmd []string
+		wantErr string
+	}{{
+		desc:    "veth",
+		node:    "r1",
+		intf:    "eth1",
+		wantCmd: []string{"sh", "-c", captureScript, "capture", hostVeth, "/proc/1/ns/net", "eth1"},
+	}, {
+		desc:    "veth with filter",
+		node:    "r1",
+		intf:    "eth1",
+		filter:  "tcp port 179",
+		wantCmd: []string{"sh", "-c", captureScript, "capture", hostVeth, "/proc/1/ns/net", "eth1", "tcp port 179"},
+	}, {
+		desc:    "grpcwire",
+		node:    "r1",
+		intf:    "eth1",
+		gwire:   true,
+		wantCmd: []string{"sh", "-c", captureScript, "capture", "gw-1", "/proc/1/ns/net", "eth1"},
+	}, {
+		desc:    "node not found",
+		node:    "r3",
+		intf:    "eth1",
+		wantErr: `node "r3" not found`,
+	}, {
+		desc:    "interface not connected",
+		node:    "r1",
+		intf:    "eth2",
+		wantErr: "not connected to a link",
+	}, {
+		desc:    "no meshnet daemon",
+		node:    "r2",
+		intf:    "eth1",
+		wantErr: "no meshnet daemon",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotPod, gotCmd = "", nil
+			tf, err := tfake.NewSimpleClientset(&topologyv1.Topology{
+				ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: ns},
+				Spec: topologyv1.TopologySpec{
+					Links: []topologyv1.Link{{LocalIntf: "eth1", PeerIntf: "eth1", PeerPod: "r2", UID: 1}},
+				},
+				Status: topologyv1.TopologyStatus{NetNS: "/proc/1/ns/net"},
+			}, &topologyv1.Topology{
+				ObjectMeta: metav1.ObjectMeta{Name: "r2", Namespace: ns},
+				Spec: topologyv1.TopologySpec{
+					Links: []topologyv1.Link{{LocalIntf: "eth1", PeerIntf: "eth1", PeerPod: "r1", UID: 1}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("cannot create fake topology clientset: %v", err)
+			}
+			kClient := kfake.NewSimpleClientset(
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: ns},
+					Spec:       corev1.PodSpec{NodeName: "worker1"},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "r2", Namespace: ns},
+					Spec:       corev1.PodSpec{NodeName: "worker2"},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "meshnet-abcde", Namespace: meshnetNamespace, Labels: map[string]string{"name": "meshnet"}},
+					Spec:       corev1.PodSpec{NodeName: "worker1"},
+				},
+			)
+			var objs []runtime.Object
+			if tt.gwire {
+				objs = append(objs, &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "networkop.co.uk/v1beta1",
+					"kind":       "GWireKObj",
+					"metadata":   map[string]any{"name": "worker1", "namespace": ns},
+					"status": map[string]any{
+						"grpcWireItems": []any{
+							map[string]any{"local_pod_name": "r1", "link_id": int64(1), "wire_iface_name_on_local_node": "gw-1"},
+						},
+					},
+				}})
+			}
+			dClient := dfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					topologyv1.SchemeGroupVersion.WithResource(topologyv1.GWireResNamePlural): "GWireKObjList",
+				}, objs...)
+			m := &Manager{
+				topo:    &tpb.Topology{Name: ns},
+				nodes:   map[string]node.Node{"r1": nil, "r2": nil},
+				kClient: kClient,
+				tClient: tf,
+				dClient: dClient,
+			}
+			var buf bytes.Buffer
+			err = m.Capture(context.Background(), tt.node, tt.intf, tt.filter, &buf)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("Capture() unexpected error: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if want := meshnetNamespace + "/meshnet-abcde/" + meshnetContainer; gotPod != want {
+				t.Errorf("Capture() ran in %q, want %q", gotPod, want)
+			}
+			if s := cmp.Diff(tt.wantCmd, gotCmd); s != "" {
+				t.Errorf("Capture() unexpected command (-want +got):\n%s", s)
+			}
+			r, err := pcapgo.NewNgReader(&buf, pcapgo.DefaultNgReaderOptions)
+			if err != nil {
+				t.Fatalf("Capture() did not write pcapng: %v", err)
+			}
+			data, _, err := r.ReadPacketData()
+			if err != nil {
+				t.Fatalf("failed to read packet: %v", err)
+			}
+			if s := cmp.Diff(packet, data); s != "" {
+				t.Errorf("Capture() unexpected packet (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestWritePcapngEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writePcapng(bytes.NewReader(nil), &buf); err == nil {
+		t.Errorf("writePcapng() of empty stream succeeded, want error")
+	}
+}
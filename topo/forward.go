@@ -0,0 +1,240 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	log "k8s.io/klog/v2"
+)
+
+// PortForward is a local port forwarded to a service of a node.
+type PortForward struct {
+	Node      string
+	Service   string
+	LocalPort uint32
+	// Port is the port of the service, which is the key of the service in
+	// the node.
+	Port uint32
+	// PodPort is the port the node listens on in its pod.
+	PodPort uint32
+}
+
+// Endpoint returns the local address of the forwarded port.
+func (f *PortForward) Endpoint() string {
+	return fmt.Sprintf("localhost:%d", f.LocalPort)
+}
+
+// forwardRetryInterval is the time to wait before reestablishing the port
+// forwards of a node.
+var forwardRetryInterval = 2 * time.Second
+
+// forwardPorts forwards ports, in the local:remote format, to pod until ctx
+// is canceled or the connection to the pod is lost. ready is closed when the
+// local ports are listening. It can be set to a fake for unit testing.
+var forwardPorts = func(ctx context.Context, rCfg *rest.Config, kClient kubernetes.Interface, namespace, pod string, ports []string, ready chan struct{}) error {
+	transport, upgrader, err := spdy.RoundTripperFor(rCfg)
+	if err != nil {
+		return err
+	}
+	req := kClient.CoreV1().RESTClient().Post().Resource("pods").Name(pod).Namespace(namespace).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+	stop := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+	fw, err := portforward.New(dialer, ports, stop, ready, &logWriter{}, &logWriter{})
+	if err != nil {
+		return err
+	}
+	return fw.ForwardPorts()
+}
+
+// PortForwards returns the port forwards to the TCP services of the provided
+// nodes, or of all nodes if none are provided. Local ports are assigned
+// sequentially from basePort to the services of all nodes of the topology,
+// sorted by node name and service port, so that the local port of a service
+// does not depend on the nodes selected.
+func (m *Manager) PortForwards(nodeNames []string, basePort uint32) ([]*PortForward, error) {
+	selected := map[string]bool{}
+	for _, name := range nodeNames {
+		if _, ok := m.nodes[name]; !ok {
+			return nil, fmt.Errorf("node %q not found", name)
+		}
+		selected[name] = true
+	}
+	var names []string
+	for name := range m.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var forwards []*PortForward
+	local := basePort
+	for _, name := range names {
+		services := m.nodes[name].GetProto().GetServices()
+		var ports []uint32
+		for k := range services {
+			ports = append(ports, k)
+		}
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+		for _, k := range ports {
+			svc := services[k]
+			if svc.GetProtocol() != tpb.Protocol_TCP {
+				continue
+			}
+			if local > math.MaxUint16 {
+				return nil, fmt.Errorf("local port %d out of range (max: %d)", local, math.MaxUint16)
+			}
+			if len(selected) == 0 || selected[name] {
+				podPort := svc.GetInside()
+				if podPort == 0 {
+					podPort = k
+				}
+				forwards = append(forwards, &PortForward{
+					Node:      name,
+					Service:   svc.GetName(),
+					LocalPort: local,
+					Port:      k,
+					PodPort:   podPort,
+				})
+			}
+			local++
+		}
+	}
+	return forwards, nil
+}
+
+// podForNode returns the name of the running pod of the provided node.
+func (m *Manager) podForNode(ctx context.Context, nodeName string) (string, error) {
+	pods, err := m.nodes[nodeName].Pods(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pods {
+		if p.Status.Phase == corev1.PodRunning && p.DeletionTimestamp == nil {
+			return p.Name, nil
+		}
+	}
+	return "", fmt.Errorf("node %q has no running pod", nodeName)
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// forwardNode forwards ports to the pod of the provided node until ctx is
+// canceled, reestablishing the forwards when the pod restarts or the
+// connection is lost. ready is called the first time the ports are
+// listening. An error is only returned if the ports of the node could not be
+// forwarded the first time the pod was found.
+func (m *Manager) forwardNode(ctx context.Context, nodeName string, ports []string, ready func()) error {
+	var once sync.Once
+	forwarded := false
+	for {
+		pod, err := m.podForNode(ctx, nodeName)
+		if err == nil {
+			readyCh := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				select {
+				case <-readyCh:
+					once.Do(ready)
+				case <-done:
+				}
+			}()
+			err = forwardPorts(ctx, m.rCfg, m.kClient, m.topo.GetName(), pod, ports, readyCh)
+			close(done)
+			switch {
+			case isClosed(readyCh):
+				once.Do(ready)
+				forwarded = true
+			case !forwarded && ctx.Err() == nil:
+				return fmt.Errorf("failed to forward ports to node %q: %w", nodeName, err)
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Warningf("Port forwards to node %q lost, retrying: %v", nodeName, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(forwardRetryInterval):
+		}
+	}
+}
+
+// Forward forwards the local ports of forwards to the pods of their nodes
+// until ctx is canceled. The forwards of a node are reestablished when its pod
+// restarts. ready, if not nil, is called once the ports of all nodes are
+// listening.
+func (m *Manager) Forward(ctx context.Context, forwards []*PortForward, ready func()) error {
+	if len(forwards) == 0 {
+		return fmt.Errorf("no ports to forward")
+	}
+	ports := map[string][]string{}
+	for _, f := range forwards {
+		if _, ok := m.nodes[f.Node]; !ok {
+			return fmt.Errorf("node %q not found", f.Node)
+		}
+		ports[f.Node] = append(ports[f.Node], fmt.Sprintf("%d:%d", f.LocalPort, f.PodPort))
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	readyCh := make(chan struct{}, len(ports))
+	go func() {
+		for range ports {
+			select {
+			case <-readyCh:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ready != nil {
+			ready()
+		}
+	}()
+	errCh := make(chan error, len(ports))
+	for name, p := range ports {
+		go func(name string, p []string) {
+			errCh <- m.forwardNode(ctx, name, p, func() { readyCh <- struct{}{} })
+		}(name, p)
+	}
+	var err error
+	for range ports {
+		if e := <-errCh; e != nil && err == nil {
+			err = e
+			cancel()
+		}
+	}
+	return err
+}
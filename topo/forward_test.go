@@ -0,0 +1,234 @@
+package topo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func forwardManager(phase corev1.PodPhase) *Manager {
+	kClient := kfake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "test"},
+			Status:     corev1.PodStatus{Phase: phase},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "r2", Namespace: "test"},
+			Status:     corev1.PodStatus{Phase: phase},
+		},
+	)
+	protos := map[string]*tpb.Node{
+		"r1": {
+			Name: "r1",
+			Services: map[uint32]*tpb.Service{
+				22:  {Name: "ssh", Inside: 22},
+				514: {Name: "syslog", Inside: 514, Protocol: tpb.Protocol_UDP},
+				443: {Name: "ssl", Inside: 8443},
+			},
+		},
+		"r2": {
+			Name: "r2",
+			Services: map[uint32]*tpb.Service{
+				9339: {Name: "gnmi", Inside: 9339},
+			},
+		},
+		"r3": {Name: "r3"},
+	}
+	m := &Manager{
+		topo:    &tpb.Topology{Name: "test"},
+		nodes:   map[string]node.Node{},
+		kClient: kClient,
+	}
+	for name, pb := range protos {
+		m.nodes[name] = &node.Impl{Namespace: "test", KubeClient: kClient, Proto: pb}
+	}
+	return m
+}
+
+func TestPortForwards(t *testing.T) {
+	tests := []struct {
+		desc    string
+		nodes   []string
+		want    []*PortForward
+		wantErr string
+	}{{
+		desc: "all nodes",
+		want: []*PortForward{
+			{Node: "r1", Service: "ssh", LocalPort: 20000, Port: 22, PodPort: 22},
+			{Node: "r1", Service: "ssl", LocalPort: 20001, Port: 443, PodPort: 8443},
+			{Node: "r2", Service: "gnmi", LocalPort: 20002, Port: 9339, PodPort: 9339},
+		},
+	}, {
+		desc:  "selected node keeps local port",
+		nodes: []string{"r2"},
+		want: []*PortForward{
+			{Node: "r2", Service: "gnmi", LocalPort: 20002, Port: 9339, PodPort: 9339},
+		},
+	}, {
+		desc:    "node not found",
+		nodes:   []string{"r4"},
+		wantErr: `node "r4" not found`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := forwardManager(corev1.PodRunning)
+			got, err := m.PortForwards(tt.nodes, 20000)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("PortForwards() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("PortForwards() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestForward(t *testing.T) {
+	origForwardPorts := forwardPorts
+	origForwardRetryInterval := forwardRetryInterval
+	defer func() {
+		forwardPorts = origForwardPorts
+		forwardRetryInterval = origForwardRetryInterval
+	}()
+	forwardRetryInterval = time.Millisecond
+	forwards := []*PortForward{
+		{Node: "r1", Service: "ssh", LocalPort: 20000, Port: 22, PodPort: 22},
+		{Node: "r1", Service: "ssl", LocalPort: 20001, Port: 443, PodPort: 8443},
+		{Node: "r2", Service: "gnmi", LocalPort: 20002, Port: 9339, PodPort: 9339},
+	}
+
+	tests := []struct {
+		desc      string
+		forwards  []*PortForward
+		phase     corev1.PodPhase
+		lost      int
+		fwErr     error
+		want      map[string][]string
+		wantCalls int
+		wantErr   string
+	}{{
+		desc:     "success",
+		forwards: forwards,
+		phase:    corev1.PodRunning,
+		want: map[string][]string{
+			"test/r1": {"20000:22", "20001:8443"},
+			"test/r2": {"20002:9339"},
+		},
+		wantCalls: 2,
+	}, {
+		desc:     "reconnect",
+		forwards: forwards[2:],
+		phase:    corev1.PodRunning,
+		lost:     2,
+		want: map[string][]string{
+			"test/r2": {"20002:9339"},
+		},
+		wantCalls: 3,
+	}, {
+		desc:     "listen failure",
+		forwards: forwards,
+		phase:    corev1.PodRunning,
+		fwErr:    fmt.Errorf("unable to listen on any of the requested ports"),
+		wantErr:  "unable to listen",
+	}, {
+		desc:     "no running pod",
+		forwards: forwards,
+		phase:    corev1.PodPending,
+	}, {
+		desc:     "node not found",
+		forwards: []*PortForward{{Node: "r4", LocalPort: 20000, PodPort: 22}},
+		wantErr:  `node "r4" not found`,
+	}, {
+		desc:    "no forwards",
+		wantErr: "no ports to forward",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			var mu sync.Mutex
+			got := map[string][]string{}
+			calls := 0
+			forwardPorts = func(ctx context.Context, _ *rest.Config, _ kubernetes.Interface, namespace, pod string, ports []string, ready chan struct{}) error {
+				mu.Lock()
+				calls++
+				lost := calls <= tt.lost
+				if tt.fwErr == nil {
+					got[namespace+"/"+pod] = ports
+				}
+				mu.Unlock()
+				if tt.fwErr != nil {
+					return tt.fwErr
+				}
+				close(ready)
+				if lost {
+					return fmt.Errorf("lost connection to pod")
+				}
+				<-ctx.Done()
+				return nil
+			}
+			if tt.phase == corev1.PodPending {
+				var c context.CancelFunc
+				ctx, c = context.WithTimeout(ctx, 50*time.Millisecond)
+				defer c()
+			}
+			m := forwardManager(tt.phase)
+			ready := make(chan struct{})
+			errCh := make(chan error)
+			go func() {
+				errCh <- m.Forward(ctx, tt.forwards, func() { close(ready) })
+			}()
+			var err error
+			select {
+			case err = <-errCh:
+			case <-ready:
+				// Wait for the expected forwards before stopping them.
+				for {
+					mu.Lock()
+					c := calls
+					mu.Unlock()
+					if c >= tt.wantCalls || ctx.Err() != nil {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				cancel()
+				err = <-errCh
+			}
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("Forward() unexpected error: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			select {
+			case <-ready:
+			default:
+				if tt.phase == corev1.PodRunning {
+					t.Errorf("Forward() did not report ready ports")
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Forward() forwarded ports %d times, want %d", calls, tt.wantCalls)
+			}
+			if len(tt.want) == 0 {
+				tt.want = map[string][]string{}
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("Forward() unexpected ports (-want +got):\n%s", s)
+			}
+		})
+	}
+}
@@ -106,7 +106,7 @@ func (n *Node) Create(ctx context.Context) error {
 // CreatePod creates a Pod for the Node based on the underlying proto.
 func (n *Node) CreatePod(ctx context.Context) error {
 	pb := n.Proto
-	log.Infof("Creating Pod:\n %+v", pb)
+	log.Infof("Creating Pod:\n %+v", node.StripPassword(pb))
 
 	initContainerImage := pb.Config.InitImage
 	if initContainerImage == "" {
@@ -269,6 +269,9 @@ func (n *Node) CreatePod(ctx context.Context) error {
 			pod.Spec.Containers[i].Args = append(pod.Spec.Containers[i].Args, fmt.Sprintf("--config_file=%s/%s", pb.Config.ConfigPath, pb.Config.ConfigFile))
 		}
 	}
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
+	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -19,8 +19,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -45,6 +43,9 @@ import (
 
 const (
 	scrapliPlatformName = "arista_eos"
+	// sslProfile is the SSL profile of the certificate issued by the
+	// topology CA, used by the gNMI server.
+	sslProfile = "kne"
 )
 
 var (
@@ -69,9 +70,12 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Certer       = (*Node)(nil)
-	_ node.ConfigPusher = (*Node)(nil)
-	_ node.Resetter     = (*Node)(nil)
+	_ node.Certer        = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
+	_ node.ConfigPusher  = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
+	_ node.CLIer         = (*Node)(nil)
+	_ node.Commander     = (*Node)(nil)
 
 	ethIntfRe  = regexp.MustCompile(`^Ethernet\d+(?:/\d+)?(?:/\d+)?$`)
 	mgmtIntfRe = regexp.MustCompile(`^Management\d+(?:/\d+)?$`)
@@ -190,16 +194,9 @@ func (n *Node) Status(ctx context.Context) (node.Status, error) {
 
 func (n *Node) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
 	pb := n.Proto
-	var data []byte
-	switch v := pb.Config.GetConfigData().(type) {
-	case *tpb.Config_File:
-		var err error
-		data, err = os.ReadFile(filepath.Join(n.BasePath, v.File))
-		if err != nil {
-			return nil, err
-		}
-	case *tpb.Config_Data:
-		data = v.Data
+	data, err := n.ReadConfig()
+	if err != nil {
+		return nil, err
 	}
 	if data != nil {
 		cm := &corev1.ConfigMap{
@@ -235,6 +232,18 @@ func (n *Node) CreateCRD(ctx context.Context) error {
 	if linksLen > math.MaxInt32 {
 		return fmt.Errorf("links count %d out of range (max: %d)", linksLen, math.MaxInt32)
 	}
+	// The CEosLabDevice custom resource only takes plain environment
+	// variables and no volumes, so Secrets cannot be passed to cEOS nodes
+	// without copying their values into the custom resource.
+	if len(config.GetSecrets()) > 0 {
+		return fmt.Errorf("secrets are not supported by cEOS nodes: the CEosLabDevice custom resource only takes plain environment variables and no volumes")
+	}
+	if err := n.ValidateCRConfig(false); err != nil {
+		return err
+	}
+	if err := n.ValidateCRServices(); err != nil {
+		return err
+	}
 	device := &ceos.CEosLabDevice{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "ceoslab.arista.com/v1alpha1",
@@ -348,22 +357,47 @@ func (n *Node) Delete(ctx context.Context) error {
 // to accept inputs.
 // scrapligo options can be provided to this function for a caller to modify scrapligo platform.
 // For example, mock transport can be set via options
-func (n *Node) SpawnCLIConn() error {
-	opts := []scrapliutil.Option{
-		scrapliopts.WithAuthBypass(),
+func (n *Node) SpawnCLIConn(ctx context.Context) error {
+	opts, err := n.CLIAuthOptions(ctx)
+	if err != nil {
+		return err
 	}
 
 	// add options defined in test package
 	opts = append(opts, n.testOpts...)
 
-	opts = n.PatchCLIConnOpen("kubectl", []string{"Cli"}, opts)
+	opts = n.PatchCLIConnOpen("kubectl", n.CLICommand(), opts)
 
-	var err error
-	n.cliConn, err = n.GetCLIConn(scrapliPlatformName, opts)
+	n.cliConn, err = n.GetCLIConn(ctx, scrapliPlatformName, opts)
 
 	return err
 }
 
+// Operations returns the gNOI operations supported by the node. cEOS does
+// not support reloading the switch from within its container, so there is no
+// reboot: the node is restarted by deleting its pod instead.
+func (n *Node) Operations() []node.Operation {
+	return n.GNOIOperations(node.OperationFilePut, node.OperationFileGet, node.OperationCertInstall, node.OperationPing)
+}
+
+// CLICommand returns the command that starts the EOS CLI in the node container.
+func (n *Node) CLICommand() []string {
+	return []string{"Cli"}
+}
+
+// RunCommands runs cmds on the EOS CLI using scrapligo SendCommands.
+func (n *Node) RunCommands(ctx context.Context, cmds []string) ([]*node.CommandResult, error) {
+	log.Infof("%s - running commands", n.Name())
+
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return nil, err
+	}
+
+	defer n.cliConn.Close()
+
+	return node.SendCommands(ctx, n.cliConn, cmds)
+}
+
 func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 	return status.Errorf(codes.Unimplemented, "Node %q does not implement Certer interface. "+
 		"To configure a certificate on a cEOS-lab device, define the certificate in the "+
@@ -371,6 +405,67 @@ func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 		"CEosLabDevice custom resource instance.", n.Name())
 }
 
+// sslProfileConfig returns the config of the SSL profile of the certificate
+// and key issued by the topology CA and of the gNMI server using it.
+func sslProfileConfig(certName, keyName string) []string {
+	return []string{
+		"management security",
+		fmt.Sprintf("ssl profile %s", sslProfile),
+		fmt.Sprintf("certificate %s key %s", certName, keyName),
+		"trust certificate kne-ca.crt",
+		"management api gnmi",
+		"transport grpc default",
+		fmt.Sprintf("ssl profile %s", sslProfile),
+	}
+}
+
+// InstallCert copies the certificate and key issued by the topology CA, and the
+// CA certificate as kne-ca.crt, to flash, installs them in the EOS
+// certificate and key stores and configures the gNMI server to use them.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	certName, keyName, err := n.CAIssuedCertNames()
+	if err != nil {
+		return err
+	}
+	log.Infof("%s - installing CA issued cert %s", n.Name(), certName)
+	// The pod IP the cert is issued for is assigned before the EOS container
+	// runs, so wait for the CLI, which is retried until ctx is done, before
+	// writing the files.
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return err
+	}
+	defer n.cliConn.Close()
+	if err := n.WriteFiles(ctx, map[string][]byte{
+		"/mnt/flash/" + certName: cert.CertPEM,
+		"/mnt/flash/" + keyName:  cert.KeyPEM,
+		"/mnt/flash/kne-ca.crt":  cert.CAPEM,
+	}); err != nil {
+		return err
+	}
+	results, err := node.SendCommands(ctx, n.cliConn, []string{
+		fmt.Sprintf("copy flash:%s certificate:%s", certName, certName),
+		fmt.Sprintf("copy flash:%s sslkey:%s", keyName, keyName),
+		"copy flash:kne-ca.crt certificate:kne-ca.crt",
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			return fmt.Errorf("%q failed: %w", r.Command, r.Err)
+		}
+	}
+	resp, err := n.cliConn.SendConfigs(sslProfileConfig(certName, keyName))
+	if err != nil {
+		return fmt.Errorf("failed configuring ssl profile: %w", err)
+	}
+	if resp.Failed != nil {
+		return fmt.Errorf("failed configuring ssl profile: %w", resp.Failed)
+	}
+	log.Infof("%s - finished installing cert", n.Name())
+	return nil
+}
+
 func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 	log.Infof("%s - pushing config", n.Name())
 
@@ -383,7 +478,7 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 		return err
 	}
 
-	err = n.SpawnCLIConn()
+	err = n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -405,7 +500,7 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 func (n *Node) ResetCfg(ctx context.Context) error {
 	log.Infof("%s resetting config", n.Name())
 
-	err := n.SpawnCLIConn()
+	err := n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -513,10 +608,21 @@ func (n *Node) FixInterfaces() error {
 	return nil
 }
 
+// intfName returns the cEOS name EthernetN of the interface ethN. Other names
+// are returned unchanged.
+func intfName(_ *tpb.Node, name string) (string, error) {
+	i, ok := node.EthIntfNum(name)
+	if !ok {
+		return name, nil
+	}
+	return fmt.Sprintf("Ethernet%d", i), nil
+}
+
 func (n *Node) DefaultNodeConstraints() node.Constraints {
 	return defaultConstraints
 }
 
 func init() {
 	node.Vendor(tpb.Vendor_ARISTA, New)
+	node.VendorIntfNames(tpb.Vendor_ARISTA, intfName)
 }
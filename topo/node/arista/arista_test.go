@@ -473,6 +473,23 @@ func TestCRD(t *testing.T) {
 	}
 }
 
+func TestCRDSecrets(t *testing.T) {
+	n := &Node{
+		Impl: &node.Impl{
+			KubeClient: fake.NewSimpleClientset(),
+			Namespace:  "default",
+			Proto: &topopb.Node{
+				Name:   "device",
+				Config: &topopb.Config{Secrets: []*topopb.SecretRef{{Name: "license", Env: map[string]string{"LICENSE": "key"}}}},
+			},
+		},
+	}
+	err := n.CreateCRD(context.Background())
+	if s := errdiff.Substring(err, "secrets are not supported by cEOS nodes"); s != "" {
+		t.Errorf("CreateCRD() unexpected error: %s", s)
+	}
+}
+
 func TestResetCfg(t *testing.T) {
 	ki := fake.NewSimpleClientset(&corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -555,6 +572,44 @@ func TestResetCfg(t *testing.T) {
 	}
 }
 
+func TestRunCommands(t *testing.T) {
+	ni := &node.Impl{
+		KubeClient: fake.NewSimpleClientset(),
+		Namespace:  "test",
+		Proto: &topopb.Node{
+			Name:   "pod1",
+			Vendor: topopb.Vendor_ARISTA,
+			Config: &topopb.Config{},
+		},
+	}
+	nImpl, err := New(ni)
+	if err != nil {
+		t.Fatalf("failed creating kne arista node")
+	}
+	n, _ := nImpl.(*Node)
+	n.testOpts = []scrapliutil.Option{
+		scrapliopts.WithTransportType(scraplitransport.FileTransport),
+		scrapliopts.WithFileTransportFile("testdata/run_commands"),
+		scrapliopts.WithTimeoutOps(10 * time.Second),
+		scrapliopts.WithTransportReadSize(1),
+		scrapliopts.WithReadDelay(0),
+		scrapliopts.WithDefaultLogger(),
+	}
+	got, err := n.RunCommands(context.Background(), []string{"show version", "show foo"})
+	if err != nil {
+		t.Fatalf("RunCommands() failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("RunCommands() got %d results, want 2", len(got))
+	}
+	if got[0].Err != nil || got[0].Output != "Arista cEOSLab" {
+		t.Errorf("RunCommands() got result %+v for %q, want output %q", got[0], got[0].Command, "Arista cEOSLab")
+	}
+	if got[1].Err == nil {
+		t.Errorf("RunCommands() got nil error for %q, want error", got[1].Command)
+	}
+}
+
 func TestStatus(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -646,3 +701,58 @@ func TestDefaultNodeConstraints(t *testing.T) {
 		t.Errorf("DefaultNodeConstraints() returned unexpected Memory: got %s, want %s", constraints.Memory, defaultConstraints.Memory)
 	}
 }
+
+func TestSSLProfileConfig(t *testing.T) {
+	want := []string{
+		"management security",
+		"ssl profile kne",
+		"certificate gnmi.crt key gnmi.key",
+		"trust certificate kne-ca.crt",
+		"management api gnmi",
+		"transport grpc default",
+		"ssl profile kne",
+	}
+	if s := cmp.Diff(want, sslProfileConfig("gnmi.crt", "gnmi.key")); s != "" {
+		t.Errorf("sslProfileConfig() unexpected diff (-want +got):\n%s", s)
+	}
+}
+
+func TestVendorIntfName(t *testing.T) {
+	tests := []struct {
+		node *topopb.Node
+		name string
+		want string
+	}{
+		{node: &topopb.Node{Vendor: topopb.Vendor_ARISTA}, name: "eth1", want: "Ethernet1"},
+		{node: &topopb.Node{Vendor: topopb.Vendor_ARISTA}, name: "eth12", want: "Ethernet12"},
+		{node: &topopb.Node{Vendor: topopb.Vendor_ARISTA}, name: "mgmt0", want: "mgmt0"},
+		{
+			node: &topopb.Node{
+				Vendor:     topopb.Vendor_ARISTA,
+				Interfaces: map[string]*topopb.Interface{"eth1": {Name: "custom1"}},
+			},
+			name: "eth1",
+			want: "custom1",
+		},
+	}
+	for _, tt := range tests {
+		got, err := node.VendorIntfName(tt.node, tt.name)
+		if err != nil {
+			t.Fatalf("VendorIntfName(%q) failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("VendorIntfName(%q) got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOperations(t *testing.T) {
+	n := &Node{Impl: &node.Impl{Proto: &topopb.Node{
+		Name:     "dev1",
+		Services: map[uint32]*topopb.Service{6030: {Names: []string{"gnmi", "gnoi"}, Inside: 6030}},
+	}}}
+	want := []node.Operation{node.OperationFilePut, node.OperationFileGet, node.OperationCertInstall, node.OperationPing}
+	if s := cmp.Diff(want, n.Operations()); s != "" {
+		t.Errorf("Operations() unexpected diff (-want +got):\n%s", s)
+	}
+}
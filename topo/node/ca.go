@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// DefaultCertKeySize is the RSA key size of certificates when not set.
+	DefaultCertKeySize = 2048
+	// DefaultCertName and DefaultKeyName are the names of CA issued
+	// certificates and keys on nodes when not set.
+	DefaultCertName = "kne.crt"
+	DefaultKeyName  = "kne.key"
+	// CABundleSecretName is the Secret of the topology namespace with the
+	// certificate of the topology CA in the CABundleKey key.
+	CABundleSecretName = "kne-ca-bundle"
+	CABundleKey        = "ca.crt"
+	caValidity         = 10 * 365 * 24 * time.Hour
+	certValidity       = 365 * 24 * time.Hour
+)
+
+// CA is a certificate authority issuing the certificates of nodes.
+type CA struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// Cert is a certificate issued to a node.
+type Cert struct {
+	CertPEM []byte
+	KeyPEM  []byte
+	// CAPEM is the certificate of the issuing CA.
+	CAPEM []byte
+}
+
+func newKey(keySize uint32) (*rsa.PrivateKey, []byte, error) {
+	if keySize == 0 {
+		keySize = DefaultCertKeySize
+	}
+	key, err := rsa.GenerateKey(rand.Reader, int(keySize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, keyPEM, nil
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// NewCA returns a new self-signed CA.
+func NewCA(commonName string, keySize uint32) (*CA, error) {
+	key, keyPEM, err := newKey(keySize)
+	if err != nil {
+		return nil, err
+	}
+	sn, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          sn,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	return ParseCA(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM)
+}
+
+// ParseCA returns the CA with the provided PEM encoded certificate and key.
+func ParseCA(certPEM, keyPEM []byte) (*CA, error) {
+	b, _ := pem.Decode(certPEM)
+	if b == nil || b.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded CA certificate found")
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("certificate %q is not a CA", cert.Subject.CommonName)
+	}
+	kb, _ := pem.Decode(keyPEM)
+	if kb == nil {
+		return nil, fmt.Errorf("no PEM encoded CA key found")
+	}
+	var key crypto.Signer
+	switch kb.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(kb.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(kb.Bytes)
+	default:
+		var k any
+		k, err = x509.ParsePKCS8PrivateKey(kb.Bytes)
+		if s, ok := k.(crypto.Signer); ok {
+			key = s
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unsupported CA key type %q", kb.Type)
+	}
+	return &CA{cert: cert, key: key, certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+// CertPEM returns the PEM encoded certificate of the CA.
+func (ca *CA) CertPEM() []byte {
+	return ca.certPEM
+}
+
+// KeyPEM returns the PEM encoded key of the CA.
+func (ca *CA) KeyPEM() []byte {
+	return ca.keyPEM
+}
+
+// Issue returns a new server and client certificate with the provided common
+// name and subject alternative names, signed by the CA.
+func (ca *CA) Issue(commonName string, dnsNames []string, ips []net.IP, keySize uint32) (*Cert, error) {
+	key, keyPEM, err := newKey(keySize)
+	if err != nil {
+		return nil, err
+	}
+	sn, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: sn,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate: %w", err)
+	}
+	return &Cert{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  keyPEM,
+		CAPEM:   ca.certPEM,
+	}, nil
+}
+
+// validCertNameRegexp defines the allowed characters in a certificate name.
+var validCertNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// CAIssuedCertNames returns the names of the CA issued certificate and key on
+// the node.
+func (n *Impl) CAIssuedCertNames() (string, string, error) {
+	cfg := n.GetProto().GetConfig().GetCert().GetCaIssued()
+	certName, keyName := cfg.GetCertName(), cfg.GetKeyName()
+	if certName == "" {
+		certName = DefaultCertName
+	}
+	if keyName == "" {
+		keyName = DefaultKeyName
+	}
+	for _, name := range []string{certName, keyName} {
+		if !validCertNameRegexp.MatchString(name) {
+			return "", "", fmt.Errorf("invalid cert name %q: must match %s", name, validCertNameRegexp.String())
+		}
+	}
+	return certName, keyName, nil
+}
+
+// WriteFiles writes files, keyed by absolute path, in the default container
+// of the pod of the node, which must provide sh.
+func (n *Impl) WriteFiles(ctx context.Context, files map[string][]byte) error {
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if !path.IsAbs(p) {
+			return fmt.Errorf("path %q must be absolute", p)
+		}
+		req := n.KubeClient.CoreV1().RESTClient().Post().Resource("pods").Name(n.Name()).Namespace(n.Namespace).SubResource("exec")
+		req.VersionedParams(&corev1.PodExecOptions{
+			Command: []string{"sh", "-c", fmt.Sprintf("mkdir -p '%s' && cat > '%s'", path.Dir(p), p)},
+			Stdin:   true,
+			Stderr:  true,
+		}, scheme.ParameterCodec)
+		exec, err := remotecommand.NewSPDYExecutor(n.RestConfig, "POST", req.URL())
+		if err != nil {
+			return err
+		}
+		var stderr bytes.Buffer
+		if err := exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdin:  bytes.NewReader(files[p]),
+			Stderr: &stderr,
+		}); err != nil {
+			return fmt.Errorf("failed to write %q: %w: %s", p, err, stderr.String())
+		}
+	}
+	return nil
+}
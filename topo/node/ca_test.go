@@ -0,0 +1,127 @@
+package node
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+)
+
+func TestCA(t *testing.T) {
+	ca, err := NewCA("test CA", 1024)
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	parsed, err := ParseCA(ca.CertPEM(), ca.KeyPEM())
+	if err != nil {
+		t.Fatalf("ParseCA() failed: %v", err)
+	}
+	cert, err := parsed.Issue("r1", []string{"r1"}, []net.IP{net.ParseIP("192.168.18.100")}, 1024)
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	if s := cmp.Diff(ca.CertPEM(), cert.CAPEM); s != "" {
+		t.Errorf("Issue() unexpected CA diff (-want +got):\n%s", s)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(cert.CAPEM)
+	b, _ := pem.Decode(cert.CertPEM)
+	c, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse issued cert: %v", err)
+	}
+	for _, name := range []string{"r1", "192.168.18.100"} {
+		if _, err := c.Verify(x509.VerifyOptions{DNSName: name, Roots: roots}); err != nil {
+			t.Errorf("Verify(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := c.Verify(x509.VerifyOptions{DNSName: "r2", Roots: roots}); err == nil {
+		t.Errorf("Verify(%q) succeeded, want error", "r2")
+	}
+	if _, err := ParseCA(cert.CertPEM, cert.KeyPEM); err == nil {
+		t.Errorf("ParseCA() of a node cert succeeded, want error")
+	}
+}
+
+func TestParseCAErrors(t *testing.T) {
+	ca, err := NewCA("test CA", 1024)
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		cert    []byte
+		key     []byte
+		wantErr string
+	}{{
+		desc:    "no cert",
+		key:     ca.KeyPEM(),
+		wantErr: "no PEM encoded CA certificate found",
+	}, {
+		desc:    "no key",
+		cert:    ca.CertPEM(),
+		wantErr: "no PEM encoded CA key found",
+	}, {
+		desc:    "invalid key",
+		cert:    ca.CertPEM(),
+		key:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("invalid")}),
+		wantErr: "failed to parse CA key",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := ParseCA(tt.cert, tt.key)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("ParseCA() unexpected error: %s", s)
+			}
+		})
+	}
+}
+
+func TestCAIssuedCertNames(t *testing.T) {
+	tests := []struct {
+		desc     string
+		cfg      *topopb.CAIssuedCertCfg
+		wantCert string
+		wantKey  string
+		wantErr  string
+	}{{
+		desc:     "defaults",
+		cfg:      &topopb.CAIssuedCertCfg{},
+		wantCert: DefaultCertName,
+		wantKey:  DefaultKeyName,
+	}, {
+		desc:     "names",
+		cfg:      &topopb.CAIssuedCertCfg{CertName: "gnmi.crt", KeyName: "gnmi.key"},
+		wantCert: "gnmi.crt",
+		wantKey:  "gnmi.key",
+	}, {
+		desc:    "invalid name",
+		cfg:     &topopb.CAIssuedCertCfg{CertName: "../gnmi.crt"},
+		wantErr: `invalid cert name "../gnmi.crt"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Proto: &topopb.Node{
+					Name: "dev1",
+					Config: &topopb.Config{
+						Cert: &topopb.CertificateCfg{
+							Config: &topopb.CertificateCfg_CaIssued{CaIssued: tt.cfg},
+						},
+					},
+				},
+			}
+			gotCert, gotKey, err := n.CAIssuedCertNames()
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("CAIssuedCertNames() unexpected error: %s", s)
+			}
+			if gotCert != tt.wantCert || gotKey != tt.wantKey {
+				t.Errorf("CAIssuedCertNames() = %q, %q, want %q, %q", gotCert, gotKey, tt.wantCert, tt.wantKey)
+			}
+		})
+	}
+}
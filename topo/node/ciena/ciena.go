@@ -166,7 +166,7 @@ func (n *Node) Delete(ctx context.Context) error {
 // CreatePod creates a Pod for the Node based on the underlying proto.
 func (n *Node) CreatePod(ctx context.Context) error {
 	pb := n.Proto
-	log.Infof("Creating Pod:\n %+v", pb)
+	log.Infof("Creating Pod:\n %+v", node.StripPassword(pb))
 
 	var extraVolumes []corev1.Volume
 	var extraMounts []corev1.VolumeMount
@@ -283,6 +283,9 @@ func (n *Node) CreatePod(ctx context.Context) error {
 			pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
 		}
 	}
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
+	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to create pod for %q: %w", pb.Name, err)
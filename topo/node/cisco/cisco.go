@@ -145,7 +145,10 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Resetter = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
+	_ node.CLIer         = (*Node)(nil)
+	_ node.Commander     = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
 )
 
 // For enabling option to skip validation in unit tests
@@ -339,6 +342,9 @@ fi
 			}
 		}
 	}
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
+	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to create pod for %q: %w", pb.Name, err)
@@ -481,8 +487,8 @@ func getCiscoInterfaceID(pb *tpb.Node, eth string) (string, error) {
 	if !ethWithIDRegx.MatchString(eth) {
 		return "", fmt.Errorf("interface '%s' is invalid", eth)
 	}
-	if pb.Interfaces[eth].Name != "" {
-		return pb.Interfaces[eth].Name, nil
+	if n := pb.GetInterfaces()[eth].GetName(); n != "" {
+		return n, nil
 	}
 	// ethWithIDRegx.MatchString(eth) was successful, so no need to do extra check here
 	ethID, _ := strconv.Atoi(ethRegx.Split(eth, -1)[1])
@@ -526,6 +532,16 @@ func getCiscoInterfaceID(pb *tpb.Node, eth string) (string, error) {
 	}
 }
 
+// intfName returns the name of the interface name of a node of the model of
+// pb, such as FourHundredGigE0/0/0/0 for eth1 of an 8201. Names that are not
+// of the form ethN are returned unchanged.
+func intfName(pb *tpb.Node, name string) (string, error) {
+	if _, ok := node.EthIntfNum(name); !ok {
+		return name, nil
+	}
+	return getCiscoInterfaceID(pb, name)
+}
+
 func defaults(pb *tpb.Node) (*tpb.Node, error) {
 	defaultNodeClone := proto.Clone(&defaultCiscoNode).(*tpb.Node)
 	if pb == nil {
@@ -671,16 +687,20 @@ func noOp(d *scraplinetwork.Driver) error {
 // to accept inputs.
 // scrapligo options can be provided to this function for a caller to modify scrapligo platform.
 // For example, mock transport can be set via options
-func (n *Node) SpawnCLIConn() error {
+func (n *Node) SpawnCLIConn(ctx context.Context) error {
 	opts := []scrapliutil.Option{
-		scrapliopts.WithAuthBypass(),
 		scrapliopts.WithTimeoutOps(scrapliOperationTimeout),
 	}
+	authOpts, err := n.CLIAuthOptions(ctx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, authOpts...)
 	// add options defined in test package
 	opts = append(opts, n.testOpts...)
 	if n.Proto.Model != ModelXRD {
 		opts = n.PatchCLIConnOpen("kubectl", []string{"xr"}, opts)
-		opts = n.PatchCLIConnOpen("kubectl", []string{"telnet", "0", "60000"}, opts)
+		opts = n.PatchCLIConnOpen("kubectl", n.CLICommand(), opts)
 	} else {
 		opts = append(opts, scrapliopts.WithDefaultDesiredPriv("run"))
 		// Overwrite scrapligo's default network on open function with a no-op function.
@@ -688,10 +708,9 @@ func (n *Node) SpawnCLIConn() error {
 		// as no commands are run that could have an output that pages. Any future change that uses the run CLI
 		// should consider this limitation.
 		opts = append(opts, scrapliopts.WithNetworkOnOpen(noOp))
-		opts = n.PatchCLIConnOpen("kubectl", []string{"bash", "/pkg/bin/xr_cli", "run"}, opts)
+		opts = n.PatchCLIConnOpen("kubectl", append(n.CLICommand(), "run"), opts)
 	}
-	var err error
-	n.cliConn, err = n.GetCLIConn(scrapliPlatformName, opts)
+	n.cliConn, err = n.GetCLIConn(ctx, scrapliPlatformName, opts)
 	if err != nil {
 		return err
 	}
@@ -710,27 +729,62 @@ func (n *Node) SpawnCLIConn() error {
 	return nil
 }
 
+// Operations returns the gNOI operations supported by the node. The 8000e
+// models emulate a whole router in a VM, which reboots like the hardware. XRd
+// is the XR control plane run directly in the container and does not support
+// reloading the router, so it has no reboot.
+func (n *Node) Operations() []node.Operation {
+	if n.Proto.Model == ModelXRD {
+		return n.GNOIOperations(node.OperationFilePut, node.OperationFileGet, node.OperationCertInstall, node.OperationPing)
+	}
+	return n.GNOIOperations(node.AllOperations...)
+}
+
+// CLICommand returns the command that starts the IOS XR CLI in the node container.
+func (n *Node) CLICommand() []string {
+	if n.Proto.Model != ModelXRD {
+		return []string{"telnet", "0", "60000"}
+	}
+	return []string{"bash", "/pkg/bin/xr_cli"}
+}
+
+// RunCommands runs cmds on the IOS XR CLI using scrapligo SendCommands.
+func (n *Node) RunCommands(ctx context.Context, cmds []string) ([]*node.CommandResult, error) {
+	log.Infof("%s - running commands", n.Name())
+
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return nil, err
+	}
+
+	defer n.cliConn.Close()
+
+	return node.SendCommands(ctx, n.cliConn, cmds)
+}
+
 // SpawnCLIConnConf spawns a connection towards a IOSXR configuration CLI for XRd using `kubectl exec` terminal
 // and ensures configuration CLI is ready to accept inputs.
-func (n *Node) SpawnCLIConnConf() error {
+func (n *Node) SpawnCLIConnConf(ctx context.Context) error {
 	if n.Proto.Model != ModelXRD {
 		return status.Errorf(codes.Unimplemented, "SpawnCLIConnConf only implemented for Cisco XRd node, for other node types use SpawnCLIConn")
 	}
 
 	opts := []scrapliutil.Option{
-		scrapliopts.WithAuthBypass(),
 		scrapliopts.WithDefaultDesiredPriv("configuration"),
 		scrapliopts.WithTimeoutOps(scrapliOperationTimeout),
 		scrapliopts.WithNetworkOnOpen(noOp),
 	}
+	authOpts, err := n.CLIAuthOptions(ctx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, authOpts...)
 	// add options defined in test package
 	opts = append(opts, n.testOpts...)
 	// Go straight to the config prompt. Note that the terminal length and width can't be set from within
 	// the config prompt. This is not an issue as no commands are run here or by scrapligo with could have
 	// an output that pages.
 	opts = n.PatchCLIConnOpen("kubectl", []string{"bash", "/pkg/bin/xr_cli", "config"}, opts)
-	var err error
-	n.cliConn, err = n.GetCLIConn(scrapliPlatformName, opts)
+	n.cliConn, err = n.GetCLIConn(ctx, scrapliPlatformName, opts)
 	if err != nil {
 		return err
 	}
@@ -765,7 +819,7 @@ func (n *Node) ResetCfg(ctx context.Context) error {
 		}
 	}
 
-	err := n.SpawnCLIConn()
+	err := n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -832,9 +886,9 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 	log.V(1).Info(cfgs)
 
 	if n.Proto.Model != ModelXRD {
-		err = n.SpawnCLIConn()
+		err = n.SpawnCLIConn(ctx)
 	} else {
-		err = n.SpawnCLIConnConf()
+		err = n.SpawnCLIConnConf(ctx)
 	}
 	if err != nil {
 		return err
@@ -863,6 +917,15 @@ func (n *Node) GenerateSelfSigned(context.Context) error {
 	return status.Errorf(codes.Unimplemented, "certificate generation is not supported")
 }
 
+// InstallCert installs the cert issued by the topology CA with gNOI
+// CertificateManagement.Install. Until then IOS XR serves gRPC with the
+// self-signed cert it generates when gRPC is enabled, so the node needs a gnoi
+// service and gRPC enabled in its startup config.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	return n.GNOIInstallCert(ctx, cert)
+}
+
 func init() {
 	node.Vendor(tpb.Vendor_CISCO, New)
+	node.VendorIntfNames(tpb.Vendor_CISCO, intfName)
 }
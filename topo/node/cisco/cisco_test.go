@@ -1407,3 +1407,89 @@ func TestValidPathRegexp(t *testing.T) {
 		})
 	}
 }
+
+func TestVendorIntfName(t *testing.T) {
+	tests := []struct {
+		desc    string
+		node    *tpb.Node
+		name    string
+		want    string
+		wantErr string
+	}{{
+		desc: "xrd",
+		node: &tpb.Node{Vendor: tpb.Vendor_CISCO, Model: "xrd"},
+		name: "eth1",
+		want: "GigabitEthernet0/0/0/0",
+	}, {
+		desc: "8201 400G",
+		node: &tpb.Node{Vendor: tpb.Vendor_CISCO, Model: "8201"},
+		name: "eth1",
+		want: "FourHundredGigE0/0/0/0",
+	}, {
+		desc: "8201 100G",
+		node: &tpb.Node{Vendor: tpb.Vendor_CISCO, Model: "8201"},
+		name: "eth25",
+		want: "HundredGigE0/0/0/24",
+	}, {
+		desc: "interface config name",
+		node: &tpb.Node{
+			Vendor:     tpb.Vendor_CISCO,
+			Model:      "8201",
+			Interfaces: map[string]*tpb.Interface{"eth1": {Name: "FourHundredGigE0/0/0/0/1"}},
+		},
+		name: "eth1",
+		want: "FourHundredGigE0/0/0/0/1",
+	}, {
+		desc: "not ethN",
+		node: &tpb.Node{Vendor: tpb.Vendor_CISCO, Model: "8201"},
+		name: "mgmt",
+		want: "mgmt",
+	}, {
+		desc:    "8101-32H out of range",
+		node:    &tpb.Node{Vendor: tpb.Vendor_CISCO, Model: "8101-32H"},
+		name:    "eth33",
+		wantErr: "eth1..eth32 is supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := node.VendorIntfName(tt.node, tt.name)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("VendorIntfName() failed: %s", s)
+			}
+			if got != tt.want {
+				t.Errorf("VendorIntfName() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	services := map[uint32]*tpb.Service{9339: {Names: []string{"gnmi", "gnoi"}, Inside: 57400}}
+	tests := []struct {
+		desc  string
+		model string
+		svcs  map[uint32]*tpb.Service
+		want  []node.Operation
+	}{{
+		desc:  "8201",
+		model: "8201",
+		svcs:  services,
+		want:  node.AllOperations,
+	}, {
+		desc:  "xrd",
+		model: ModelXRD,
+		svcs:  services,
+		want:  []node.Operation{node.OperationFilePut, node.OperationFileGet, node.OperationCertInstall, node.OperationPing},
+	}, {
+		desc:  "no gnoi service",
+		model: "8201",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Node{Impl: &node.Impl{Proto: &tpb.Node{Name: "dev1", Model: tt.model, Services: tt.svcs}}}
+			if s := cmp.Diff(tt.want, n.Operations()); s != "" {
+				t.Errorf("Operations() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
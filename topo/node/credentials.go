@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	scrapliopts "github.com/scrapli/scrapligo/driver/options"
+	scrapliutil "github.com/scrapli/scrapligo/util"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Credentials returns the username and password of the node, reading them
+// from the Secret or file of the credentials of the node if set. nil is
+// returned if the node has no credentials.
+func (n *Impl) Credentials(ctx context.Context) (*tpb.Credentials, error) {
+	c := n.GetProto().GetCredentials()
+	if c == nil {
+		return nil, nil
+	}
+	creds := &tpb.Credentials{}
+	switch {
+	case c.GetSecret() != "":
+		s, err := n.KubeClient.CoreV1().Secrets(n.Namespace).Get(ctx, c.GetSecret(), metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials secret %q: %w", c.GetSecret(), err)
+		}
+		creds.Username = string(s.Data[corev1.BasicAuthUsernameKey])
+		creds.Password = string(s.Data[corev1.BasicAuthPasswordKey])
+	case c.GetFile() != "":
+		p := c.GetFile()
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(n.BasePath, p)
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		var f struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := yaml.UnmarshalStrict(b, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %q: %w", c.GetFile(), err)
+		}
+		creds.Username = f.Username
+		creds.Password = f.Password
+	}
+	if c.GetUsername() != "" {
+		creds.Username = c.GetUsername()
+	}
+	if c.GetPassword() != "" {
+		creds.Password = c.GetPassword()
+	}
+	if creds.GetUsername() == "" {
+		return nil, fmt.Errorf("credentials of node %q have no username", n.Name())
+	}
+	return creds, nil
+}
+
+// StripPassword returns a copy of pb without its inline password, so that
+// the node can be logged. Credentials from Secrets and files are kept as
+// references.
+func StripPassword(pb *tpb.Node) *tpb.Node {
+	pb = proto.Clone(pb).(*tpb.Node)
+	if pb.GetCredentials() != nil {
+		pb.Credentials.Password = ""
+	}
+	return pb
+}
+
+// CLIAuthOptions returns the scrapligo options to authenticate CLI sessions
+// to the node with its credentials, or to bypass authentication if the node
+// has no credentials.
+func (n *Impl) CLIAuthOptions(ctx context.Context) ([]scrapliutil.Option, error) {
+	creds, err := n.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return []scrapliutil.Option{scrapliopts.WithAuthBypass()}, nil
+	}
+	return []scrapliutil.Option{
+		scrapliopts.WithAuthUsername(creds.GetUsername()),
+		scrapliopts.WithAuthPassword(creds.GetPassword()),
+	}, nil
+}
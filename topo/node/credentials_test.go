@@ -0,0 +1,135 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCredentials(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "creds.yaml"), []byte("username: file-user\npassword: file-pass\n"), 0600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("user: file-user\n"), 0600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+	kClient := kfake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "test"},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("secret-user"),
+			corev1.BasicAuthPasswordKey: []byte("secret-pass"),
+		},
+	})
+	tests := []struct {
+		desc    string
+		creds   *topopb.Credentials
+		want    *topopb.Credentials
+		wantErr string
+	}{{
+		desc: "no credentials",
+	}, {
+		desc:  "inline",
+		creds: &topopb.Credentials{Username: "admin", Password: "admin"},
+		want:  &topopb.Credentials{Username: "admin", Password: "admin"},
+	}, {
+		desc:  "secret",
+		creds: &topopb.Credentials{Source: &topopb.Credentials_Secret{Secret: "creds"}},
+		want:  &topopb.Credentials{Username: "secret-user", Password: "secret-pass"},
+	}, {
+		desc:  "file with inline username",
+		creds: &topopb.Credentials{Username: "admin", Source: &topopb.Credentials_File{File: "creds.yaml"}},
+		want:  &topopb.Credentials{Username: "admin", Password: "file-pass"},
+	}, {
+		desc:    "secret not found",
+		creds:   &topopb.Credentials{Source: &topopb.Credentials_Secret{Secret: "missing"}},
+		wantErr: `failed to get credentials secret "missing"`,
+	}, {
+		desc:    "file not found",
+		creds:   &topopb.Credentials{Source: &topopb.Credentials_File{File: "missing.yaml"}},
+		wantErr: "failed to read credentials file",
+	}, {
+		desc:    "invalid file",
+		creds:   &topopb.Credentials{Source: &topopb.Credentials_File{File: "invalid.yaml"}},
+		wantErr: `failed to parse credentials file "invalid.yaml"`,
+	}, {
+		desc:    "no username",
+		creds:   &topopb.Credentials{Password: "admin"},
+		wantErr: "have no username",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kClient,
+				BasePath:   dir,
+				Proto: &topopb.Node{
+					Name:        "dev1",
+					Credentials: tt.creds,
+				},
+			}
+			got, err := n.Credentials(context.Background())
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("Credentials() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("Credentials() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestStripPassword(t *testing.T) {
+	tests := []struct {
+		desc string
+		pb   *topopb.Node
+		want *topopb.Node
+	}{{
+		desc: "no credentials",
+		pb:   &topopb.Node{Name: "dev1"},
+		want: &topopb.Node{Name: "dev1"},
+	}, {
+		desc: "inline password",
+		pb: &topopb.Node{
+			Name:        "dev1",
+			Credentials: &topopb.Credentials{Username: "admin", Password: "secret"},
+		},
+		want: &topopb.Node{
+			Name:        "dev1",
+			Credentials: &topopb.Credentials{Username: "admin"},
+		},
+	}, {
+		desc: "secret",
+		pb: &topopb.Node{
+			Name:        "dev1",
+			Credentials: &topopb.Credentials{Source: &topopb.Credentials_Secret{Secret: "creds"}},
+		},
+		want: &topopb.Node{
+			Name:        "dev1",
+			Credentials: &topopb.Credentials{Source: &topopb.Credentials_Secret{Secret: "creds"}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			orig := proto.Clone(tt.pb)
+			got := StripPassword(tt.pb)
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("StripPassword() unexpected diff (-want +got):\n%s", s)
+			}
+			if s := cmp.Diff(orig, tt.pb, protocmp.Transform()); s != "" {
+				t.Errorf("StripPassword() modified the node (-want +got):\n%s", s)
+			}
+		})
+	}
+}
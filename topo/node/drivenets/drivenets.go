@@ -23,8 +23,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
-	"path/filepath"
 
 	"github.com/drivenets/cdnos-controller/api/v1/clientset"
 	"github.com/openconfig/kne/topo/node"
@@ -111,9 +109,10 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Certer       = (*Node)(nil)
-	_ node.ConfigPusher = (*Node)(nil)
-	_ node.Resetter     = (*Node)(nil)
+	_ node.Certer        = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
+	_ node.ConfigPusher  = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
 )
 
 var clientFn = func(c *rest.Config) (clientset.Interface, error) {
@@ -139,6 +138,15 @@ func (n *Node) cdnosCreate(ctx context.Context) error {
 	nodeSpec := n.GetProto()
 	config := nodeSpec.GetConfig()
 	log.Infof("create cdnos %q", nodeSpec.Name)
+	if err := n.ValidateCRConfig(true); err != nil {
+		return err
+	}
+	if err := n.ValidateCRServices(); err != nil {
+		return err
+	}
+	if err := n.CreateSecrets(ctx); err != nil {
+		return err
+	}
 
 	ports := map[string]cdnosv1.ServicePort{}
 
@@ -166,7 +174,7 @@ func (n *Node) cdnosCreate(ctx context.Context) error {
 			Image:          config.Image,
 			Command:        config.Command[0],
 			Args:           config.Args,
-			Env:            node.ToEnvVar(config.Env),
+			Env:            append(node.ToEnvVar(config.Env), n.SecretEnv()...),
 			ConfigPath:     config.ConfigPath,
 			ConfigFile:     config.ConfigFile,
 			InitImage:      config.InitImage,
@@ -186,6 +194,19 @@ func (n *Node) cdnosCreate(ctx context.Context) error {
 					KeySize:    int(tls.SelfSigned.KeySize),
 				},
 			}
+		case *tpb.CertificateCfg_CaIssued:
+			// The Cdnos CR only supports self-signed certs, which are
+			// served until the CA issued cert is installed.
+			cn := tls.CaIssued.CommonName
+			if cn == "" {
+				cn = nodeSpec.Name
+			}
+			dut.Spec.TLS = &cdnosv1.TLSSpec{
+				SelfSigned: &cdnosv1.SelfSignedSpec{
+					CommonName: cn,
+					KeySize:    int(tls.CaIssued.KeySize),
+				},
+			}
 		}
 	}
 
@@ -227,6 +248,23 @@ func (n *Node) cdnosStatus(ctx context.Context) (node.Status, error) {
 	}
 }
 
+// StatusDetails returns the status details of the pod of the node, with the
+// phase and message of its Cdnos resource.
+func (n *Node) StatusDetails(ctx context.Context) (*node.StatusDetails, error) {
+	if n.Impl.Proto.Model != modelCdnos {
+		return nil, fmt.Errorf("invalid model specified")
+	}
+	cs, err := clientFn(n.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+	got, err := cs.CdnosV1alpha1().Cdnoss(n.Namespace).Get(ctx, n.Name(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return node.CRStatusDetails(ctx, n.Impl, "cdnos", string(got.Status.Phase), got.Status.Message), nil
+}
+
 func (n *Node) Delete(ctx context.Context) error {
 	if n.Impl.Proto.Model != modelCdnos {
 		return fmt.Errorf("unknown model")
@@ -242,19 +280,27 @@ func (n *Node) cdnosDelete(ctx context.Context) error {
 	return cs.CdnosV1alpha1().Cdnoss(n.Namespace).Delete(ctx, n.Name(), metav1.DeleteOptions{})
 }
 
+// ResetCfg replaces the config of the node with its startup config using gNMI.
 func (n *Node) ResetCfg(ctx context.Context) error {
-	log.Info("ResetCfg is a noop.")
-	return nil
+	return n.GNMIResetCfg(ctx)
 }
 
-func (n *Node) ConfigPush(context.Context, io.Reader) error {
-	return status.Errorf(codes.Unimplemented, "config push is not implemented using gNMI to configure device")
+// ConfigPush pushes config to the node using gNMI.
+func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
+	return n.GNMIConfigPush(ctx, r)
 }
 
 func (n *Node) GenerateSelfSigned(context.Context) error {
 	return status.Errorf(codes.Unimplemented, "certificate generation is not supported")
 }
 
+// InstallCert installs the cert issued by the topology CA with gNOI
+// CertificateManagement.Install, replacing the self-signed cert the cdnos
+// controller created for the node.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	return n.GNOIInstallCert(ctx, cert)
+}
+
 func cdnosDefaults(pb *tpb.Node) *tpb.Node {
 	defaultNodeClone := proto.Clone(&defaultNode).(*tpb.Node)
 	if pb.Config == nil {
@@ -323,16 +369,9 @@ func init() {
 
 func (n *Node) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
 	pb := n.Proto
-	var data []byte
-	switch v := pb.Config.GetConfigData().(type) {
-	case *tpb.Config_File:
-		var err error
-		data, err = os.ReadFile(filepath.Join(n.BasePath, v.File))
-		if err != nil {
-			return nil, err
-		}
-	case *tpb.Config_Data:
-		data = v.Data
+	data, err := n.ReadConfig()
+	if err != nil {
+		return nil, err
 	}
 	if data == nil {
 		return nil, nil
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode/utf8"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	log "k8s.io/klog/v2"
+)
+
+// defaultFileMode is the permission bits of mounted files without a mode.
+const defaultFileMode = 0644
+
+// readFileMount reads the files of the mount keyed by file name. For a file
+// source the name of the single file is also returned.
+func (n *Impl) readFileMount(fm *tpb.FileMount) (map[string][]byte, string, error) {
+	if fm.GetSource() == "" {
+		return nil, "", fmt.Errorf("source cannot be empty")
+	}
+	if !filepath.IsAbs(fm.GetDestination()) {
+		return nil, "", fmt.Errorf("destination %q must be an absolute path", fm.GetDestination())
+	}
+	p := fm.GetSource()
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(n.BasePath, p)
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, "", err
+	}
+	var paths []string
+	var file string
+	if fi.IsDir() {
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() {
+				paths = append(paths, filepath.Join(p, e.Name()))
+			}
+		}
+		if len(paths) == 0 {
+			return nil, "", fmt.Errorf("directory %q has no regular files", fm.GetSource())
+		}
+	} else {
+		paths = []string{p}
+		file = fi.Name()
+	}
+	files := map[string][]byte{}
+	size := 0
+	for _, p := range paths {
+		key := filepath.Base(p)
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, "", fmt.Errorf("invalid file name %q: %v", key, errs)
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, "", err
+		}
+		size += len(b)
+		files[key] = b
+	}
+	if size > maxObjectSize {
+		return nil, "", fmt.Errorf("size %d exceeds the maximum of %d bytes", size, maxObjectSize)
+	}
+	return files, file, nil
+}
+
+// createFileMount creates the ConfigMap or Secret holding the files of the
+// mount at index i and returns the volume and volume mount for it.
+func (n *Impl) createFileMount(ctx context.Context, i int, fm *tpb.FileMount) (*corev1.Volume, *corev1.VolumeMount, error) {
+	files, file, err := n.readFileMount(fm)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := fmt.Sprintf("%s-files-%d", n.Name(), i)
+	meta := metav1.ObjectMeta{
+		Name: name,
+		Labels: map[string]string{
+			"app":  n.Name(),
+			"topo": n.Namespace,
+		},
+	}
+	mode := int32(defaultFileMode)
+	if fm.GetMode() != 0 {
+		mode = int32(fm.GetMode() & 07777)
+	}
+	var keys []string
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var items []corev1.KeyToPath
+	for _, k := range keys {
+		items = append(items, corev1.KeyToPath{Key: k, Path: k})
+	}
+	vol := &corev1.Volume{Name: fmt.Sprintf("files-%d", i)}
+	if fm.GetSecret() {
+		s := &corev1.Secret{
+			ObjectMeta: meta,
+			Type:       corev1.SecretTypeOpaque,
+			Data:       files,
+		}
+		if err := n.createOrUpdateSecret(ctx, s); err != nil {
+			return nil, nil, err
+		}
+		vol.VolumeSource.Secret = &corev1.SecretVolumeSource{
+			SecretName:  name,
+			Items:       items,
+			DefaultMode: &mode,
+		}
+	} else {
+		cm := &corev1.ConfigMap{ObjectMeta: meta}
+		for k, b := range files {
+			if utf8.Valid(b) {
+				if cm.Data == nil {
+					cm.Data = map[string]string{}
+				}
+				cm.Data[k] = string(b)
+				continue
+			}
+			if cm.BinaryData == nil {
+				cm.BinaryData = map[string][]byte{}
+			}
+			cm.BinaryData[k] = b
+		}
+		if _, err := n.createOrUpdateConfigMap(ctx, cm); err != nil {
+			return nil, nil, err
+		}
+		vol.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Items:                items,
+			DefaultMode:          &mode,
+		}
+	}
+	vm := &corev1.VolumeMount{
+		Name:      vol.Name,
+		MountPath: fm.GetDestination(),
+		ReadOnly:  true,
+		SubPath:   file,
+	}
+	log.Infof("Created %d file(s) from %q for node %s", len(files), fm.GetSource(), n.Name())
+	return vol, vm, nil
+}
+
+// AddFiles creates the ConfigMaps and Secrets for the file mounts of the node
+// and adds them to all containers of the pod.
+func (n *Impl) AddFiles(ctx context.Context, pod *corev1.Pod) error {
+	for i, fm := range n.GetProto().GetConfig().GetFileMounts() {
+		vol, vm, err := n.createFileMount(ctx, i, fm)
+		if err != nil {
+			return fmt.Errorf("failed to mount %q: %w", fm.GetSource(), err)
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, *vol)
+		for j, c := range pod.Spec.Containers {
+			pod.Spec.Containers[j].VolumeMounts = append(c.VolumeMounts, *vm)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sonic"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	for name, data := range map[string]string{
+		"sonic/config_db.json": "{}",
+		"sonic/frr.conf":       "hostname r1\n",
+		"sonic/image.bin":      "\xff\xfe",
+		"init.sh":              "#!/bin/sh\n",
+		"bad name.sh":          "#!/bin/sh\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "empty"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	large := filepath.Join(wd, "testdata/large.cfg")
+	mode := func(m int32) *int32 { return &m }
+	labels := map[string]string{"app": "dev1", "topo": "test"}
+
+	tests := []struct {
+		desc       string
+		existing   []runtime.Object
+		mounts     []*topopb.FileMount
+		wantVols   []corev1.Volume
+		wantMounts []corev1.VolumeMount
+		wantCMs    []*corev1.ConfigMap
+		wantSecret *corev1.Secret
+		wantErr    string
+	}{{
+		desc: "no mounts",
+	}, {
+		desc: "directory",
+		mounts: []*topopb.FileMount{{
+			Source:      "sonic",
+			Destination: "/etc/sonic",
+		}},
+		wantVols: []corev1.Volume{{
+			Name: "files-0",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "dev1-files-0"},
+					Items: []corev1.KeyToPath{
+						{Key: "config_db.json", Path: "config_db.json"},
+						{Key: "frr.conf", Path: "frr.conf"},
+						{Key: "image.bin", Path: "image.bin"},
+					},
+					DefaultMode: mode(0644),
+				},
+			},
+		}},
+		wantMounts: []corev1.VolumeMount{{
+			Name:      "files-0",
+			MountPath: "/etc/sonic",
+			ReadOnly:  true,
+		}},
+		wantCMs: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-0", Namespace: "test", Labels: labels},
+			Data: map[string]string{
+				"config_db.json": "{}",
+				"frr.conf":       "hostname r1\n",
+			},
+			BinaryData: map[string][]byte{
+				"image.bin": []byte("\xff\xfe"),
+			},
+		}},
+	}, {
+		desc: "file and secret",
+		mounts: []*topopb.FileMount{{
+			Source:      "init.sh",
+			Destination: "/usr/local/bin/init.sh",
+			Mode:        0755,
+		}, {
+			Source:      "sonic/frr.conf",
+			Destination: "/etc/frr/frr.conf",
+			Secret:      true,
+		}},
+		wantVols: []corev1.Volume{{
+			Name: "files-0",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "dev1-files-0"},
+					Items:                []corev1.KeyToPath{{Key: "init.sh", Path: "init.sh"}},
+					DefaultMode:          mode(0755),
+				},
+			},
+		}, {
+			Name: "files-1",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  "dev1-files-1",
+					Items:       []corev1.KeyToPath{{Key: "frr.conf", Path: "frr.conf"}},
+					DefaultMode: mode(0644),
+				},
+			},
+		}},
+		wantMounts: []corev1.VolumeMount{{
+			Name:      "files-0",
+			MountPath: "/usr/local/bin/init.sh",
+			ReadOnly:  true,
+			SubPath:   "init.sh",
+		}, {
+			Name:      "files-1",
+			MountPath: "/etc/frr/frr.conf",
+			ReadOnly:  true,
+			SubPath:   "frr.conf",
+		}},
+		wantCMs: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-0", Namespace: "test", Labels: labels},
+			Data:       map[string]string{"init.sh": "#!/bin/sh\n"},
+		}},
+		wantSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-1", Namespace: "test", Labels: labels},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"frr.conf": []byte("hostname r1\n")},
+		},
+	}, {
+		desc: "existing",
+		existing: []runtime.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-0", Namespace: "test", Labels: labels},
+				Data:       map[string]string{"init.sh": "#!/bin/false\n"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-1", Namespace: "test", Labels: labels},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       map[string][]byte{"frr.conf": []byte("hostname old\n")},
+			},
+		},
+		mounts: []*topopb.FileMount{{
+			Source:      "init.sh",
+			Destination: "/usr/local/bin/init.sh",
+			Mode:        0755,
+		}, {
+			Source:      "sonic/frr.conf",
+			Destination: "/etc/frr/frr.conf",
+			Secret:      true,
+		}},
+		wantVols: []corev1.Volume{{
+			Name: "files-0",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "dev1-files-0"},
+					Items:                []corev1.KeyToPath{{Key: "init.sh", Path: "init.sh"}},
+					DefaultMode:          mode(0755),
+				},
+			},
+		}, {
+			Name: "files-1",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  "dev1-files-1",
+					Items:       []corev1.KeyToPath{{Key: "frr.conf", Path: "frr.conf"}},
+					DefaultMode: mode(0644),
+				},
+			},
+		}},
+		wantMounts: []corev1.VolumeMount{{
+			Name:      "files-0",
+			MountPath: "/usr/local/bin/init.sh",
+			ReadOnly:  true,
+			SubPath:   "init.sh",
+		}, {
+			Name:      "files-1",
+			MountPath: "/etc/frr/frr.conf",
+			ReadOnly:  true,
+			SubPath:   "frr.conf",
+		}},
+		wantCMs: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-0", Namespace: "test", Labels: labels},
+			Data:       map[string]string{"init.sh": "#!/bin/sh\n"},
+		}},
+		wantSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-files-1", Namespace: "test", Labels: labels},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"frr.conf": []byte("hostname r1\n")},
+		},
+	}, {
+		desc: "too large",
+		mounts: []*topopb.FileMount{{
+			Source:      large,
+			Destination: "/etc/large.cfg",
+		}},
+		wantErr: "exceeds the maximum",
+	}, {
+		desc: "source dne",
+		mounts: []*topopb.FileMount{{
+			Source:      "dne",
+			Destination: "/etc/dne",
+		}},
+		wantErr: "no such file",
+	}, {
+		desc: "empty directory",
+		mounts: []*topopb.FileMount{{
+			Source:      "empty",
+			Destination: "/etc/empty",
+		}},
+		wantErr: "has no regular files",
+	}, {
+		desc: "invalid file name",
+		mounts: []*topopb.FileMount{{
+			Source:      "bad name.sh",
+			Destination: "/bad.sh",
+		}},
+		wantErr: `invalid file name "bad name.sh"`,
+	}, {
+		desc: "relative destination",
+		mounts: []*topopb.FileMount{{
+			Source:      "init.sh",
+			Destination: "init.sh",
+		}},
+		wantErr: "must be an absolute path",
+	}, {
+		desc:    "missing source",
+		mounts:  []*topopb.FileMount{{Destination: "/init.sh"}},
+		wantErr: "source cannot be empty",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(tt.existing...),
+				BasePath:   dir,
+				Proto: &topopb.Node{
+					Name:   "dev1",
+					Config: &topopb.Config{FileMounts: tt.mounts},
+				},
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "dev1"}},
+				},
+			}
+			err := n.AddFiles(ctx, pod)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("AddFiles() failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.wantVols, pod.Spec.Volumes); s != "" {
+				t.Errorf("AddFiles() unexpected volumes diff (-want +got):\n%s", s)
+			}
+			if s := cmp.Diff(tt.wantMounts, pod.Spec.Containers[0].VolumeMounts); s != "" {
+				t.Errorf("AddFiles() unexpected volume mounts diff (-want +got):\n%s", s)
+			}
+			for _, want := range tt.wantCMs {
+				got, err := n.KubeClient.CoreV1().ConfigMaps("test").Get(ctx, want.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("AddFiles() did not create configmap %q: %v", want.Name, err)
+				}
+				if s := cmp.Diff(want, got); s != "" {
+					t.Errorf("AddFiles() unexpected configmap diff (-want +got):\n%s", s)
+				}
+			}
+			if want := tt.wantSecret; want != nil {
+				got, err := n.KubeClient.CoreV1().Secrets("test").Get(ctx, want.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("AddFiles() did not create secret %q: %v", want.Name, err)
+				}
+				if s := cmp.Diff(want, got); s != "" {
+					t.Errorf("AddFiles() unexpected secret diff (-want +got):\n%s", s)
+				}
+			}
+		})
+	}
+}
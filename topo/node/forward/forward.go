@@ -118,7 +118,7 @@ func wireToArg(wire *fpb.Wire) (string, error) {
 // CreatePod creates a Pod for the Node based on the underlying proto.
 func (n *Node) CreatePod(ctx context.Context) error {
 	pb := n.Proto
-	log.Infof("Creating Pod:\n %+v", pb)
+	log.Infof("Creating Pod:\n %+v", node.StripPassword(pb))
 	initContainerImage := pb.Config.InitImage
 	if initContainerImage == "" {
 		initContainerImage = node.DefaultInitContainerImage
@@ -213,6 +213,9 @@ func (n *Node) CreatePod(ctx context.Context) error {
 			pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
 		}
 	}
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
+	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return err
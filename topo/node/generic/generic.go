@@ -0,0 +1,321 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generic implements a data driven vendor for containerised NOSes.
+// The image, interface naming, readiness and config push of the node are
+// declared by a profile instead of code.
+package generic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	gpb "github.com/openconfig/kne/proto/generic"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	log "k8s.io/klog/v2"
+)
+
+var defaultCLICommand = []string{"sh"}
+
+func New(nodeImpl *node.Impl) (node.Node, error) {
+	if nodeImpl == nil {
+		return nil, fmt.Errorf("nodeImpl cannot be nil")
+	}
+	if nodeImpl.Proto == nil {
+		return nil, fmt.Errorf("nodeImpl.Proto cannot be nil")
+	}
+	p, err := loadProfile(nodeImpl.BasePath, nodeImpl.Proto)
+	if err != nil {
+		return nil, fmt.Errorf("node %q: %w", nodeImpl.Proto.GetName(), err)
+	}
+	renames, err := compileRenames(p.GetInterfaceRenames())
+	if err != nil {
+		return nil, fmt.Errorf("node %q: %w", nodeImpl.Proto.GetName(), err)
+	}
+	nodeImpl.Proto = defaults(nodeImpl.Proto, p)
+	if nodeImpl.Proto.GetConfig().GetImage() == "" {
+		return nil, fmt.Errorf("node %q: image must be set in the node config or profile", nodeImpl.Proto.GetName())
+	}
+	n := &Node{
+		Impl:    nodeImpl,
+		profile: p,
+	}
+	if n.Proto.Interfaces, err = renameInterfaces(renames, nodeImpl.Proto.Interfaces); err != nil {
+		return nil, fmt.Errorf("node %q: %w", nodeImpl.Proto.GetName(), err)
+	}
+	return n, nil
+}
+
+// loadProfile returns the profile of the node: the profile file of the
+// vendor data, overridden by the fields of the inline profile.
+func loadProfile(basePath string, pb *tpb.Node) (*gpb.Profile, error) {
+	cfg := &gpb.GenericConfig{}
+	if vd := pb.GetConfig().GetVendorData(); vd != nil {
+		if err := vd.UnmarshalTo(cfg); err != nil {
+			return nil, fmt.Errorf("invalid vendor data: %w", err)
+		}
+	}
+	p := &gpb.Profile{}
+	if f := cfg.GetProfileFile(); f != "" {
+		path := f
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(basePath, path)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profile: %w", err)
+		}
+		switch filepath.Ext(f) {
+		case ".yaml", ".yml", ".json":
+			jsonBytes, err := yaml.YAMLToJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse yaml profile %q: %w", f, err)
+			}
+			if err := protojson.Unmarshal(jsonBytes, p); err != nil {
+				return nil, fmt.Errorf("invalid profile %q: %w", f, err)
+			}
+		default:
+			if err := prototext.Unmarshal(b, p); err != nil {
+				return nil, fmt.Errorf("invalid profile %q: %w", f, err)
+			}
+		}
+	}
+	if inline := cfg.GetProfile(); inline != nil {
+		dst := p.ProtoReflect()
+		inline.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			dst.Set(fd, v)
+			return true
+		})
+	}
+	if err := validateProbe(p.GetReadinessProbe()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// InlineProfile returns the vendor data of the node with its profile file,
+// relative to basePath unless absolute, merged into the inline profile, so that the node can
+// be created where the file cannot be read, such as by the KNE operator.
+func InlineProfile(basePath string, pb *tpb.Node) (*anypb.Any, error) {
+	vd := pb.GetConfig().GetVendorData()
+	cfg := &gpb.GenericConfig{}
+	if vd != nil {
+		if err := vd.UnmarshalTo(cfg); err != nil {
+			return nil, fmt.Errorf("invalid vendor data: %w", err)
+		}
+	}
+	if cfg.GetProfileFile() == "" {
+		return vd, nil
+	}
+	p, err := loadProfile(basePath, pb)
+	if err != nil {
+		return nil, err
+	}
+	return anypb.New(&gpb.GenericConfig{Profile: p})
+}
+
+func validateProbe(p *gpb.Probe) error {
+	if p == nil {
+		return nil
+	}
+	set := 0
+	for _, ok := range []bool{len(p.GetExec()) > 0, p.GetTcpPort() != 0, p.GetHttpPort() != 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("readiness probe must set exactly one of exec, tcp_port or http_port")
+	}
+	return nil
+}
+
+func defaults(pb *tpb.Node, p *gpb.Profile) *tpb.Node {
+	if pb.Config == nil {
+		pb.Config = &tpb.Config{}
+	}
+	if pb.Config.Image == "" {
+		pb.Config.Image = p.GetImage()
+	}
+	if len(pb.Config.Command) == 0 {
+		pb.Config.Command = p.GetCommand()
+	}
+	if len(pb.Config.Args) == 0 {
+		pb.Config.Args = p.GetArgs()
+	}
+	for k, v := range p.GetEnv() {
+		if pb.Config.Env == nil {
+			pb.Config.Env = map[string]string{}
+		}
+		if _, ok := pb.Config.Env[k]; !ok {
+			pb.Config.Env[k] = v
+		}
+	}
+	if pb.Config.ConfigPath == "" {
+		pb.Config.ConfigPath = p.GetConfigPath()
+	}
+	if pb.Config.ConfigFile == "" {
+		pb.Config.ConfigFile = p.GetConfigFile()
+	}
+	if pb.Config.EntryCommand == "" {
+		pb.Config.EntryCommand = fmt.Sprintf("kubectl exec -it %s -- %s", pb.Name, strings.Join(cliCommand(p), " "))
+	}
+	if pb.Constraints == nil {
+		pb.Constraints = p.GetConstraints()
+	}
+	if pb.Services == nil {
+		pb.Services = p.GetServices()
+	}
+	if pb.Os == "" {
+		pb.Os = p.GetOs()
+	}
+	if pb.Model == "" {
+		pb.Model = p.GetModel()
+	}
+	return pb
+}
+
+func cliCommand(p *gpb.Profile) []string {
+	if len(p.GetCliCommand()) == 0 {
+		return defaultCLICommand
+	}
+	return p.GetCliCommand()
+}
+
+type Node struct {
+	*node.Impl
+	profile *gpb.Profile
+}
+
+var (
+	_ node.CLIer        = (*Node)(nil)
+	_ node.ConfigPusher = (*Node)(nil)
+	_ node.Resetter     = (*Node)(nil)
+)
+
+func (n *Node) Create(ctx context.Context) error {
+	if err := n.ValidateConstraints(); err != nil {
+		return fmt.Errorf("node %s failed to validate node with errors: %s", n.Name(), err)
+	}
+	if err := n.CreatePod(ctx); err != nil {
+		return fmt.Errorf("node %s failed to create pod %w", n.Name(), err)
+	}
+	if err := n.CreateService(ctx); err != nil {
+		return fmt.Errorf("node %s failed to create service %w", n.Name(), err)
+	}
+	return nil
+}
+
+// CreatePod creates a Pod for the Node based on the underlying proto, with
+// the readiness probe of the profile.
+func (n *Node) CreatePod(ctx context.Context) error {
+	pod, err := n.NewPod(ctx)
+	if err != nil {
+		return err
+	}
+	pod.Spec.Containers[0].ReadinessProbe = readinessProbe(n.profile.GetReadinessProbe())
+	return n.SubmitPod(ctx, pod)
+}
+
+func readinessProbe(p *gpb.Probe) *corev1.Probe {
+	if p == nil {
+		return nil
+	}
+	probe := &corev1.Probe{
+		InitialDelaySeconds: int32(p.GetInitialDelaySeconds()),
+		PeriodSeconds:       int32(p.GetPeriodSeconds()),
+		FailureThreshold:    int32(p.GetFailureThreshold()),
+	}
+	switch {
+	case len(p.GetExec()) > 0:
+		probe.Exec = &corev1.ExecAction{Command: p.GetExec()}
+	case p.GetTcpPort() != 0:
+		probe.TCPSocket = &corev1.TCPSocketAction{Port: intstr.FromInt(int(p.GetTcpPort()))}
+	default:
+		probe.HTTPGet = &corev1.HTTPGetAction{Path: p.GetHttpPath(), Port: intstr.FromInt(int(p.GetHttpPort()))}
+	}
+	return probe
+}
+
+func (n *Node) DefaultNodeConstraints() node.Constraints {
+	return node.Constraints{
+		CPU:    n.profile.GetConstraints()["cpu"],
+		Memory: n.profile.GetConstraints()["memory"],
+	}
+}
+
+func (n *Node) CLICommand() []string {
+	return cliCommand(n.profile)
+}
+
+// run runs cmd in the node container with stdin.
+func (n *Node) run(ctx context.Context, cmd []string, stdin io.Reader) error {
+	var stdout, stderr bytes.Buffer
+	if err := n.Exec(ctx, cmd, stdin, &stdout, &stderr); err != nil {
+		return fmt.Errorf("%q failed: %w: %s", strings.Join(cmd, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	log.V(1).Infof("%s - %q output: %s", n.Name(), strings.Join(cmd, " "), stdout.String())
+	return nil
+}
+
+// ConfigPush pushes config with the config push command of the profile.
+func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
+	cmd := n.profile.GetConfigPushCommand()
+	if len(cmd) == 0 {
+		return status.Errorf(codes.Unimplemented, "profile of node %q has no config_push_command", n.Name())
+	}
+	log.Infof("%s - pushing config", n.Name())
+	return n.run(ctx, cmd, r)
+}
+
+// ResetCfg resets the config with the reset command of the profile, or by
+// pushing the startup config if the profile has none.
+func (n *Node) ResetCfg(ctx context.Context) error {
+	if cmd := n.profile.GetResetCommand(); len(cmd) > 0 {
+		log.Infof("%s - resetting config", n.Name())
+		return n.run(ctx, cmd, nil)
+	}
+	if len(n.profile.GetConfigPushCommand()) == 0 {
+		return status.Errorf(codes.Unimplemented, "profile of node %q has no reset_command or config_push_command", n.Name())
+	}
+	cfg, err := n.ReadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		log.Infof("%s - no startup config, ResetCfg is a noop", n.Name())
+		return nil
+	}
+	log.Infof("%s - resetting config", n.Name())
+	return n.run(ctx, n.profile.GetConfigPushCommand(), bytes.NewReader(cfg))
+}
+
+func init() {
+	node.Vendor(tpb.Vendor_GENERIC, New)
+}
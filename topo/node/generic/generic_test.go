@@ -0,0 +1,288 @@
+package generic
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	gpb "github.com/openconfig/kne/proto/generic"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func vendorData(t *testing.T, cfg *gpb.GenericConfig) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal vendor data: %v", err)
+	}
+	return a
+}
+
+func TestRenameInterfaces(t *testing.T) {
+	tests := []struct {
+		desc    string
+		rules   []*gpb.InterfaceRename
+		in      []string
+		want    []string
+		wantErr string
+	}{{
+		desc: "no rules",
+		in:   []string{"Ethernet0", "eth1"},
+		want: []string{"Ethernet0", "eth1"},
+	}, {
+		desc:  "template",
+		rules: []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "Ethernet{n}"}, Name: "eth{n/4+1}"}},
+		in:    []string{"Ethernet0", "Ethernet4", "Ethernet8", "mgmt"},
+		want:  []string{"eth1", "eth2", "eth3", "mgmt"},
+	}, {
+		desc:  "reverse template",
+		rules: []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "e{n}"}, Name: "Ethernet{4*(n-1)}"}},
+		in:    []string{"e1", "e2"},
+		want:  []string{"Ethernet0", "Ethernet4"},
+	}, {
+		desc:  "multiple captures",
+		rules: []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "{slot}/{card}/{port}"}, Name: "eth{(slot-1)*10+port}"}},
+		in:    []string{"1/1/1", "2/1/3"},
+		want:  []string{"eth1", "eth13"},
+	}, {
+		desc: "first matching rule",
+		rules: []*gpb.InterfaceRename{
+			{Match: &gpb.InterfaceRename_Regex{Regex: `^Ethernet(?P<n>\d+)$`}, Name: "eth{n}"},
+			{Match: &gpb.InterfaceRename_Regex{Regex: `^Ethernet`}, Name: "other"},
+		},
+		in:   []string{"Ethernet1"},
+		want: []string{"eth1"},
+	}, {
+		desc:    "collision",
+		rules:   []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "Ethernet{n}"}, Name: "eth{n/4+1}"}},
+		in:      []string{"Ethernet0", "Ethernet1"},
+		wantErr: `interfaces "Ethernet0" and "Ethernet1" are both renamed to "eth1"`,
+	}, {
+		desc:    "division by zero",
+		rules:   []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "e{n}"}, Name: "eth{4/n}"}},
+		in:      []string{"e0"},
+		wantErr: "division by zero",
+	}, {
+		desc:    "unknown variable",
+		rules:   []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "e{n}"}, Name: "eth{m}"}},
+		in:      []string{"e0"},
+		wantErr: `unknown variable "m"`,
+	}, {
+		desc:    "no match",
+		rules:   []*gpb.InterfaceRename{{Name: "eth{n}"}},
+		wantErr: "has no template or regex",
+	}, {
+		desc:    "no name",
+		rules:   []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Template{Template: "e{n}"}}},
+		wantErr: "has no name",
+	}, {
+		desc:    "invalid regex",
+		rules:   []*gpb.InterfaceRename{{Match: &gpb.InterfaceRename_Regex{Regex: "("}, Name: "eth1"}},
+		wantErr: "invalid interface rename",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in := map[string]*tpb.Interface{}
+			for _, k := range tt.in {
+				in[k] = &tpb.Interface{Name: k}
+			}
+			var got map[string]*tpb.Interface
+			rules, err := compileRenames(tt.rules)
+			if err == nil {
+				got, err = renameInterfaces(rules, in)
+			}
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("renameInterfaces() unexpected error: %s", s)
+			}
+			if err != nil {
+				return
+			}
+			want := map[string]*tpb.Interface{}
+			for i, k := range tt.want {
+				want[k] = &tpb.Interface{Name: tt.in[i]}
+			}
+			if s := cmp.Diff(want, got, protocmp.Transform()); s != "" {
+				t.Errorf("renameInterfaces() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	absLinux, err := filepath.Abs("testdata/linux.yaml")
+	if err != nil {
+		t.Fatalf("failed to get absolute path: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		cfg     *gpb.GenericConfig
+		node    *tpb.Node
+		want    *tpb.Node
+		wantErr string
+	}{{
+		desc: "profile file",
+		cfg:  &gpb.GenericConfig{ProfileFile: "sonic.pb.txt"},
+		node: &tpb.Node{
+			Name:       "r1",
+			Interfaces: map[string]*tpb.Interface{"Ethernet4": {PeerName: "r2"}},
+		},
+		want: &tpb.Node{
+			Name: "r1",
+			Config: &tpb.Config{
+				Image:        "sonic-vs:latest",
+				Command:      []string{"/usr/local/bin/supervisord"},
+				ConfigPath:   "/etc/sonic",
+				ConfigFile:   "config_db.json",
+				EntryCommand: "kubectl exec -it r1 -- vtysh",
+			},
+			Constraints: map[string]string{"cpu": "500m", "memory": "1Gi"},
+			Services:    map[uint32]*tpb.Service{22: {Name: "ssh", Inside: 22}},
+			Os:          "sonic",
+			Interfaces:  map[string]*tpb.Interface{"eth2": {PeerName: "r2"}},
+		},
+	}, {
+		desc: "node config overrides profile",
+		cfg:  &gpb.GenericConfig{ProfileFile: "linux.yaml"},
+		node: &tpb.Node{
+			Name:       "r1",
+			Config:     &tpb.Config{Image: "ubuntu:latest", Env: map[string]string{"A": "1"}},
+			Interfaces: map[string]*tpb.Interface{"port3": {}},
+		},
+		want: &tpb.Node{
+			Name: "r1",
+			Config: &tpb.Config{
+				Image:        "ubuntu:latest",
+				Command:      []string{"/bin/sh", "-c", "sleep infinity"},
+				Env:          map[string]string{"A": "1"},
+				EntryCommand: "kubectl exec -it r1 -- sh",
+			},
+			Interfaces: map[string]*tpb.Interface{"eth3": {}},
+		},
+	}, {
+		desc: "absolute profile file",
+		cfg:  &gpb.GenericConfig{ProfileFile: absLinux},
+		node: &tpb.Node{Name: "r1"},
+		want: &tpb.Node{
+			Name: "r1",
+			Config: &tpb.Config{
+				Image:        "alpine:latest",
+				Command:      []string{"/bin/sh", "-c", "sleep infinity"},
+				EntryCommand: "kubectl exec -it r1 -- sh",
+			},
+		},
+	}, {
+		desc: "inline profile overrides profile file",
+		cfg: &gpb.GenericConfig{
+			ProfileFile: "sonic.pb.txt",
+			Profile: &gpb.Profile{
+				Image:   "sonic-vs:dev",
+				Env:     map[string]string{"B": "2"},
+				Command: []string{"/bin/bash"},
+			},
+		},
+		node: &tpb.Node{Name: "r1"},
+		want: &tpb.Node{
+			Name: "r1",
+			Config: &tpb.Config{
+				Image:        "sonic-vs:dev",
+				Command:      []string{"/bin/bash"},
+				Env:          map[string]string{"B": "2"},
+				ConfigPath:   "/etc/sonic",
+				ConfigFile:   "config_db.json",
+				EntryCommand: "kubectl exec -it r1 -- vtysh",
+			},
+			Constraints: map[string]string{"cpu": "500m", "memory": "1Gi"},
+			Services:    map[uint32]*tpb.Service{22: {Name: "ssh", Inside: 22}},
+			Os:          "sonic",
+		},
+	}, {
+		desc:    "no image",
+		node:    &tpb.Node{Name: "r1"},
+		wantErr: "image must be set",
+	}, {
+		desc:    "missing profile",
+		cfg:     &gpb.GenericConfig{ProfileFile: "missing.pb.txt"},
+		node:    &tpb.Node{Name: "r1"},
+		wantErr: "failed to read profile",
+	}, {
+		desc:    "invalid profile",
+		cfg:     &gpb.GenericConfig{ProfileFile: "invalid.pb.txt"},
+		node:    &tpb.Node{Name: "r1"},
+		wantErr: "invalid profile",
+	}, {
+		desc: "invalid probe",
+		cfg: &gpb.GenericConfig{Profile: &gpb.Profile{
+			Image:          "alpine:latest",
+			ReadinessProbe: &gpb.Probe{TcpPort: 22, HttpPort: 80},
+		}},
+		node:    &tpb.Node{Name: "r1"},
+		wantErr: "exactly one of exec, tcp_port or http_port",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.cfg != nil {
+				if tt.node.Config == nil {
+					tt.node.Config = &tpb.Config{}
+				}
+				tt.node.Config.VendorData = vendorData(t, tt.cfg)
+			}
+			n, err := New(&node.Impl{Proto: tt.node, BasePath: "testdata"})
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("New() unexpected error: %s", s)
+			}
+			if err != nil {
+				return
+			}
+			got := proto.Clone(n.GetProto()).(*tpb.Node)
+			got.Config.VendorData = nil
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("New() unexpected node (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestNode(t *testing.T) {
+	ctx := context.Background()
+	kClient := kfake.NewSimpleClientset()
+	pb := &tpb.Node{
+		Name:   "r1",
+		Config: &tpb.Config{VendorData: vendorData(t, &gpb.GenericConfig{ProfileFile: "linux.yaml"})},
+	}
+	n, err := New(&node.Impl{Namespace: "test", KubeClient: kClient, Proto: pb, BasePath: "testdata"})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	if err := n.Create(ctx); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	pod, err := kClient.CoreV1().Pods("test").Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	want := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(22)}}}
+	if s := cmp.Diff(want, pod.Spec.Containers[0].ReadinessProbe); s != "" {
+		t.Errorf("Create() unexpected readiness probe (-want +got):\n%s", s)
+	}
+	if s := cmp.Diff([]string{"sh"}, n.(node.CLIer).CLICommand()); s != "" {
+		t.Errorf("CLICommand() unexpected diff (-want +got):\n%s", s)
+	}
+	if err := n.(node.ConfigPusher).ConfigPush(ctx, nil); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ConfigPush() got error %v, want code %s", err, codes.Unimplemented)
+	}
+	if err := n.(node.Resetter).ResetCfg(ctx); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ResetCfg() got error %v, want code %s", err, codes.Unimplemented)
+	}
+}
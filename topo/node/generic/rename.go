@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package generic
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	gpb "github.com/openconfig/kne/proto/generic"
+	tpb "github.com/openconfig/kne/proto/topo"
+)
+
+var braceRE = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// renameRule is a compiled InterfaceRename.
+type renameRule struct {
+	re   *regexp.Regexp
+	name string
+}
+
+// templateRegexp returns the regular expression of a template such as
+// "Ethernet{n}", in which {x} matches a decimal number captured as x.
+func templateRegexp(tmpl string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, m := range braceRE.FindAllStringSubmatchIndex(tmpl, -1) {
+		b.WriteString(regexp.QuoteMeta(tmpl[last:m[0]]))
+		fmt.Fprintf(&b, `(?P<%s>\d+)`, tmpl[m[2]:m[3]])
+		last = m[1]
+	}
+	b.WriteString(regexp.QuoteMeta(tmpl[last:]))
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func compileRenames(rules []*gpb.InterfaceRename) ([]*renameRule, error) {
+	var compiled []*renameRule
+	for _, r := range rules {
+		var re *regexp.Regexp
+		var err error
+		switch m := r.GetMatch().(type) {
+		case *gpb.InterfaceRename_Template:
+			re, err = templateRegexp(m.Template)
+		case *gpb.InterfaceRename_Regex:
+			re, err = regexp.Compile(m.Regex)
+		default:
+			return nil, fmt.Errorf("interface rename to %q has no template or regex", r.GetName())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid interface rename: %w", err)
+		}
+		if r.GetName() == "" {
+			return nil, fmt.Errorf("interface rename %q has no name", re)
+		}
+		compiled = append(compiled, &renameRule{re: re, name: r.GetName()})
+	}
+	return compiled, nil
+}
+
+// rename returns the name of the interface named intf in the topology, and
+// whether the rule matched it.
+func (r *renameRule) rename(intf string) (string, bool, error) {
+	m := r.re.FindStringSubmatch(intf)
+	if m == nil {
+		return "", false, nil
+	}
+	vars := map[string]int{}
+	for i, name := range r.re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		v, err := strconv.Atoi(m[i])
+		if err != nil {
+			return "", false, fmt.Errorf("interface %q: group %q is not a number: %q", intf, name, m[i])
+		}
+		vars[name] = v
+	}
+	var evalErr error
+	name := braceRE.ReplaceAllStringFunc(r.name, func(s string) string {
+		v, err := eval(s[1:len(s)-1], vars)
+		if err != nil && evalErr == nil {
+			evalErr = fmt.Errorf("interface %q: invalid expression %s: %w", intf, s, err)
+		}
+		return strconv.Itoa(v)
+	})
+	if evalErr != nil {
+		return "", false, evalErr
+	}
+	return name, true, nil
+}
+
+// renameInterfaces renames the interfaces of the topology with the first
+// matching rule.
+func renameInterfaces(rules []*renameRule, in map[string]*tpb.Interface) (map[string]*tpb.Interface, error) {
+	if len(rules) == 0 {
+		return in, nil
+	}
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	intf := map[string]*tpb.Interface{}
+	from := map[string]string{}
+	for _, k := range keys {
+		name := k
+		for _, r := range rules {
+			n, ok, err := r.rename(k)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				name = n
+				break
+			}
+		}
+		if prev, ok := from[name]; ok {
+			return nil, fmt.Errorf("interfaces %q and %q are both renamed to %q", prev, k, name)
+		}
+		from[name] = k
+		intf[name] = in[k]
+	}
+	return intf, nil
+}
+
+// eval evaluates the integer expression expr with the variables vars.
+func eval(expr string, vars map[string]int) (int, error) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return 0, err
+	}
+	return evalNode(e, vars)
+}
+
+func evalNode(e ast.Expr, vars map[string]int) (int, error) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return 0, fmt.Errorf("unsupported literal %s", e.Value)
+		}
+		return strconv.Atoi(e.Value)
+	case *ast.Ident:
+		v, ok := vars[e.Name]
+		if !ok {
+			return 0, fmt.Errorf("unknown variable %q", e.Name)
+		}
+		return v, nil
+	case *ast.ParenExpr:
+		return evalNode(e.X, vars)
+	case *ast.UnaryExpr:
+		x, err := evalNode(e.X, vars)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x, nil
+		case token.SUB:
+			return -x, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s", e.Op)
+	case *ast.BinaryExpr:
+		x, err := evalNode(e.X, vars)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evalNode(e.Y, vars)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO, token.REM:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			if e.Op == token.QUO {
+				return x / y, nil
+			}
+			return x % y, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s", e.Op)
+	}
+	return 0, fmt.Errorf("unsupported expression")
+}
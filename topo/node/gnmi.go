@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+)
+
+// defaultGNMIService is the name of the service used to connect to the gNMI
+// server of nodes.
+const defaultGNMIService = "gnmi"
+
+// dialGNMI returns a gNMI client connected to addr and a function closing the
+// connection. It can be set to a fake for unit testing.
+var dialGNMI = func(addr string, opts ...grpc.DialOption) (gpb.GNMIClient, func() error, error) {
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gpb.NewGNMIClient(conn), conn.Close, nil
+}
+
+// GNMIAddress returns the external address of the gNMI service of the node.
+func (n *Impl) GNMIAddress(ctx context.Context) (string, error) {
+	name := n.GetProto().GetConfig().GetGnmi().GetService()
+	if name == "" {
+		name = defaultGNMIService
+	}
+	return n.serviceAddress(ctx, name)
+}
+
+// servicePort returns the lowest outside port of the services of the node
+// named name, or 0 if the node has no such service.
+func (n *Impl) servicePort(name string) uint32 {
+	var port uint32
+	for k, s := range n.GetProto().GetServices() {
+		names := append([]string{s.GetName()}, s.GetNames()...)
+		for _, sn := range names {
+			if sn == name && (port == 0 || k < port) {
+				port = k
+			}
+		}
+	}
+	return port
+}
+
+// serviceAddress returns the external address of the service of the node
+// named name.
+func (n *Impl) serviceAddress(ctx context.Context, name string) (string, error) {
+	port := n.servicePort(name)
+	if port == 0 {
+		return "", fmt.Errorf("node %q has no %q service", n.Name(), name)
+	}
+	services, err := n.Services(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range services {
+		for _, in := range s.Status.LoadBalancer.Ingress {
+			if in.IP != "" {
+				return net.JoinHostPort(in.IP, strconv.Itoa(int(port))), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("service %q of node %q has no external IP", name, n.Name())
+}
+
+// grpcTransport returns the transport credentials of the connection to the
+// gRPC servers of the node. Servers with a cert issued by the topology CA are
+// verified with the CA bundle, servers with a self-signed cert are not
+// verified and servers without cert config are dialed without TLS.
+func (n *Impl) grpcTransport(ctx context.Context) (credentials.TransportCredentials, error) {
+	cert := n.GetProto().GetConfig().GetCert()
+	switch {
+	case cert.GetCaIssued() != nil:
+		s, err := n.KubeClient.CoreV1().Secrets(n.Namespace).Get(ctx, CABundleSecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(s.Data[CABundleKey]) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %q", CABundleSecretName)
+		}
+		return credentials.NewTLS(&tls.Config{RootCAs: pool}), nil
+	case cert != nil:
+		return credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}), nil //nolint:gosec
+	default:
+		return insecure.NewCredentials(), nil
+	}
+}
+
+// grpcOptions returns the dial options of the gRPC servers of the node and ctx
+// with the credentials of the node added to the outgoing metadata.
+func (n *Impl) grpcOptions(ctx context.Context) (context.Context, []grpc.DialOption, error) {
+	tc, err := n.grpcTransport(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx, err = n.withCredentials(ctx); err != nil {
+		return nil, nil, err
+	}
+	return ctx, []grpc.DialOption{grpc.WithTransportCredentials(tc)}, nil
+}
+
+// withCredentials returns ctx with the credentials of the node added to the
+// outgoing metadata.
+func (n *Impl) withCredentials(ctx context.Context) (context.Context, error) {
+	creds, err := n.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", creds.GetUsername(), "password", creds.GetPassword())
+	}
+	return ctx, nil
+}
+
+// GNMISet sets the JSON encoded config at the path of the gNMI options of the
+// node, replacing the current config unless update is set.
+func (n *Impl) GNMISet(ctx context.Context, cfg []byte, update bool) error {
+	if !json.Valid(cfg) {
+		return fmt.Errorf("config of node %q is not valid JSON", n.Name())
+	}
+	opts := n.GetProto().GetConfig().GetGnmi()
+	path, err := ygot.StringToStructuredPath(opts.GetPath())
+	if err != nil {
+		return fmt.Errorf("invalid gNMI path %q: %w", opts.GetPath(), err)
+	}
+	val := &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: cfg}}
+	if opts.GetEncoding() == tpb.GNMIOptions_JSON {
+		val = &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: cfg}}
+	}
+	req := &gpb.SetRequest{}
+	if update {
+		req.Update = []*gpb.Update{{Path: path, Val: val}}
+	} else {
+		req.Replace = []*gpb.Update{{Path: path, Val: val}}
+	}
+	addr, err := n.GNMIAddress(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, dialOpts, err := n.grpcOptions(ctx)
+	if err != nil {
+		return err
+	}
+	c, closeFn, err := dialGNMI(addr, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to dial gNMI server of node %q: %w", n.Name(), err)
+	}
+	defer closeFn()
+	log.Infof("%s - setting config with gNMI at %s", n.Name(), addr)
+	if _, err := c.Set(ctx, req); err != nil {
+		return fmt.Errorf("gNMI set on node %q failed: %w", n.Name(), err)
+	}
+	return nil
+}
+
+// GNMIConfigPush pushes the JSON encoded config in r to the node with a gNMI
+// Set request. Vendors configured through gNMI can implement ConfigPusher with
+// it.
+func (n *Impl) GNMIConfigPush(ctx context.Context, r io.Reader) error {
+	cfg, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	log.Infof("%s - pushing config", n.Name())
+	if err := n.GNMISet(ctx, cfg, n.GetProto().GetConfig().GetGnmi().GetUpdate()); err != nil {
+		return err
+	}
+	log.Infof("%s - finished config push", n.Name())
+	return nil
+}
+
+// GNMIResetCfg replaces the config of the node with its startup config with a
+// gNMI Set request. It is a noop if the node has no startup config. Vendors
+// configured through gNMI can implement Resetter with it.
+func (n *Impl) GNMIResetCfg(ctx context.Context) error {
+	cfg, err := n.ReadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		log.Infof("%s - no startup config, ResetCfg is a noop", n.Name())
+		return nil
+	}
+	log.Infof("%s - resetting config", n.Name())
+	if err := n.GNMISet(ctx, cfg, false); err != nil {
+		return err
+	}
+	log.Infof("%s - finished resetting config", n.Name())
+	return nil
+}
@@ -0,0 +1,232 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	topopb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeGNMIClient struct {
+	gpb.GNMIClient
+	setErr error
+	addr   string
+	req    *gpb.SetRequest
+	md     metadata.MD
+}
+
+func (f *fakeGNMIClient) Set(ctx context.Context, req *gpb.SetRequest, _ ...grpc.CallOption) (*gpb.SetResponse, error) {
+	f.req = req
+	f.md, _ = metadata.FromOutgoingContext(ctx)
+	return &gpb.SetResponse{}, f.setErr
+}
+
+func TestGNMIConfigPush(t *testing.T) {
+	origDialGNMI := dialGNMI
+	defer func() {
+		dialGNMI = origDialGNMI
+	}()
+	services := map[uint32]*topopb.Service{
+		9339: {Name: "gnmi", Inside: 9339},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-dev1", Namespace: "test"},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "192.168.18.100"}},
+			},
+		},
+	}
+	cfg := `{"openconfig-system:system":{"config":{"hostname":"dev1"}}}`
+	tests := []struct {
+		desc     string
+		node     *topopb.Node
+		cfg      string
+		noIP     bool
+		setErr   error
+		wantAddr string
+		wantReq  *gpb.SetRequest
+		wantMD   metadata.MD
+		wantErr  string
+	}{{
+		desc:     "replace",
+		node:     &topopb.Node{Name: "dev1", Services: services},
+		cfg:      cfg,
+		wantAddr: "192.168.18.100:9339",
+		wantReq: &gpb.SetRequest{
+			Replace: []*gpb.Update{{
+				Path: &gpb.Path{},
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(cfg)}},
+			}},
+		},
+	}, {
+		desc: "update with options and credentials",
+		node: &topopb.Node{
+			Name: "dev1",
+			Config: &topopb.Config{
+				Gnmi: &topopb.GNMIOptions{
+					Encoding: topopb.GNMIOptions_JSON,
+					Update:   true,
+					Path:     "/system/config",
+					Service:  "grpc",
+				},
+			},
+			Services: map[uint32]*topopb.Service{
+				9339:  {Name: "gnmi", Inside: 9339},
+				50051: {Name: "grpc", Names: []string{"gnmi-alt"}, Inside: 57400},
+			},
+			Credentials: &topopb.Credentials{Username: "admin", Password: "admin"},
+		},
+		cfg:      `{"hostname":"dev1"}`,
+		wantAddr: "192.168.18.100:50051",
+		wantReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}, {Name: "config"}}},
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(`{"hostname":"dev1"}`)}},
+			}},
+		},
+		wantMD: metadata.Pairs("username", "admin", "password", "admin"),
+	}, {
+		desc:    "invalid json",
+		node:    &topopb.Node{Name: "dev1", Services: services},
+		cfg:     "hostname dev1",
+		wantErr: "not valid JSON",
+	}, {
+		desc: "invalid path",
+		node: &topopb.Node{
+			Name:     "dev1",
+			Config:   &topopb.Config{Gnmi: &topopb.GNMIOptions{Path: "/system/config]"}},
+			Services: services,
+		},
+		cfg:     cfg,
+		wantErr: "invalid gNMI path",
+	}, {
+		desc:    "no gnmi service",
+		node:    &topopb.Node{Name: "dev1", Services: map[uint32]*topopb.Service{22: {Name: "ssh", Inside: 22}}},
+		cfg:     cfg,
+		wantErr: `node "dev1" has no "gnmi" service`,
+	}, {
+		desc:    "no external ip",
+		node:    &topopb.Node{Name: "dev1", Services: services},
+		cfg:     cfg,
+		noIP:    true,
+		wantErr: "has no external IP",
+	}, {
+		desc: "no ca bundle",
+		node: &topopb.Node{
+			Name: "dev1",
+			Config: &topopb.Config{
+				Cert: &topopb.CertificateCfg{
+					Config: &topopb.CertificateCfg_CaIssued{CaIssued: &topopb.CAIssuedCertCfg{}},
+				},
+			},
+			Services: services,
+		},
+		cfg:     cfg,
+		wantErr: "failed to get CA bundle",
+	}, {
+		desc:    "set error",
+		node:    &topopb.Node{Name: "dev1", Services: services},
+		cfg:     cfg,
+		setErr:  fmt.Errorf("permission denied"),
+		wantErr: "permission denied",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := service.DeepCopy()
+			if tt.noIP {
+				s.Status = corev1.ServiceStatus{}
+			}
+			f := &fakeGNMIClient{setErr: tt.setErr}
+			dialGNMI = func(addr string, _ ...grpc.DialOption) (gpb.GNMIClient, func() error, error) {
+				f.addr = addr
+				return f, func() error { return nil }, nil
+			}
+			n := &Impl{Namespace: "test", KubeClient: kfake.NewSimpleClientset(s), Proto: tt.node}
+			err := n.GNMIConfigPush(context.Background(), strings.NewReader(tt.cfg))
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("GNMIConfigPush() unexpected error: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if f.addr != tt.wantAddr {
+				t.Errorf("GNMIConfigPush() dialed %q, want %q", f.addr, tt.wantAddr)
+			}
+			if s := cmp.Diff(tt.wantReq, f.req, protocmp.Transform()); s != "" {
+				t.Errorf("GNMIConfigPush() unexpected SetRequest (-want +got):\n%s", s)
+			}
+			if s := cmp.Diff(tt.wantMD, f.md); s != "" {
+				t.Errorf("GNMIConfigPush() unexpected metadata (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestGNMIResetCfg(t *testing.T) {
+	origDialGNMI := dialGNMI
+	defer func() {
+		dialGNMI = origDialGNMI
+	}()
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-dev1", Namespace: "test"},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "192.168.18.100"}},
+			},
+		},
+	}
+	tests := []struct {
+		desc    string
+		config  *topopb.Config
+		wantReq *gpb.SetRequest
+	}{{
+		desc: "no startup config",
+	}, {
+		desc: "startup config",
+		config: &topopb.Config{
+			ConfigData: &topopb.Config_Data{Data: []byte(`{"hostname":"dev1"}`)},
+			Gnmi:       &topopb.GNMIOptions{Update: true},
+		},
+		wantReq: &gpb.SetRequest{
+			Replace: []*gpb.Update{{
+				Path: &gpb.Path{},
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"hostname":"dev1"}`)}},
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &fakeGNMIClient{}
+			dialGNMI = func(string, ...grpc.DialOption) (gpb.GNMIClient, func() error, error) {
+				return f, func() error { return nil }, nil
+			}
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(service),
+				Proto: &topopb.Node{
+					Name:     "dev1",
+					Config:   tt.config,
+					Services: map[uint32]*topopb.Service{9339: {Name: "gnmi", Inside: 9339}},
+				},
+			}
+			if err := n.GNMIResetCfg(context.Background()); err != nil {
+				t.Fatalf("GNMIResetCfg() unexpected error: %v", err)
+			}
+			if s := cmp.Diff(tt.wantReq, f.req, protocmp.Transform()); s != "" {
+				t.Errorf("GNMIResetCfg() unexpected SetRequest (-want +got):\n%s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	certpb "github.com/openconfig/gnoi/cert"
+	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+	gnoitypes "github.com/openconfig/gnoi/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+// Operation is an operational command run on nodes by an Operator.
+type Operation string
+
+const (
+	OperationReboot      Operation = "reboot"
+	OperationFilePut     Operation = "file-put"
+	OperationFileGet     Operation = "file-get"
+	OperationCertInstall Operation = "cert-install"
+	OperationPing        Operation = "ping"
+)
+
+// AllOperations are the operations implemented by the default gNOI Operator.
+var AllOperations = []Operation{
+	OperationReboot,
+	OperationFilePut,
+	OperationFileGet,
+	OperationCertInstall,
+	OperationPing,
+}
+
+// RebootOptions are the options of a node reboot.
+type RebootOptions struct {
+	// Warm reboots only the software of the node instead of a cold reboot.
+	Warm bool
+	// Delay is the time to wait before rebooting.
+	Delay time.Duration
+	// Message is the reason of the reboot.
+	Message string
+	// Force reboots the node even if validation checks fail.
+	Force bool
+}
+
+// PingOptions are the options of a ping run from a node.
+type PingOptions struct {
+	Destination     string
+	Source          string
+	Count           int32
+	Interval        time.Duration
+	Size            int32
+	NetworkInstance string
+}
+
+// PingReply is a single reply to a ping.
+type PingReply struct {
+	Source   string
+	Sequence int32
+	Bytes    int32
+	TTL      int32
+	Time     time.Duration
+}
+
+// PingResult is the result of a ping run from a node.
+type PingResult struct {
+	Replies  []*PingReply
+	Sent     int32
+	Received int32
+	MinTime  time.Duration
+	AvgTime  time.Duration
+	MaxTime  time.Duration
+	StdDev   time.Duration
+}
+
+// gnoiService is the name of the service used to connect to the gNOI server of
+// nodes.
+const gnoiService = "gnoi"
+
+// fileChunkSize is the size of the chunks of files sent with gNOI File.Put.
+const fileChunkSize = 64 * 1024
+
+type gnoiClients struct {
+	system spb.SystemClient
+	file   fpb.FileClient
+	cert   certpb.CertificateManagementClient
+}
+
+// dialGNOI returns gNOI clients connected to addr and a function closing the
+// connection. It can be set to a fake for unit testing.
+var dialGNOI = func(addr string, opts ...grpc.DialOption) (*gnoiClients, func() error, error) {
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &gnoiClients{
+		system: spb.NewSystemClient(conn),
+		file:   fpb.NewFileClient(conn),
+		cert:   certpb.NewCertificateManagementClient(conn),
+	}, conn.Close, nil
+}
+
+// Operations returns no operations. Vendors declare the gNOI operations
+// supported by their nodes by overriding it, usually with GNOIOperations.
+func (n *Impl) Operations() []Operation {
+	return nil
+}
+
+// GNOIOperations returns ops if the node has a gnoi service and none
+// otherwise.
+func (n *Impl) GNOIOperations(ops ...Operation) []Operation {
+	if n.servicePort(gnoiService) == 0 {
+		return nil
+	}
+	return ops
+}
+
+// gnoi returns gNOI clients connected to the gnoi service of the node, ctx
+// with the credentials of the node and a function closing the connection.
+func (n *Impl) gnoi(ctx context.Context) (context.Context, *gnoiClients, func() error, error) {
+	tc, err := n.grpcTransport(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return n.gnoiWithTransport(ctx, tc)
+}
+
+// gnoiWithTransport is gnoi with the transport credentials tc instead of the
+// ones of the cert config of the node.
+func (n *Impl) gnoiWithTransport(ctx context.Context, tc credentials.TransportCredentials) (context.Context, *gnoiClients, func() error, error) {
+	addr, err := n.serviceAddress(ctx, gnoiService)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx, err = n.withCredentials(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c, closeFn, err := dialGNOI(addr, grpc.WithTransportCredentials(tc))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to dial gNOI server of node %q: %w", n.Name(), err)
+	}
+	return ctx, c, closeFn, nil
+}
+
+// Reboot reboots the node with gNOI System.Reboot.
+func (n *Impl) Reboot(ctx context.Context, opts *RebootOptions) error {
+	if opts == nil {
+		opts = &RebootOptions{}
+	}
+	ctx, c, closeFn, err := n.gnoi(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	req := &spb.RebootRequest{
+		Method:  spb.RebootMethod_COLD,
+		Delay:   uint64(opts.Delay.Nanoseconds()),
+		Message: opts.Message,
+		Force:   opts.Force,
+	}
+	if opts.Warm {
+		req.Method = spb.RebootMethod_WARM
+	}
+	log.Infof("%s - rebooting (%s)", n.Name(), req.GetMethod())
+	if _, err := c.system.Reboot(ctx, req); err != nil {
+		return fmt.Errorf("failed to reboot node %q: %w", n.Name(), err)
+	}
+	return nil
+}
+
+// PutFile writes the contents of r to the remote file on the node with gNOI
+// File.Put.
+func (n *Impl) PutFile(ctx context.Context, remote string, r io.Reader, perm os.FileMode) error {
+	ctx, c, closeFn, err := n.gnoi(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	stream, err := c.file.Put(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to put file %q on node %q: %w", remote, n.Name(), err)
+	}
+	if err := stream.Send(&fpb.PutRequest{
+		Request: &fpb.PutRequest_Open{Open: &fpb.PutRequest_Details{RemoteFile: remote, Permissions: permissions(perm)}},
+	}); err != nil {
+		return fmt.Errorf("failed to put file %q on node %q: %w", remote, n.Name(), err)
+	}
+	h := md5.New()
+	buf := make([]byte, fileChunkSize)
+	for {
+		i, err := r.Read(buf)
+		if i > 0 {
+			h.Write(buf[:i])
+			if err := stream.Send(&fpb.PutRequest{
+				Request: &fpb.PutRequest_Contents{Contents: bytes.Clone(buf[:i])},
+			}); err != nil {
+				return fmt.Errorf("failed to put file %q on node %q: %w", remote, n.Name(), err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&fpb.PutRequest{
+		Request: &fpb.PutRequest_Hash{Hash: &gnoitypes.HashType{Method: gnoitypes.HashType_MD5, Hash: h.Sum(nil)}},
+	}); err != nil {
+		return fmt.Errorf("failed to put file %q on node %q: %w", remote, n.Name(), err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("failed to put file %q on node %q: %w", remote, n.Name(), err)
+	}
+	return nil
+}
+
+// permissions returns the gNOI octal representation of perm, such as 644.
+func permissions(perm os.FileMode) uint32 {
+	perm &= os.ModePerm
+	return uint32(perm>>6)*100 + uint32(perm>>3&7)*10 + uint32(perm&7)
+}
+
+// GetFile writes the contents of the remote file on the node to w with gNOI
+// File.Get and verifies its hash.
+func (n *Impl) GetFile(ctx context.Context, remote string, w io.Writer) error {
+	ctx, c, closeFn, err := n.gnoi(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	stream, err := c.file.Get(ctx, &fpb.GetRequest{RemoteFile: remote})
+	if err != nil {
+		return fmt.Errorf("failed to get file %q from node %q: %w", remote, n.Name(), err)
+	}
+	var contents bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("failed to get file %q from node %q: %w", remote, n.Name(), err)
+		}
+		switch v := resp.GetResponse().(type) {
+		case *fpb.GetResponse_Contents:
+			contents.Write(v.Contents)
+		case *fpb.GetResponse_Hash:
+			if err := verifyHash(contents.Bytes(), v.Hash); err != nil {
+				return fmt.Errorf("failed to get file %q from node %q: %w", remote, n.Name(), err)
+			}
+			_, err := w.Write(contents.Bytes())
+			return err
+		}
+	}
+}
+
+func verifyHash(b []byte, ht *gnoitypes.HashType) error {
+	var h hash.Hash
+	switch ht.GetMethod() {
+	case gnoitypes.HashType_MD5:
+		h = md5.New()
+	case gnoitypes.HashType_SHA256:
+		h = sha256.New()
+	case gnoitypes.HashType_SHA512:
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported hash method %s", ht.GetMethod())
+	}
+	h.Write(b)
+	if !bytes.Equal(h.Sum(nil), ht.GetHash()) {
+		return fmt.Errorf("%s hash mismatch", ht.GetMethod())
+	}
+	return nil
+}
+
+// InstallCertificate installs cert on the node with the provided id using
+// gNOI CertificateManagement.Install.
+func (n *Impl) InstallCertificate(ctx context.Context, id string, cert *Cert) error {
+	req, err := loadCertificateRequest(id, cert)
+	if err != nil {
+		return err
+	}
+	tc, err := n.grpcTransport(ctx)
+	if err != nil {
+		return err
+	}
+	return n.installCertificate(ctx, tc, req)
+}
+
+// certInstallRetryInterval is the time to wait before retrying to install a CA
+// issued cert on a node whose gNOI server is not available yet.
+var certInstallRetryInterval = 5 * time.Second
+
+// GNOIInstallCert installs the cert issued by the topology CA on the node using
+// gNOI CertificateManagement.Install, with the cert name of the ca_issued
+// config as certificate id. Until then the gNOI server presents a cert of the
+// node itself, so its cert is not verified. The install is retried while the
+// gNOI server is unavailable, for example while the node is booting, until ctx
+// is done.
+func (n *Impl) GNOIInstallCert(ctx context.Context, cert *Cert) error {
+	certName, _, err := n.CAIssuedCertNames()
+	if err != nil {
+		return err
+	}
+	req, err := loadCertificateRequest(certName, cert)
+	if err != nil {
+		return err
+	}
+	tc := credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	log.Infof("%s - installing CA issued cert %s", n.Name(), certName)
+	for {
+		err := n.installCertificate(ctx, tc, req)
+		switch {
+		case err == nil:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case status.Code(err) != codes.Unavailable:
+			return err
+		}
+		log.V(1).Infof("%s - waiting for the gNOI server: %v", n.Name(), err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(certInstallRetryInterval):
+		}
+	}
+}
+
+// loadCertificateRequest returns the request loading cert with the provided
+// id, along with the CA certificate if set.
+func loadCertificateRequest(id string, cert *Cert) (*certpb.LoadCertificateRequest, error) {
+	b, _ := pem.Decode(cert.CertPEM)
+	if b == nil {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	c509, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(c509.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	req := &certpb.LoadCertificateRequest{
+		Certificate: &certpb.Certificate{Type: certpb.CertificateType_CT_X509, Certificate: cert.CertPEM},
+		KeyPair: &certpb.KeyPair{
+			PrivateKey: cert.KeyPEM,
+			PublicKey:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}),
+		},
+		CertificateId: id,
+	}
+	if len(cert.CAPEM) != 0 {
+		req.CaCertificates = []*certpb.Certificate{{Type: certpb.CertificateType_CT_X509, Certificate: cert.CAPEM}}
+	}
+	return req, nil
+}
+
+// installCertificate sends req to the gNOI server of the node dialed with tc.
+func (n *Impl) installCertificate(ctx context.Context, tc credentials.TransportCredentials, req *certpb.LoadCertificateRequest) error {
+	id := req.GetCertificateId()
+	ctx, c, closeFn, err := n.gnoiWithTransport(ctx, tc)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	stream, err := c.cert.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install certificate %q on node %q: %w", id, n.Name(), err)
+	}
+	if err := stream.Send(&certpb.InstallCertificateRequest{
+		InstallRequest: &certpb.InstallCertificateRequest_LoadCertificate{LoadCertificate: req},
+	}); err != nil {
+		return fmt.Errorf("failed to install certificate %q on node %q: %w", id, n.Name(), err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		return fmt.Errorf("failed to install certificate %q on node %q: %w", id, n.Name(), err)
+	}
+	return stream.CloseSend()
+}
+
+// Ping pings the destination from the node with gNOI System.Ping.
+func (n *Impl) Ping(ctx context.Context, opts *PingOptions) (*PingResult, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("ping options cannot be nil")
+	}
+	ctx, c, closeFn, err := n.gnoi(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	stream, err := c.system.Ping(ctx, &spb.PingRequest{
+		Destination:     opts.Destination,
+		Source:          opts.Source,
+		Count:           opts.Count,
+		Interval:        opts.Interval.Nanoseconds(),
+		Size:            opts.Size,
+		NetworkInstance: opts.NetworkInstance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to ping %q from node %q: %w", opts.Destination, n.Name(), err)
+	}
+	res := &PingResult{}
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to ping %q from node %q: %w", opts.Destination, n.Name(), err)
+		}
+		// The summary of the ping is the only response with the number of
+		// packets sent set.
+		if resp.GetSent() != 0 {
+			res.Sent = resp.GetSent()
+			res.Received = resp.GetReceived()
+			res.MinTime = time.Duration(resp.GetMinTime())
+			res.AvgTime = time.Duration(resp.GetAvgTime())
+			res.MaxTime = time.Duration(resp.GetMaxTime())
+			res.StdDev = time.Duration(resp.GetStdDev())
+			continue
+		}
+		res.Replies = append(res.Replies, &PingReply{
+			Source:   resp.GetSource(),
+			Sequence: resp.GetSequence(),
+			Bytes:    resp.GetBytes(),
+			TTL:      resp.GetTtl(),
+			Time:     time.Duration(resp.GetTime()),
+		})
+	}
+}
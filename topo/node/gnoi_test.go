@@ -0,0 +1,361 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	certpb "github.com/openconfig/gnoi/cert"
+	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+	gnoitypes "github.com/openconfig/gnoi/types"
+	topopb "github.com/openconfig/kne/proto/topo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeGNOIServer struct {
+	spb.UnimplementedSystemServer
+	fpb.UnimplementedFileServer
+	certpb.UnimplementedCertificateManagementServer
+	reboot   *spb.RebootRequest
+	ping     *spb.PingRequest
+	files    map[string][]byte
+	perms    map[string]uint32
+	badHash  bool
+	loadCert *certpb.LoadCertificateRequest
+	// unavailable is the number of installs failing before the server is
+	// available.
+	unavailable int
+}
+
+func (f *fakeGNOIServer) Reboot(_ context.Context, req *spb.RebootRequest) (*spb.RebootResponse, error) {
+	f.reboot = req
+	return &spb.RebootResponse{}, nil
+}
+
+func (f *fakeGNOIServer) Ping(req *spb.PingRequest, stream spb.System_PingServer) error {
+	f.ping = req
+	for i := int32(1); i <= req.GetCount(); i++ {
+		if err := stream.Send(&spb.PingResponse{Source: req.GetDestination(), Sequence: i, Bytes: 64, Ttl: 64, Time: int64(i * 1000)}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&spb.PingResponse{Source: req.GetDestination(), Sent: req.GetCount(), Received: req.GetCount(), MinTime: 1000, AvgTime: 1500, MaxTime: 2000, StdDev: 500})
+}
+
+func (f *fakeGNOIServer) Put(stream fpb.File_PutServer) error {
+	var name string
+	var contents []byte
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		switch v := req.GetRequest().(type) {
+		case *fpb.PutRequest_Open:
+			name = v.Open.GetRemoteFile()
+			f.perms[name] = v.Open.GetPermissions()
+		case *fpb.PutRequest_Contents:
+			contents = append(contents, v.Contents...)
+		case *fpb.PutRequest_Hash:
+			h := md5.Sum(contents)
+			if !bytes.Equal(h[:], v.Hash.GetHash()) {
+				return status.Errorf(codes.DataLoss, "hash mismatch")
+			}
+			f.files[name] = contents
+			return stream.SendAndClose(&fpb.PutResponse{})
+		}
+	}
+}
+
+func (f *fakeGNOIServer) Get(req *fpb.GetRequest, stream fpb.File_GetServer) error {
+	contents, ok := f.files[req.GetRemoteFile()]
+	if !ok {
+		return status.Errorf(codes.NotFound, "%s not found", req.GetRemoteFile())
+	}
+	for _, c := range [][]byte{contents[:len(contents)/2], contents[len(contents)/2:]} {
+		if err := stream.Send(&fpb.GetResponse{Response: &fpb.GetResponse_Contents{Contents: c}}); err != nil {
+			return err
+		}
+	}
+	h := md5.Sum(contents)
+	if f.badHash {
+		h = md5.Sum(nil)
+	}
+	return stream.Send(&fpb.GetResponse{Response: &fpb.GetResponse_Hash{Hash: &gnoitypes.HashType{Method: gnoitypes.HashType_MD5, Hash: h[:]}}})
+}
+
+func (f *fakeGNOIServer) Install(stream certpb.CertificateManagement_InstallServer) error {
+	if f.unavailable > 0 {
+		f.unavailable--
+		return status.Errorf(codes.Unavailable, "server not ready")
+	}
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	f.loadCert = req.GetLoadCertificate()
+	if err := stream.Send(&certpb.InstallCertificateResponse{}); err != nil {
+		return err
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// newFakeGNOI starts a fake gNOI server and sets dialGNOI to connect to it.
+func newFakeGNOI(t *testing.T) *fakeGNOIServer {
+	t.Helper()
+	f := &fakeGNOIServer{files: map[string][]byte{}, perms: map[string]uint32{}}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	spb.RegisterSystemServer(s, f)
+	fpb.RegisterFileServer(s, f)
+	certpb.RegisterCertificateManagementServer(s, f)
+	go s.Serve(lis)
+	origDialGNOI := dialGNOI
+	dialGNOI = func(addr string, _ ...grpc.DialOption) (*gnoiClients, func() error, error) {
+		if addr != "192.168.18.100:9339" {
+			t.Errorf("dialGNOI() got address %q, want %q", addr, "192.168.18.100:9339")
+		}
+		return origDialGNOI("passthrough:///bufnet",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+	}
+	t.Cleanup(func() {
+		dialGNOI = origDialGNOI
+		s.Stop()
+	})
+	return f
+}
+
+func newGNOINode(services map[uint32]*topopb.Service) *Impl {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-dev1", Namespace: "test"},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "192.168.18.100"}},
+			},
+		},
+	}
+	return &Impl{
+		Namespace:  "test",
+		KubeClient: kfake.NewSimpleClientset(service),
+		Proto:      &topopb.Node{Name: "dev1", Services: services},
+	}
+}
+
+var gnoiServices = map[uint32]*topopb.Service{
+	9339: {Name: "gnmi", Names: []string{"gnoi"}, Inside: 9339},
+}
+
+func TestOperations(t *testing.T) {
+	if got := newGNOINode(gnoiServices).Operations(); got != nil {
+		t.Errorf("Operations() = %v, want nil", got)
+	}
+	if got := newGNOINode(gnoiServices).GNOIOperations(AllOperations...); !cmp.Equal(got, AllOperations) {
+		t.Errorf("GNOIOperations() = %v, want %v", got, AllOperations)
+	}
+	n := newGNOINode(map[uint32]*topopb.Service{9339: {Name: "gnmi", Inside: 9339}})
+	if got := n.GNOIOperations(AllOperations...); got != nil {
+		t.Errorf("GNOIOperations() = %v, want nil", got)
+	}
+	if err := n.Reboot(context.Background(), nil); err == nil || !strings.Contains(err.Error(), `has no "gnoi" service`) {
+		t.Errorf("Reboot() unexpected error: %v", err)
+	}
+}
+
+func TestReboot(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts *RebootOptions
+		want *spb.RebootRequest
+	}{{
+		desc: "default",
+		want: &spb.RebootRequest{Method: spb.RebootMethod_COLD},
+	}, {
+		desc: "options",
+		opts: &RebootOptions{Warm: true, Delay: time.Second, Message: "test", Force: true},
+		want: &spb.RebootRequest{Method: spb.RebootMethod_WARM, Delay: uint64(time.Second), Message: "test", Force: true},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := newFakeGNOI(t)
+			if err := newGNOINode(gnoiServices).Reboot(context.Background(), tt.opts); err != nil {
+				t.Fatalf("Reboot() unexpected error: %v", err)
+			}
+			if s := cmp.Diff(tt.want, f.reboot, protocmp.Transform()); s != "" {
+				t.Errorf("Reboot() unexpected request (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	f := newFakeGNOI(t)
+	n := newGNOINode(gnoiServices)
+	contents := bytes.Repeat([]byte("0123456789"), fileChunkSize/5)
+	if err := n.PutFile(context.Background(), "/tmp/test", bytes.NewReader(contents), 0640); err != nil {
+		t.Fatalf("PutFile() unexpected error: %v", err)
+	}
+	if !bytes.Equal(f.files["/tmp/test"], contents) {
+		t.Errorf("PutFile() wrote %d bytes, want %d", len(f.files["/tmp/test"]), len(contents))
+	}
+	if got := f.perms["/tmp/test"]; got != 640 {
+		t.Errorf("PutFile() set permissions %d, want 640", got)
+	}
+	var buf bytes.Buffer
+	if err := n.GetFile(context.Background(), "/tmp/test", &buf); err != nil {
+		t.Fatalf("GetFile() unexpected error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), contents) {
+		t.Errorf("GetFile() read %d bytes, want %d", buf.Len(), len(contents))
+	}
+	if err := n.GetFile(context.Background(), "/tmp/missing", &buf); status.Code(errors.Unwrap(err)) != codes.NotFound {
+		t.Errorf("GetFile() of missing file unexpected error: %v", err)
+	}
+	f.badHash = true
+	err := n.GetFile(context.Background(), "/tmp/test", &buf)
+	if s := errdiff.Substring(err, "MD5 hash mismatch"); s != "" {
+		t.Errorf("GetFile() unexpected error: %s", s)
+	}
+}
+
+func TestInstallCertificate(t *testing.T) {
+	f := newFakeGNOI(t)
+	ca, err := NewCA("test CA", 1024)
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	cert, err := ca.Issue("dev1", []string{"dev1"}, nil, 1024)
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	n := newGNOINode(gnoiServices)
+	if err := n.InstallCertificate(context.Background(), "kne", cert); err != nil {
+		t.Fatalf("InstallCertificate() unexpected error: %v", err)
+	}
+	b, _ := pem.Decode(f.loadCert.GetKeyPair().GetPublicKey())
+	if _, err := x509.ParsePKIXPublicKey(b.Bytes); err != nil {
+		t.Errorf("InstallCertificate() sent invalid public key: %v", err)
+	}
+	want := &certpb.LoadCertificateRequest{
+		Certificate:    &certpb.Certificate{Type: certpb.CertificateType_CT_X509, Certificate: cert.CertPEM},
+		KeyPair:        &certpb.KeyPair{PrivateKey: cert.KeyPEM, PublicKey: f.loadCert.GetKeyPair().GetPublicKey()},
+		CertificateId:  "kne",
+		CaCertificates: []*certpb.Certificate{{Type: certpb.CertificateType_CT_X509, Certificate: ca.CertPEM()}},
+	}
+	if s := cmp.Diff(want, f.loadCert, protocmp.Transform()); s != "" {
+		t.Errorf("InstallCertificate() unexpected request (-want +got):\n%s", s)
+	}
+	err = n.InstallCertificate(context.Background(), "kne", &Cert{CertPEM: []byte("invalid")})
+	if s := errdiff.Substring(err, "no PEM encoded certificate found"); s != "" {
+		t.Errorf("InstallCertificate() unexpected error: %s", s)
+	}
+}
+
+func TestGNOIInstallCert(t *testing.T) {
+	origInterval := certInstallRetryInterval
+	defer func() {
+		certInstallRetryInterval = origInterval
+	}()
+	certInstallRetryInterval = time.Millisecond
+	ca, err := NewCA("test CA", 1024)
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	cert, err := ca.Issue("dev1", []string{"dev1"}, nil, 1024)
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	newNode := func() *Impl {
+		n := newGNOINode(gnoiServices)
+		n.Proto.Config = &topopb.Config{
+			Cert: &topopb.CertificateCfg{Config: &topopb.CertificateCfg_CaIssued{CaIssued: &topopb.CAIssuedCertCfg{CertName: "gnmi.crt"}}},
+		}
+		return n
+	}
+
+	t.Run("retried until available", func(t *testing.T) {
+		f := newFakeGNOI(t)
+		f.unavailable = 2
+		if err := newNode().GNOIInstallCert(context.Background(), cert); err != nil {
+			t.Fatalf("GNOIInstallCert() unexpected error: %v", err)
+		}
+		if got, want := f.loadCert.GetCertificateId(), "gnmi.crt"; got != want {
+			t.Errorf("GNOIInstallCert() installed certificate %q, want %q", got, want)
+		}
+	})
+	t.Run("context done", func(t *testing.T) {
+		f := newFakeGNOI(t)
+		f.unavailable = 1000
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := newNode().GNOIInstallCert(ctx, cert)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GNOIInstallCert() got error %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestPing(t *testing.T) {
+	f := newFakeGNOI(t)
+	got, err := newGNOINode(gnoiServices).Ping(context.Background(), &PingOptions{Destination: "10.0.0.2", Count: 2, Interval: time.Second})
+	if err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+	wantReq := &spb.PingRequest{Destination: "10.0.0.2", Count: 2, Interval: int64(time.Second)}
+	if s := cmp.Diff(wantReq, f.ping, protocmp.Transform()); s != "" {
+		t.Errorf("Ping() unexpected request (-want +got):\n%s", s)
+	}
+	want := &PingResult{
+		Replies: []*PingReply{
+			{Source: "10.0.0.2", Sequence: 1, Bytes: 64, TTL: 64, Time: time.Microsecond},
+			{Source: "10.0.0.2", Sequence: 2, Bytes: 64, TTL: 64, Time: 2 * time.Microsecond},
+		},
+		Sent:     2,
+		Received: 2,
+		MinTime:  time.Microsecond,
+		AvgTime:  1500 * time.Nanosecond,
+		MaxTime:  2 * time.Microsecond,
+		StdDev:   500 * time.Nanosecond,
+	}
+	if s := cmp.Diff(want, got); s != "" {
+		t.Errorf("Ping() unexpected result (-want +got):\n%s", s)
+	}
+}
+
+func TestPingNilOptions(t *testing.T) {
+	if _, err := newGNOINode(gnoiServices).Ping(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "cannot be nil") {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	for perm, want := range map[uint32]uint32{0644: 644, 0755: 755, 0600: 600, 0: 0} {
+		if got := permissions(os.FileMode(perm)); got != want {
+			t.Errorf("permissions(%o) = %d, want %d", perm, got, want)
+		}
+	}
+}
@@ -145,32 +145,63 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Certer       = (*Node)(nil)
-	_ node.ConfigPusher = (*Node)(nil)
-	_ node.Resetter     = (*Node)(nil)
+	_ node.Certer        = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
+	_ node.ConfigPusher  = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
+	_ node.CLIer         = (*Node)(nil)
+	_ node.Commander     = (*Node)(nil)
 )
 
 // SpawnCLIConn spawns a CLI connection towards a Network OS using `kubectl exec` terminal and ensures CLI is ready
 // to accept inputs.
 // scrapligo options can be provided to this function for a caller to modify scrapligo platform.
 // For example, mock transport can be set via options
-func (n *Node) SpawnCLIConn() error {
+func (n *Node) SpawnCLIConn(ctx context.Context) error {
 	opts := []scrapliutil.Option{
-		scrapliopts.WithAuthBypass(),
 		scrapliopts.WithTimeoutOps(scrapliOperationTimeout),
 	}
+	authOpts, err := n.CLIAuthOptions(ctx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, authOpts...)
 
 	// add options defined in test package
 	opts = append(opts, n.testOpts...)
 
-	opts = n.PatchCLIConnOpen("kubectl", []string{"cli"}, opts)
+	opts = n.PatchCLIConnOpen("kubectl", n.CLICommand(), opts)
 
-	var err error
-	n.cliConn, err = n.GetCLIConn(scrapliPlatformName, opts)
+	n.cliConn, err = n.GetCLIConn(ctx, scrapliPlatformName, opts)
 
 	return err
 }
 
+// Operations returns the gNOI operations supported by the node. cPTX runs
+// Junos EVO in a VM inside the container, so System.Reboot reboots the VM
+// without restarting the pod and all the operations are supported.
+func (n *Node) Operations() []node.Operation {
+	return n.GNOIOperations(node.AllOperations...)
+}
+
+// CLICommand returns the command that starts the Junos CLI in the node container.
+func (n *Node) CLICommand() []string {
+	return []string{"cli"}
+}
+
+// RunCommands runs cmds on the Junos CLI using scrapligo SendCommands.
+func (n *Node) RunCommands(ctx context.Context, cmds []string) ([]*node.CommandResult, error) {
+	log.Infof("%s - running commands", n.Name())
+
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return nil, err
+	}
+
+	defer n.cliConn.Close()
+
+	return node.SendCommands(ctx, n.cliConn, cmds)
+}
+
 // DefaultNodeConstraints returns default node constraints for Juniper.
 // If the model for cptx is specified correctly it returns defaults for cptx.
 // Otherwise, it returns defaults for ncptx by default.
@@ -199,6 +230,11 @@ func (n *Node) GRPCConfig() []string {
 	if selfSigned := n.Proto.GetConfig().GetCert().GetSelfSigned(); selfSigned != nil && selfSigned.GetCertName() != "" {
 		certName = selfSigned.GetCertName()
 	}
+	if n.Proto.GetConfig().GetCert().GetCaIssued() != nil {
+		if name, _, err := n.CAIssuedCertNames(); err == nil {
+			certName = name
+		}
+	}
 	log.Infof("gNMI Port %d, certName %s", port, certName)
 	return []string{
 		"set system services http servers server grpc-server-9339",
@@ -348,7 +384,7 @@ func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 		log.Infof("%s - pod running.", n.Name())
 	}
 
-	if err := n.SpawnCLIConn(); err != nil {
+	if err := n.SpawnCLIConn(ctx); err != nil {
 		return err
 	}
 
@@ -371,6 +407,45 @@ func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 	return n.cliConn.Close()
 }
 
+// InstallCert loads the certificate and key issued by the topology CA with
+// Junos PKI and configures the gRPC servers to use it.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	certName, keyName, err := n.CAIssuedCertNames()
+	if err != nil {
+		return err
+	}
+	log.Infof("%s - installing CA issued cert %s", n.Name(), certName)
+	// The pod IP the cert is issued for is assigned before the Junos container
+	// runs, so wait for the CLI, which is retried until ctx is done, before
+	// writing the files.
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return err
+	}
+	defer n.cliConn.Close()
+	certPath, keyPath := "/var/tmp/"+certName, "/var/tmp/"+keyName
+	if err := n.WriteFiles(ctx, map[string][]byte{
+		certPath: cert.CertPEM,
+		keyPath:  cert.KeyPEM,
+	}); err != nil {
+		return err
+	}
+	resp, err := n.cliConn.SendCommand(fmt.Sprintf("request security pki local-certificate load certificate-id %s filename %s key %s", certName, certPath, keyPath))
+	if err != nil {
+		return fmt.Errorf("failed loading cert: %w", err)
+	}
+	if resp.Failed != nil {
+		return resp.Failed
+	}
+	if strings.Contains(resp.Result, "error:") {
+		return fmt.Errorf("failed loading cert: %s", resp.Result)
+	}
+	if err := n.waitConfigInfraReadyAndPushConfigs(n.GRPCConfig()); err != nil {
+		return fmt.Errorf("failed sending grpc config commands - ca-issued-cert: %v", err)
+	}
+	log.Infof("%s - finished installing cert", n.Name())
+	return nil
+}
+
 func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 	log.Infof("%s - pushing config", n.Name())
 
@@ -388,7 +463,7 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 		return err
 	}
 
-	err = n.SpawnCLIConn()
+	err = n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -442,7 +517,7 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 func (n *Node) ResetCfg(ctx context.Context) error {
 	log.Infof("%s - resetting config", n.Name())
 
-	err := n.SpawnCLIConn()
+	err := n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -744,6 +819,9 @@ fi
 		VolumeMounts:    initVolumeMounts,
 	}}
 
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
+	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to create pod for %q: %w", pb.Name, err)
@@ -856,6 +934,18 @@ func (n *Node) isChannelized() bool {
 	return false
 }
 
+// intfName returns the cPTX name et-0/0/N-1 of the interface ethN. Other
+// names are returned unchanged. Channelized ports, such as et-0/0/0:0, are
+// only named by the interface config.
+func intfName(_ *tpb.Node, name string) (string, error) {
+	i, ok := node.EthIntfNum(name)
+	if !ok {
+		return name, nil
+	}
+	return fmt.Sprintf("et-0/0/%d", i-1), nil
+}
+
 func init() {
 	node.Vendor(tpb.Vendor_JUNIPER, New)
+	node.VendorIntfNames(tpb.Vendor_JUNIPER, intfName)
 }
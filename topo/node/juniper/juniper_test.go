@@ -1196,3 +1196,32 @@ fi
 		}
 	}
 }
+
+func TestVendorIntfName(t *testing.T) {
+	tests := []struct {
+		node *tpb.Node
+		name string
+		want string
+	}{
+		{node: &tpb.Node{Vendor: tpb.Vendor_JUNIPER}, name: "eth1", want: "et-0/0/0"},
+		{node: &tpb.Node{Vendor: tpb.Vendor_JUNIPER}, name: "eth12", want: "et-0/0/11"},
+		{node: &tpb.Node{Vendor: tpb.Vendor_JUNIPER}, name: "mgmt0", want: "mgmt0"},
+		{
+			node: &tpb.Node{
+				Vendor:     tpb.Vendor_JUNIPER,
+				Interfaces: map[string]*tpb.Interface{"eth1": {Name: "custom1"}},
+			},
+			name: "eth1",
+			want: "custom1",
+		},
+	}
+	for _, tt := range tests {
+		got, err := node.VendorIntfName(tt.node, tt.name)
+		if err != nil {
+			t.Fatalf("VendorIntfName(%q) failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("VendorIntfName(%q) got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
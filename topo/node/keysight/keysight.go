@@ -167,6 +167,11 @@ func (n *Node) TopologySpecs(ctx context.Context) ([]*topologyv1.Topology, error
 	log.Infof("Getting interfaces for ixia node resource %s ...", n.Name())
 	desiredState := "INITIATED"
 
+	// The operator creates the pods of the node from the IxiaTG custom
+	// resource, which has no fields for the pod options of the node.
+	if err := n.ValidateCRConfig(false); err != nil {
+		return nil, err
+	}
 	crd, err := n.newCRD()
 	if err != nil {
 		return nil, err
@@ -340,6 +345,16 @@ func (n *Node) Status(ctx context.Context) (node.Status, error) {
 	return state, err
 }
 
+// StatusDetails returns the state and reason of the IxiaTG resource of the
+// node.
+func (n *Node) StatusDetails(ctx context.Context) (*node.StatusDetails, error) {
+	status, err := n.getStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get ixia CRD: %v", err)
+	}
+	return node.CRStatusDetails(ctx, n.Impl, "ixiatg", status.State, status.Reason), nil
+}
+
 func (n *Node) Delete(ctx context.Context) error {
 	log.Infof("Deleting IxiaTG node resource %s", n.Name())
 	c, err := ixclient.NewForConfig(n.RestConfig)
@@ -1,6 +1,7 @@
 package keysight
 
 import (
+	"context"
 	"testing"
 
 	"github.com/openconfig/gnmi/errdiff"
@@ -70,3 +71,32 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestTopologySpecsUnsupported(t *testing.T) {
+	tests := []struct {
+		desc    string
+		pb      *tpb.Node
+		wantErr string
+	}{{
+		desc:    "placement",
+		pb:      &tpb.Node{Placement: &tpb.Placement{NodeSelector: map[string]string{"pool": "ate"}}},
+		wantErr: "placement is not supported",
+	}, {
+		desc:    "sidecars",
+		pb:      &tpb.Node{Sidecars: []*tpb.Sidecar{{Name: "capture", Image: "tcpdump:latest"}}},
+		wantErr: "sidecars are not supported",
+	}, {
+		desc:    "file mounts",
+		pb:      &tpb.Node{Config: &tpb.Config{FileMounts: []*tpb.FileMount{{Source: "license.txt", Destination: "/license"}}}},
+		wantErr: "file mounts are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Node{Impl: &node.Impl{Proto: tt.pb}}
+			_, err := n.TopologySpecs(context.Background())
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("TopologySpecs() unexpected error: %s", s)
+			}
+		})
+	}
+}
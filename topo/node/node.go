@@ -6,7 +6,6 @@ import (
 	"io"
 	"math"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +20,7 @@ import (
 	scrapliplatform "github.com/scrapli/scrapligo/platform"
 	scrapliutil "github.com/scrapli/scrapligo/util"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -72,16 +72,83 @@ type Certer interface {
 	GenerateSelfSigned(context.Context) error
 }
 
+// CertInstaller provides an interface for installing certificates issued by
+// the topology CA on nodes.
+type CertInstaller interface {
+	InstallCert(context.Context, *Cert) error
+}
+
 // ConfigPusher provides an interface for performing config pushes to the node.
 type ConfigPusher interface {
 	ConfigPush(context.Context, io.Reader) error
 }
 
+// Credentialer provides the credentials to log into nodes.
+type Credentialer interface {
+	Credentials(ctx context.Context) (*tpb.Credentials, error)
+}
+
 // Resetter provides Reset interface to nodes.
 type Resetter interface {
 	ResetCfg(ctx context.Context) error
 }
 
+// VolumeReleaser provides an interface for nodes with persistent volumes.
+type VolumeReleaser interface {
+	// ReleaseVolumes applies the retain or delete policy of the persistent
+	// volumes of the node before the topology is deleted. policy overrides
+	// the policy of all volumes if not nil.
+	ReleaseVolumes(ctx context.Context, policy *tpb.PersistentVolume_Policy) error
+}
+
+// PodPatchValidator provides an interface for nodes which apply pod patches
+// to their pods.
+type PodPatchValidator interface {
+	// ValidatePodPatches returns an error if the pod patches of the node are
+	// invalid regardless of the pod they are applied to.
+	ValidatePodPatches() error
+}
+
+// Execer provides an interface for running commands in the node container.
+type Execer interface {
+	Exec(ctx context.Context, cmd []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+}
+
+// CLIer provides an interface for nodes that expose a vendor native CLI.
+type CLIer interface {
+	// CLICommand returns the command that starts the vendor CLI inside
+	// the node container.
+	CLICommand() []string
+}
+
+// Commander provides an interface for running operational commands on
+// the vendor CLI of a node.
+type Commander interface {
+	// RunCommands runs cmds in order and returns the result of each command.
+	// An error is only returned if the commands could not be run at all,
+	// including when ctx is done before the commands complete.
+	RunCommands(ctx context.Context, cmds []string) ([]*CommandResult, error)
+}
+
+// CommandResult is the output of a single command run by a Commander.
+type CommandResult struct {
+	Command string
+	Output  string
+	Err     error
+}
+
+// Operator provides an interface for operational commands on nodes. Impl
+// implements it with the gNOI services of the node.
+type Operator interface {
+	// Operations returns the operations supported by the node.
+	Operations() []Operation
+	Reboot(ctx context.Context, opts *RebootOptions) error
+	PutFile(ctx context.Context, remote string, r io.Reader, perm os.FileMode) error
+	GetFile(ctx context.Context, remote string, w io.Writer) error
+	InstallCertificate(ctx context.Context, id string, cert *Cert) error
+	Ping(ctx context.Context, opts *PingOptions) (*PingResult, error)
+}
+
 // Node is the base interface for all node implementations in KNE.
 type Node interface {
 	Interface
@@ -108,6 +175,7 @@ type NewNodeFn func(n *Impl) (Node, error)
 var (
 	mu          sync.Mutex
 	vendorTypes = map[tpb.Vendor]NewNodeFn{}
+	pluginFn    NewNodeFn
 	tempCfgDir  = "/tmp/kne"
 )
 
@@ -146,6 +214,17 @@ func Vendor(v tpb.Vendor, fn NewNodeFn) {
 	mu.Unlock()
 }
 
+// Plugins registers the implementation of the nodes of out-of-tree plugins,
+// which are selected by the plugin field of the node instead of its vendor.
+func Plugins(fn NewNodeFn) {
+	mu.Lock()
+	if pluginFn != nil {
+		panic("duplicate registration for plugins")
+	}
+	pluginFn = fn
+	mu.Unlock()
+}
+
 // Impl is a topology node in the cluster.
 type Impl struct {
 	Namespace  string
@@ -154,14 +233,17 @@ type Impl struct {
 	Proto      *tpb.Node
 	BasePath   string
 	Kubecfg    string
+	// Topology is the topology the node is part of.
+	Topology *tpb.Topology
 }
 
 // New creates a new node for use in the k8s cluster.  Configure will push the node to
 // the cluster.
-func New(namespace string, pb *tpb.Node, kClient kubernetes.Interface, rCfg *rest.Config, bp, kubecfg string) (Node, error) {
+func New(namespace string, pb *tpb.Node, kClient kubernetes.Interface, rCfg *rest.Config, bp, kubecfg string, topo *tpb.Topology) (Node, error) {
 	return getImpl(&Impl{
 		Namespace:  namespace,
 		Proto:      pb,
+		Topology:   topo,
 		KubeClient: kClient,
 		RestConfig: rCfg,
 		BasePath:   bp,
@@ -307,34 +389,12 @@ func convertSysctlNameToProcSysPath(sysctlName string) string {
 	return procSysPath
 }
 
-func (n *Impl) readConfig() ([]byte, error) {
-	if n.Proto == nil || n.Proto.Config == nil {
-		return nil, nil
-	}
-	switch v := n.Proto.Config.GetConfigData().(type) {
-	case *tpb.Config_File:
-		if v == nil {
-			return nil, nil
-		}
-		return os.ReadFile(filepath.Join(n.BasePath, v.File))
-	case *tpb.Config_Data:
-		if v == nil {
-			return nil, nil
-		}
-		return v.Data, nil
-	case nil:
-		return nil, nil
-	default:
-		return nil, fmt.Errorf("config type not supported: %T", v)
-	}
-}
-
 // CreateConfig creates a boot config for the node based on the underlying proto.
 // A volume containing the boot config is returned. If the config size is <3MB
 // then a ConfigMap is created as the volume source. Else a temporary file
 // is written with the boot config to serve as a HostPath volume source.
 func (n *Impl) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
-	data, err := n.readConfig()
+	data, err := n.ReadConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +413,7 @@ func (n *Impl) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
 				n.Proto.Config.ConfigFile: string(data),
 			},
 		}
-		sCM, err := n.KubeClient.CoreV1().ConfigMaps(n.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		sCM, err := n.createOrUpdateConfigMap(ctx, cm)
 		if err != nil {
 			return nil, err
 		}
@@ -399,15 +459,71 @@ func (n *Impl) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
 	}, nil
 }
 
+// createOrUpdateConfigMap creates cm in the topology namespace, replacing the
+// data of an existing ConfigMap, such as one which outlived the pod of the
+// node.
+func (n *Impl) createOrUpdateConfigMap(ctx context.Context, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	cms := n.KubeClient.CoreV1().ConfigMaps(n.Namespace)
+	sCM, err := cms.Create(ctx, cm, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return sCM, err
+	}
+	cur, err := cms.Get(ctx, cm.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cur.Labels = cm.Labels
+	cur.Data = cm.Data
+	cur.BinaryData = cm.BinaryData
+	return cms.Update(ctx, cur, metav1.UpdateOptions{})
+}
+
 // CreatePod creates a Pod for the Node based on the underlying proto.
 func (n *Impl) CreatePod(ctx context.Context) error {
+	pod, err := n.NewPod(ctx)
+	if err != nil {
+		return err
+	}
+	return n.SubmitPod(ctx, pod)
+}
+
+// NewPod returns the pod of the node based on the underlying proto, creating
+// the config of the node. Vendors may modify the pod before submitting it
+// with SubmitPod.
+func (n *Impl) NewPod(ctx context.Context) (*corev1.Pod, error) {
 	pb := n.Proto
 	links, err := GetNodeLinks(pb)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	log.Infof("Creating Pod:\n %+v", pb)
-	initContainerImage := pb.Config.InitImage
+	log.Infof("Creating Pod:\n %+v", StripPassword(pb))
+	pod := n.defaultPod(len(links))
+	if pb.Config.ConfigData != nil {
+		vol, err := n.CreateConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, *vol)
+		vm := corev1.VolumeMount{
+			Name:      ConfigVolumeName,
+			MountPath: pb.Config.ConfigPath + "/" + pb.Config.ConfigFile,
+			ReadOnly:  true,
+		}
+		if vol.VolumeSource.ConfigMap != nil {
+			vm.SubPath = pb.Config.ConfigFile
+		}
+		for i, c := range pod.Spec.Containers {
+			pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
+		}
+	}
+	return pod, nil
+}
+
+// defaultPod returns the pod of the node without its config, for a node with
+// the given number of links.
+func (n *Impl) defaultPod(links int) *corev1.Pod {
+	pb := n.Proto
+	initContainerImage := pb.GetConfig().GetInitImage()
 	if initContainerImage == "" {
 		initContainerImage = DefaultInitContainerImage
 	}
@@ -424,17 +540,17 @@ func (n *Impl) CreatePod(ctx context.Context) error {
 				Name:  fmt.Sprintf("init-%s", pb.Name),
 				Image: initContainerImage,
 				Args: []string{
-					fmt.Sprintf("%d", len(links)+1),
-					fmt.Sprintf("%d", pb.Config.Sleep),
+					fmt.Sprintf("%d", links+1),
+					fmt.Sprintf("%d", pb.GetConfig().GetSleep()),
 				},
 				ImagePullPolicy: "IfNotPresent",
 			}},
 			Containers: []corev1.Container{{
 				Name:            pb.Name,
-				Image:           pb.Config.Image,
-				Command:         pb.Config.Command,
-				Args:            pb.Config.Args,
-				Env:             ToEnvVar(pb.Config.Env),
+				Image:           pb.GetConfig().GetImage(),
+				Command:         pb.GetConfig().GetCommand(),
+				Args:            pb.GetConfig().GetArgs(),
+				Env:             ToEnvVar(pb.GetConfig().GetEnv()),
 				Resources:       ToResourceRequirements(pb.Constraints),
 				ImagePullPolicy: "IfNotPresent",
 				SecurityContext: &corev1.SecurityContext{
@@ -465,23 +581,14 @@ func (n *Impl) CreatePod(ctx context.Context) error {
 	for label, v := range n.GetProto().GetLabels() {
 		pod.ObjectMeta.Labels[label] = v
 	}
-	if pb.Config.ConfigData != nil {
-		vol, err := n.CreateConfig(ctx)
-		if err != nil {
-			return err
-		}
-		pod.Spec.Volumes = append(pod.Spec.Volumes, *vol)
-		vm := corev1.VolumeMount{
-			Name:      ConfigVolumeName,
-			MountPath: pb.Config.ConfigPath + "/" + pb.Config.ConfigFile,
-			ReadOnly:  true,
-		}
-		if vol.VolumeSource.ConfigMap != nil {
-			vm.SubPath = pb.Config.ConfigFile
-		}
-		for i, c := range pod.Spec.Containers {
-			pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
-		}
+	return pod
+}
+
+// SubmitPod customizes the pod with the common options of the node, such as
+// placement, Secrets and pod patches, and creates it in the cluster.
+func (n *Impl) SubmitPod(ctx context.Context, pod *corev1.Pod) error {
+	if err := n.CustomizePod(ctx, pod); err != nil {
+		return err
 	}
 	sPod, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
@@ -510,7 +617,7 @@ func (n *Impl) CreateService(ctx context.Context) error {
 			return fmt.Errorf("service port %d out of range (max: %d)", k, math.MaxUint16)
 		}
 		sp := corev1.ServicePort{
-			Protocol:   "TCP",
+			Protocol:   serviceProtocols[v.GetProtocol()],
 			Port:       int32(k),
 			NodePort:   int32(nodePort),
 			TargetPort: intstr.FromInt(int(v.Inside)),
@@ -543,7 +650,23 @@ func (n *Impl) CreateService(ctx context.Context) error {
 			AllocateLoadBalancerNodePorts: pointer.Bool(false),
 		},
 	}
-	sS, err := n.KubeClient.CoreV1().Services(n.Namespace).Create(ctx, s, metav1.CreateOptions{})
+	if err := n.applyServiceOptions(s); err != nil {
+		return err
+	}
+	services := n.KubeClient.CoreV1().Services(n.Namespace)
+	sS, err := services.Create(ctx, s, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// The service outlived the pod of the node, keep its cluster IPs
+		// which cannot be changed.
+		cur, gErr := services.Get(ctx, s.Name, metav1.GetOptions{})
+		if gErr != nil {
+			return gErr
+		}
+		s.ResourceVersion = cur.ResourceVersion
+		s.Spec.ClusterIP = cur.Spec.ClusterIP
+		s.Spec.ClusterIPs = cur.Spec.ClusterIPs
+		sS, err = services.Update(ctx, s, metav1.UpdateOptions{})
+	}
 	if err != nil {
 		return err
 	}
@@ -706,6 +829,12 @@ func getImpl(impl *Impl) (Node, error) {
 	if impl.Proto.Type != tpb.Node_UNKNOWN {
 		log.Warningf("node.Type (%v) is a DEPRECATED field, node.Vendor is required", impl.Proto.Type)
 	}
+	if impl.Proto.Plugin != "" {
+		if pluginFn == nil {
+			return nil, fmt.Errorf("node implementation not found for plugin %q", impl.Proto.Plugin)
+		}
+		return pluginFn(impl)
+	}
 	if fn, ok := vendorTypes[impl.Proto.Vendor]; ok {
 		return fn(impl)
 	}
@@ -731,8 +860,8 @@ func (n *Impl) PatchCLIConnOpen(bin string, cliCmd []string, opts []scrapliutil.
 }
 
 // GetCLIConn attempts to open the transport channel towards a Network OS and perform scrapligo OnOpen actions
-// for a given platform. Retries indefinitely till success and returns a scrapligo network driver instance.
-func (n *Impl) GetCLIConn(platform string, opts []scrapliutil.Option) (*scraplinetwork.Driver, error) {
+// for a given platform. Retries till success or until ctx is done and returns a scrapligo network driver instance.
+func (n *Impl) GetCLIConn(ctx context.Context, platform string, opts []scrapliutil.Option) (*scraplinetwork.Driver, error) {
 	if log.V(1).Enabled() {
 		li, _ := scraplilogging.NewInstance(scraplilogging.WithLevel("debug"),
 			scraplilogging.WithLogger(log.Info))
@@ -758,7 +887,11 @@ func (n *Impl) GetCLIConn(platform string, opts []scrapliutil.Option) (*scraplin
 
 		if err = d.Open(); err != nil {
 			log.V(1).Infof("%s - Cli not ready (%s) - waiting.", n.Name(), err)
-			time.Sleep(time.Second * 2)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%s - cli not ready: %w", n.Name(), ctx.Err())
+			case <-time.After(time.Second * 2):
+			}
 			continue
 		}
 
@@ -768,6 +901,36 @@ func (n *Impl) GetCLIConn(platform string, opts []scrapliutil.Option) (*scraplin
 	}
 }
 
+// SendCommands sends cmds to the CLI connection d and returns the result of
+// each command. A failed command does not prevent the remaining commands
+// from being sent. d is closed if ctx is done before the commands complete,
+// which fails the pending command.
+func SendCommands(ctx context.Context, d *scraplinetwork.Driver, cmds []string) ([]*CommandResult, error) {
+	stop := context.AfterFunc(ctx, func() {
+		if err := d.Close(); err != nil {
+			log.Warningf("Failed to close cli connection: %v", err)
+		}
+	})
+	defer stop()
+	resp, err := d.SendCommands(cmds)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []*CommandResult
+	for i, r := range resp.Responses {
+		cr := &CommandResult{
+			Command: cmds[i],
+			Output:  r.Result,
+			Err:     r.Failed,
+		}
+		results = append(results, cr)
+	}
+	return results, nil
+}
+
 // BackToBackLoop returns a bool indicating if the node supports a single link
 // connecting two ports on the same node. By default this is false.
 func (n *Impl) BackToBackLoop() bool {
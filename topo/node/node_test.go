@@ -41,7 +41,7 @@ func NewR(impl *Impl) (Node, error) {
 func TestReset(t *testing.T) {
 	Vendor(topopb.Vendor(1001), NewR)
 	Vendor(topopb.Vendor(1002), NewNR)
-	n, err := New("test", &topopb.Node{Vendor: topopb.Vendor(1001)}, nil, nil, "", "")
+	n, err := New("test", &topopb.Node{Vendor: topopb.Vendor(1001)}, nil, nil, "", "", nil)
 	if err != nil {
 		t.Fatalf("failed to create node: %v", err)
 	}
@@ -52,7 +52,7 @@ func TestReset(t *testing.T) {
 	if err := r.ResetCfg(context.Background()); err != nil {
 		t.Errorf("Resettable node failed to reset: %v", err)
 	}
-	nr, err := New("test", &topopb.Node{Vendor: topopb.Vendor(1002)}, nil, nil, "", "")
+	nr, err := New("test", &topopb.Node{Vendor: topopb.Vendor(1002)}, nil, nil, "", "", nil)
 	if err != nil {
 		t.Fatalf("failed to create node: %v", err)
 	}
@@ -305,7 +305,145 @@ func TestService(t *testing.T) {
 			},
 		}},
 	}, {
-		desc: "failed create duplicate",
+		desc: "udp node port",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				514: {
+					Name:     "syslog",
+					Inside:   514,
+					Protocol: topopb.Protocol_UDP,
+					NodePort: 30514,
+				},
+			},
+			ServiceOptions: &topopb.ServiceOptions{Type: topopb.ServiceOptions_NODE_PORT},
+		},
+		kClient: kfake.NewSimpleClientset(),
+		want: []*corev1.Service{{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "service-dev1",
+				Namespace: "test",
+				Labels:    map[string]string{"pod": "dev1"},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{
+					Name:       "syslog",
+					Protocol:   "UDP",
+					Port:       514,
+					TargetPort: intstr.FromInt(514),
+					NodePort:   30514,
+				}},
+				Selector: map[string]string{"app": "dev1"},
+				Type:     "NodePort",
+			},
+		}},
+	}, {
+		desc: "pinned load balancer ips",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				22: {
+					Name:   "ssh",
+					Inside: 22,
+				},
+			},
+			ServiceOptions: &topopb.ServiceOptions{
+				LoadBalancerIps: []string{"192.168.18.100", "2001:db8::100"},
+				AddressPool:     "lab",
+				Annotations:     map[string]string{"owner": "lab"},
+			},
+		},
+		kClient: kfake.NewSimpleClientset(),
+		want: []*corev1.Service{{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "service-dev1",
+				Namespace: "test",
+				Labels:    map[string]string{"pod": "dev1"},
+				Annotations: map[string]string{
+					"owner":                      "lab",
+					"metallb.io/loadBalancerIPs": "192.168.18.100,2001:db8::100",
+					"metallb.io/address-pool":    "lab",
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{
+					Name:       "ssh",
+					Protocol:   "TCP",
+					Port:       22,
+					TargetPort: intstr.FromInt(22),
+				}},
+				Selector:                      map[string]string{"app": "dev1"},
+				Type:                          "LoadBalancer",
+				AllocateLoadBalancerNodePorts: pointer.Bool(false),
+			},
+		}},
+	}, {
+		desc: "invalid load balancer ip",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				22: {Name: "ssh", Inside: 22},
+			},
+			ServiceOptions: &topopb.ServiceOptions{LoadBalancerIps: []string{"192.168.18"}},
+		},
+		kClient:        kfake.NewSimpleClientset(),
+		wantCreateErr:  "invalid load balancer IP",
+		wantServiceErr: `"service-dev1" not found`,
+	}, {
+		desc: "multiple load balancer ips of a family",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				22: {Name: "ssh", Inside: 22},
+			},
+			ServiceOptions: &topopb.ServiceOptions{LoadBalancerIps: []string{"192.168.18.100", "192.168.18.101"}},
+		},
+		kClient:        kfake.NewSimpleClientset(),
+		wantCreateErr:  "at most one load balancer IP per IP family",
+		wantServiceErr: `"service-dev1" not found`,
+	}, {
+		desc: "address pool with cluster ip",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				22: {Name: "ssh", Inside: 22},
+			},
+			ServiceOptions: &topopb.ServiceOptions{
+				Type:        topopb.ServiceOptions_CLUSTER_IP,
+				AddressPool: "lab",
+			},
+		},
+		kClient:        kfake.NewSimpleClientset(),
+		wantCreateErr:  "only valid for type LOAD_BALANCER",
+		wantServiceErr: `"service-dev1" not found`,
+	}, {
+		desc: "node port with cluster ip",
+		node: &topopb.Node{
+			Name:   "dev1",
+			Vendor: topopb.Vendor(1001),
+			Services: map[uint32]*topopb.Service{
+				22: {Name: "ssh", Inside: 22, NodePort: 30022},
+			},
+			ServiceOptions: &topopb.ServiceOptions{Type: topopb.ServiceOptions_CLUSTER_IP},
+		},
+		kClient:        kfake.NewSimpleClientset(),
+		wantCreateErr:  "node port 30022 is not valid for type CLUSTER_IP",
+		wantServiceErr: `"service-dev1" not found`,
+	}, {
+		desc: "update existing",
 		node: &topopb.Node{
 			Name:   "dev1",
 			Vendor: topopb.Vendor(1001),
@@ -321,8 +459,39 @@ func TestService(t *testing.T) {
 				Name:      "service-dev1",
 				Namespace: "test",
 			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{
+					Name: "telnet",
+					Port: 23,
+				}},
+				ClusterIP:  "10.1.1.1",
+				ClusterIPs: []string{"10.1.1.1"},
+			},
 		}),
-		wantCreateErr: `"service-dev1" already exists`,
+		want: []*corev1.Service{{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "service-dev1",
+				Namespace: "test",
+				Labels:    map[string]string{"pod": "dev1"},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{
+					Name:       "ssh",
+					Protocol:   "TCP",
+					Port:       22,
+					TargetPort: intstr.FromInt(22),
+				}},
+				Selector:                      map[string]string{"app": "dev1"},
+				ClusterIP:                     "10.1.1.1",
+				ClusterIPs:                    []string{"10.1.1.1"},
+				Type:                          "LoadBalancer",
+				AllocateLoadBalancerNodePorts: pointer.Bool(false),
+			},
+		}},
 	},
 	}
 	for _, tt := range tests {
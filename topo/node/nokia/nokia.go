@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -33,6 +32,10 @@ const (
 	// configuration reset is therefore done by reverting to this checkpoint
 	configResetCmd = "/tools system configuration checkpoint initial revert"
 	pushCfgFile    = "/home/admin/kne-push-config"
+	// licenseSecretName is the Secret of the topology namespace that the
+	// srl-controller mounts as the license of SR Linux nodes, using its
+	// <version>.key or all.key key.
+	licenseSecretName = "srlinux-licenses"
 )
 
 var (
@@ -137,9 +140,12 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Certer       = (*Node)(nil)
-	_ node.Resetter     = (*Node)(nil)
-	_ node.ConfigPusher = (*Node)(nil)
+	_ node.Certer        = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
+	_ node.ConfigPusher  = (*Node)(nil)
+	_ node.CLIer         = (*Node)(nil)
+	_ node.Commander     = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
 )
 
 // GenerateSelfSigned generates a self-signed TLS certificate using SR Linux tools command
@@ -171,7 +177,7 @@ func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 	}
 	log.Infof("%s - pod running.", n.Name())
 
-	if err := n.SpawnCLIConn(); err != nil {
+	if err := n.SpawnCLIConn(ctx); err != nil {
 		return err
 	}
 
@@ -185,6 +191,30 @@ func (n *Node) GenerateSelfSigned(ctx context.Context) error {
 	return n.cliConn.Close()
 }
 
+// InstallCert installs the cert issued by the topology CA with gNOI
+// CertificateManagement.Install. SR Linux stores it in a TLS server profile
+// named after the cert name, which the gNMI server of the startup config can
+// reference.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	return n.GNOIInstallCert(ctx, cert)
+}
+
+// validateSecrets returns an error if the node references a Secret other than
+// the license Secret. The Srlinux custom resource has no Secret references,
+// the srl-controller instead mounts the license Secret of the topology
+// namespace, which CreateSecrets creates or copies there, into the node.
+func (n *Node) validateSecrets() error {
+	for _, s := range n.GetProto().GetConfig().GetSecrets() {
+		if s.GetName() != licenseSecretName {
+			return fmt.Errorf("secret %q: SR Linux nodes only support the %q license secret", s.GetName(), licenseSecretName)
+		}
+		if s.GetMountPath() != "" || len(s.GetEnv()) > 0 {
+			return fmt.Errorf("secret %q: the license secret is mounted by the srl-controller, mount_path and env are not supported", s.GetName())
+		}
+	}
+	return nil
+}
+
 // ConfigPush pushes config lines provided in r using scrapligo SendConfig
 func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 	log.Infof("%s - pushing config", n.Name())
@@ -200,7 +230,7 @@ func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
 
 	log.V(1).Infof("config to push:\n%s", cfg)
 
-	err = n.SpawnCLIConn()
+	err = n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -258,6 +288,15 @@ func (n *Node) Create(ctx context.Context) error {
 		return fmt.Errorf("node %s failed to create config-map %w", n.Name(), err)
 	}
 	log.Infof("Created SR Linux node %s configmap", n.Name())
+	if err := n.validateSecrets(); err != nil {
+		return err
+	}
+	if err := n.ValidateCRConfig(false); err != nil {
+		return err
+	}
+	if err := n.CreateSecrets(ctx); err != nil {
+		return err
+	}
 
 	srl := &srlinuxv1.Srlinux{
 		TypeMeta: metav1.TypeMeta{
@@ -334,16 +373,9 @@ func (n *Node) Create(ctx context.Context) error {
 
 func (n *Node) CreateConfig(ctx context.Context) (*corev1.Volume, error) {
 	pb := n.Proto
-	var data []byte
-	switch v := pb.Config.GetConfigData().(type) {
-	case *tpb.Config_File:
-		var err error
-		data, err = os.ReadFile(filepath.Join(n.BasePath, v.File))
-		if err != nil {
-			return nil, err
-		}
-	case *tpb.Config_Data:
-		data = v.Data
+	data, err := n.ReadConfig()
+	if err != nil {
+		return nil, err
 	}
 	if data != nil {
 		cm := &corev1.ConfigMap{
@@ -452,7 +484,7 @@ func defaults(pb *tpb.Node) *tpb.Node {
 func (n *Node) ResetCfg(ctx context.Context) error {
 	log.Infof("%s resetting config", n.Name())
 
-	err := n.SpawnCLIConn()
+	err := n.SpawnCLIConn(ctx)
 	if err != nil {
 		return err
 	}
@@ -476,27 +508,56 @@ func (n *Node) ResetCfg(ctx context.Context) error {
 // to accept inputs.
 // scrapligo options can be provided to this function for a caller to modify scrapligo platform.
 // For example, mock transport can be set via options
-func (n *Node) SpawnCLIConn() error {
+func (n *Node) SpawnCLIConn(ctx context.Context) error {
 	opts := []scrapliutil.Option{
-		scrapliopts.WithAuthBypass(),
 		// jacked up terminal width to allow for long strings
 		// such as cert and key to not break the terminal
 		scrapliopts.WithTermWidth(5000),
 	}
+	authOpts, err := n.CLIAuthOptions(ctx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, authOpts...)
 
 	// add options defined in test package
 	opts = append(opts, n.testOpts...)
 
-	opts = n.PatchCLIConnOpen("kubectl", []string{"sr_cli", "-d"}, opts)
+	opts = n.PatchCLIConnOpen("kubectl", n.CLICommand(), opts)
 
-	var err error
-	n.cliConn, err = n.GetCLIConn(scrapliPlatformName, opts)
+	n.cliConn, err = n.GetCLIConn(ctx, scrapliPlatformName, opts)
 
 	if err != nil {
 		return err
 	}
 
-	return srlinux.WaitSRLMgmtSrvReady(context.TODO(), n.cliConn)
+	return srlinux.WaitSRLMgmtSrvReady(ctx, n.cliConn)
+}
+
+// Operations returns the gNOI operations supported by the node. SR Linux
+// restarts its applications in place on System.Reboot, keeping the container,
+// and installs the CA issued certs of the node with CertificateManagement, so
+// all the operations are supported.
+func (n *Node) Operations() []node.Operation {
+	return n.GNOIOperations(node.AllOperations...)
+}
+
+// CLICommand returns the command that starts the SR Linux CLI in the node container.
+func (n *Node) CLICommand() []string {
+	return []string{"sr_cli", "-d"}
+}
+
+// RunCommands runs cmds on the SR Linux CLI using scrapligo SendCommands.
+func (n *Node) RunCommands(ctx context.Context, cmds []string) ([]*node.CommandResult, error) {
+	log.Infof("%s - running commands", n.Name())
+
+	if err := n.SpawnCLIConn(ctx); err != nil {
+		return nil, err
+	}
+
+	defer n.cliConn.Close()
+
+	return node.SendCommands(ctx, n.cliConn, cmds)
 }
 
 // isConfigDataPresent is a helper function that returns true
@@ -509,6 +570,17 @@ func (n *Node) isConfigDataPresent() bool {
 	return false
 }
 
+// intfName returns the SR Linux name ethernet-1/N of the interface ethN.
+// Other names are returned unchanged.
+func intfName(_ *tpb.Node, name string) (string, error) {
+	i, ok := node.EthIntfNum(name)
+	if !ok {
+		return name, nil
+	}
+	return fmt.Sprintf("ethernet-1/%d", i), nil
+}
+
 func init() {
 	node.Vendor(tpb.Vendor_NOKIA, New)
+	node.VendorIntfNames(tpb.Vendor_NOKIA, intfName)
 }
@@ -520,3 +520,71 @@ func TestEscapeConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSecrets(t *testing.T) {
+	tests := []struct {
+		desc    string
+		secrets []*topopb.SecretRef
+		wantErr string
+	}{{
+		desc: "no secrets",
+	}, {
+		desc:    "license",
+		secrets: []*topopb.SecretRef{{Name: "srlinux-licenses", Namespace: "licenses"}},
+	}, {
+		desc:    "other secret",
+		secrets: []*topopb.SecretRef{{Name: "creds", Env: map[string]string{"PASSWORD": "password"}}},
+		wantErr: `SR Linux nodes only support the "srlinux-licenses" license secret`,
+	}, {
+		desc:    "mounted license",
+		secrets: []*topopb.SecretRef{{Name: "srlinux-licenses", MountPath: "/opt/srlinux/etc"}},
+		wantErr: "mount_path and env are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Node{Impl: &node.Impl{Proto: &topopb.Node{Config: &topopb.Config{Secrets: tt.secrets}}}}
+			if s := errdiff.Substring(n.validateSecrets(), tt.wantErr); s != "" {
+				t.Errorf("validateSecrets() unexpected error: %s", s)
+			}
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	n, err := New(&node.Impl{Proto: &topopb.Node{Name: "srl1"}})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if s := cmp.Diff(node.AllOperations, n.(node.Operator).Operations()); s != "" {
+		t.Errorf("Operations() unexpected diff (-want +got):\n%s", s)
+	}
+}
+
+func TestVendorIntfName(t *testing.T) {
+	tests := []struct {
+		node *topopb.Node
+		name string
+		want string
+	}{
+		{node: &topopb.Node{Vendor: topopb.Vendor_NOKIA}, name: "eth1", want: "ethernet-1/1"},
+		{node: &topopb.Node{Vendor: topopb.Vendor_NOKIA}, name: "eth12", want: "ethernet-1/12"},
+		{node: &topopb.Node{Vendor: topopb.Vendor_NOKIA}, name: "mgmt0", want: "mgmt0"},
+		{
+			node: &topopb.Node{
+				Vendor:     topopb.Vendor_NOKIA,
+				Interfaces: map[string]*topopb.Interface{"eth1": {Name: "custom1"}},
+			},
+			name: "eth1",
+			want: "custom1",
+		},
+	}
+	for _, tt := range tests {
+		got, err := node.VendorIntfName(tt.node, tt.name)
+		if err != nil {
+			t.Fatalf("VendorIntfName(%q) failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("VendorIntfName(%q) got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
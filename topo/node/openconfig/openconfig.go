@@ -150,9 +150,11 @@ type Node struct {
 
 // Add validations for interfaces the node provides
 var (
-	_ node.Certer       = (*Node)(nil)
-	_ node.ConfigPusher = (*Node)(nil)
-	_ node.Resetter     = (*Node)(nil)
+	_ node.Certer        = (*Node)(nil)
+	_ node.CertInstaller = (*Node)(nil)
+	_ node.ConfigPusher  = (*Node)(nil)
+	_ node.Resetter      = (*Node)(nil)
+	_ node.Operator      = (*Node)(nil)
 )
 
 var clientFn = func(c *rest.Config) (clientset.Interface, error) {
@@ -176,6 +178,15 @@ func (n *Node) lemmingCreate(ctx context.Context) error {
 	nodeSpec := n.GetProto()
 	config := nodeSpec.GetConfig()
 	log.Infof("create lemming %q", nodeSpec.Name)
+	if err := n.ValidateCRConfig(true); err != nil {
+		return err
+	}
+	if err := n.ValidateCRServices(); err != nil {
+		return err
+	}
+	if err := n.CreateSecrets(ctx); err != nil {
+		return err
+	}
 
 	ports := map[string]lemmingv1.ServicePort{}
 
@@ -203,7 +214,7 @@ func (n *Node) lemmingCreate(ctx context.Context) error {
 			Image:          config.Image,
 			Command:        config.Command[0],
 			Args:           config.Args,
-			Env:            node.ToEnvVar(config.Env),
+			Env:            append(node.ToEnvVar(config.Env), n.SecretEnv()...),
 			ConfigPath:     config.ConfigPath,
 			ConfigFile:     config.ConfigFile,
 			InitImage:      config.InitImage,
@@ -222,6 +233,19 @@ func (n *Node) lemmingCreate(ctx context.Context) error {
 					KeySize:    int(tls.SelfSigned.KeySize),
 				},
 			}
+		case *tpb.CertificateCfg_CaIssued:
+			// The Lemming CR only supports self-signed certs, which are
+			// served until the CA issued cert is installed.
+			cn := tls.CaIssued.CommonName
+			if cn == "" {
+				cn = nodeSpec.Name
+			}
+			dut.Spec.TLS = &lemmingv1.TLSSpec{
+				SelfSigned: &lemmingv1.SelfSignedSpec{
+					CommonName: cn,
+					KeySize:    int(tls.CaIssued.KeySize),
+				},
+			}
 		}
 	}
 
@@ -247,6 +271,23 @@ func (n *Node) Status(ctx context.Context) (node.Status, error) {
 	}
 }
 
+// StatusDetails returns the status details of the pod of the node, with the
+// phase and message of the Lemming resource of lemming nodes.
+func (n *Node) StatusDetails(ctx context.Context) (*node.StatusDetails, error) {
+	if n.Impl.Proto.Model != modelLemming {
+		return n.Impl.StatusDetails(ctx)
+	}
+	cs, err := clientFn(n.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+	got, err := cs.LemmingV1alpha1().Lemmings(n.Namespace).Get(ctx, n.Name(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return node.CRStatusDetails(ctx, n.Impl, "lemming", string(got.Status.Phase), got.Status.Message), nil
+}
+
 func (n *Node) DefaultNodeConstraints() node.Constraints {
 	switch n.Impl.Proto.Model {
 	case modelLemming:
@@ -297,19 +338,36 @@ func (n *Node) lemmingDelete(ctx context.Context) error {
 	return cs.LemmingV1alpha1().Lemmings(n.Namespace).Delete(ctx, n.Name(), metav1.DeleteOptions{})
 }
 
+// Operations returns the gNOI operations supported by the node. Lemming only
+// implements the reboot and ping RPCs of gNOI System.
+func (n *Node) Operations() []node.Operation {
+	if n.Impl.Proto.Model != modelLemming {
+		return nil
+	}
+	return n.GNOIOperations(node.OperationReboot, node.OperationPing)
+}
+
+// ResetCfg replaces the config of the node with its startup config using gNMI.
 func (n *Node) ResetCfg(ctx context.Context) error {
-	log.Info("ResetCfg is a noop.")
-	return nil
+	return n.GNMIResetCfg(ctx)
 }
 
-func (n *Node) ConfigPush(context.Context, io.Reader) error {
-	return status.Errorf(codes.Unimplemented, "config push is not implemented using gNMI to configure device")
+// ConfigPush pushes config to the node using gNMI.
+func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
+	return n.GNMIConfigPush(ctx, r)
 }
 
 func (n *Node) GenerateSelfSigned(context.Context) error {
 	return status.Errorf(codes.Unimplemented, "certificate generation is not supported")
 }
 
+// InstallCert installs the cert issued by the topology CA with gNOI
+// CertificateManagement.Install, replacing the self-signed cert the lemming
+// controller created for the node.
+func (n *Node) InstallCert(ctx context.Context, cert *node.Cert) error {
+	return n.GNOIInstallCert(ctx, cert)
+}
+
 func lemmingDefaults(pb *tpb.Node) *tpb.Node {
 	defaultNodeClone := proto.Clone(&defaultLemmingNode).(*tpb.Node)
 	if pb.Config == nil {
@@ -17,6 +17,7 @@ package openconfig
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -119,6 +120,47 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		desc: "lemming: ca issued cert",
+		n: &Node{
+			Impl: &node.Impl{
+				Namespace: "default",
+				Proto: &tpb.Node{
+					Name:  "test",
+					Model: modelLemming,
+					Config: &tpb.Config{
+						Command: []string{"/lemming"},
+						Cert: &tpb.CertificateCfg{
+							Config: &tpb.CertificateCfg_CaIssued{
+								CaIssued: &tpb.CAIssuedCertCfg{
+									KeySize: 2048,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		want: &lemmingv1.Lemming{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: lemmingv1.LemmingSpec{
+				Command:        "/lemming",
+				Ports:          map[string]lemmingv1.ServicePort{},
+				InterfaceCount: 1,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{},
+				},
+				TLS: &lemmingv1.TLSSpec{
+					SelfSigned: &lemmingv1.SelfSignedSpec{
+						CommonName: "test",
+						KeySize:    2048,
+					},
+				},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -214,7 +256,7 @@ func TestLemmingStatus(t *testing.T) {
 }
 
 func TestResetCfg(t *testing.T) {
-	n := &Node{}
+	n := &Node{&node.Impl{Proto: &tpb.Node{Name: "dev1"}}}
 	err := n.ResetCfg(context.Background())
 	if err != nil {
 		t.Fatalf("ResetCfg() unexpected error: %v", err)
@@ -222,11 +264,10 @@ func TestResetCfg(t *testing.T) {
 }
 
 func TestConfigPush(t *testing.T) {
-	n := &Node{}
-	err := n.ConfigPush(context.Background(), nil)
-	want := codes.Unimplemented
-	if s, ok := status.FromError(err); !ok || s.Code() != want {
-		t.Fatalf("ConfigPush() unexpected error get %v, want %v", s, want)
+	n := &Node{&node.Impl{Proto: &tpb.Node{Name: "dev1"}}}
+	err := n.ConfigPush(context.Background(), strings.NewReader("hostname dev1"))
+	if s := errdiff.Substring(err, "not valid JSON"); s != "" {
+		t.Fatalf("ConfigPush() unexpected error: %s", s)
 	}
 }
 
@@ -239,6 +280,36 @@ func TestGenerateSelfSigned(t *testing.T) {
 	}
 }
 
+func TestOperations(t *testing.T) {
+	services := map[uint32]*tpb.Service{9339: {Names: []string{"gnmi", "gnoi"}, Inside: 9339}}
+	tests := []struct {
+		desc  string
+		model string
+		svcs  map[uint32]*tpb.Service
+		want  []node.Operation
+	}{{
+		desc:  "lemming",
+		model: modelLemming,
+		svcs:  services,
+		want:  []node.Operation{node.OperationReboot, node.OperationPing},
+	}, {
+		desc:  "lemming without gnoi service",
+		model: modelLemming,
+	}, {
+		desc:  "unknown model",
+		model: "foo",
+		svcs:  services,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Node{&node.Impl{Proto: &tpb.Node{Name: "dev1", Model: tt.model, Services: tt.svcs}}}
+			if s := cmp.Diff(tt.want, n.Operations()); s != "" {
+				t.Errorf("Operations() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
 func TestLemmingDelete(t *testing.T) {
 	tests := []struct {
 		desc        string
@@ -0,0 +1,179 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// PodPatches returns the pod patches of the node: the pod patches of its
+// vendor in the topology followed by those of the node config.
+func (n *Impl) PodPatches() []*tpb.PodPatch {
+	var patches []*tpb.PodPatch
+	for _, vp := range n.Topology.GetVendorPodPatches() {
+		if vp.GetVendor() == n.GetProto().GetVendor() {
+			patches = append(patches, vp.GetPatches()...)
+		}
+	}
+	return append(patches, n.GetProto().GetConfig().GetPodPatches()...)
+}
+
+// readPodPatch returns the patch p in JSON.
+func (n *Impl) readPodPatch(p *tpb.PodPatch) ([]byte, error) {
+	var b []byte
+	switch s := p.GetSource().(type) {
+	case *tpb.PodPatch_Patch:
+		b = []byte(s.Patch)
+	case *tpb.PodPatch_File:
+		path := s.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(n.BasePath, path)
+		}
+		var err error
+		if b, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("patch cannot be empty")
+	}
+	return yaml.YAMLToJSON(b)
+}
+
+// applyPodPatch returns the pod in JSON after applying p to it.
+func (n *Impl) applyPodPatch(pod []byte, p *tpb.PodPatch) ([]byte, error) {
+	patch, err := n.readPodPatch(p)
+	if err != nil {
+		return nil, err
+	}
+	switch p.GetType() {
+	case tpb.PodPatch_STRATEGIC_MERGE:
+		return strategicpatch.StrategicMergePatch(pod, patch, corev1.Pod{})
+	case tpb.PodPatch_JSON:
+		jp, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, err
+		}
+		return jp.Apply(pod)
+	default:
+		return nil, fmt.Errorf("invalid patch type %s", p.GetType())
+	}
+}
+
+// patchPod returns pod after applying patches to it. Fields unknown to the
+// pod spec are rejected.
+func (n *Impl) patchPod(pod *corev1.Pod, patches []*tpb.PodPatch) (*corev1.Pod, error) {
+	b, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range patches {
+		if b, err = n.applyPodPatch(b, p); err != nil {
+			return nil, fmt.Errorf("pod patch %d: %w", i, err)
+		}
+	}
+	return decodePod(b)
+}
+
+// decodePod returns the pod in JSON b, rejecting fields unknown to the pod
+// spec.
+func decodePod(b []byte) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(pod); err != nil {
+		return nil, fmt.Errorf("invalid patched pod: %w", err)
+	}
+	return pod, nil
+}
+
+// dryRunPod validates pod by creating it in dry-run mode.
+func (n *Impl) dryRunPod(ctx context.Context, pod *corev1.Pod) error {
+	if _, err := n.KubeClient.CoreV1().Pods(n.Namespace).Create(ctx, pod, metav1.CreateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	}); err != nil {
+		return fmt.Errorf("patched pod failed validation: %w", err)
+	}
+	return nil
+}
+
+// ApplyPodPatches applies the pod patches of the node to pod. Fields unknown
+// to the pod spec are rejected and the patched pod is validated by creating
+// it in dry-run mode, so that errors in patches fail before the pod is
+// created.
+func (n *Impl) ApplyPodPatches(ctx context.Context, pod *corev1.Pod) error {
+	patches := n.PodPatches()
+	if len(patches) == 0 {
+		return nil
+	}
+	patched, err := n.patchPod(pod, patches)
+	if err != nil {
+		return err
+	}
+	if err := n.dryRunPod(ctx, patched); err != nil {
+		return err
+	}
+	log.Infof("Applied %d pod patches to node %s", len(patches), n.Name())
+	*pod = *patched
+	return nil
+}
+
+// ValidatePodPatches checks the pod patches of the node without the pod they
+// are applied to, which vendors only build when the node is created: patches
+// must be readable and well formed, and strategic merge patches must only set
+// fields of the pod spec. ApplyPodPatches applies them to the pod of the node
+// and validates the patched pod in dry-run mode.
+func (n *Impl) ValidatePodPatches() error {
+	for i, p := range n.PodPatches() {
+		if err := n.validatePodPatch(p); err != nil {
+			return fmt.Errorf("pod patch %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validatePodPatch checks p. Strategic merge patches are applied to an empty
+// pod, so that only the fields they set are checked.
+func (n *Impl) validatePodPatch(p *tpb.PodPatch) error {
+	patch, err := n.readPodPatch(p)
+	if err != nil {
+		return err
+	}
+	switch p.GetType() {
+	case tpb.PodPatch_STRATEGIC_MERGE:
+		b, err := strategicpatch.StrategicMergePatch([]byte("{}"), patch, corev1.Pod{})
+		if err != nil {
+			return err
+		}
+		_, err = decodePod(b)
+		return err
+	case tpb.PodPatch_JSON:
+		_, err := jsonpatch.DecodePatch(patch)
+		return err
+	default:
+		return fmt.Errorf("invalid patch type %s", p.GetType())
+	}
+}
@@ -0,0 +1,216 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApplyPodPatches(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "patch.yaml"), []byte("spec:\n  hostNetwork: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	pod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1", Namespace: "test"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "dev1",
+					Image: "alpine:latest",
+					Env:   []corev1.EnvVar{{Name: "A", Value: "1"}},
+				}},
+			},
+		}
+	}
+	tests := []struct {
+		desc          string
+		patches       []*topopb.PodPatch
+		vendorPatches []*topopb.VendorPodPatches
+		dryRunErr     error
+		want          *corev1.Pod
+		wantDryRun    bool
+		wantErr       string
+	}{{
+		desc: "no patches",
+		want: pod(),
+	}, {
+		desc: "strategic merge",
+		patches: []*topopb.PodPatch{{
+			Source: &topopb.PodPatch_Patch{Patch: `
+spec:
+  dnsPolicy: None
+  containers:
+  - name: dev1
+    env:
+    - name: B
+      value: "2"
+    securityContext:
+      privileged: true
+`},
+		}},
+		want: func() *corev1.Pod {
+			p := pod()
+			p.Spec.DNSPolicy = corev1.DNSNone
+			p.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "B", Value: "2"}, {Name: "A", Value: "1"}}
+			privileged := true
+			p.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+			return p
+		}(),
+		wantDryRun: true,
+	}, {
+		desc: "vendor and node patches",
+		vendorPatches: []*topopb.VendorPodPatches{{
+			Vendor:  topopb.Vendor_ALPINE,
+			Patches: []*topopb.PodPatch{{Source: &topopb.PodPatch_File{File: "patch.yaml"}}},
+		}, {
+			Vendor:  topopb.Vendor_HOST,
+			Patches: []*topopb.PodPatch{{Source: &topopb.PodPatch_Patch{Patch: `{"spec": {"hostPID": true}}`}}},
+		}},
+		patches: []*topopb.PodPatch{{
+			Type:   topopb.PodPatch_JSON,
+			Source: &topopb.PodPatch_Patch{Patch: `[{"op": "replace", "path": "/spec/containers/0/image", "value": "alpine:3"}]`},
+		}},
+		want: func() *corev1.Pod {
+			p := pod()
+			p.Spec.HostNetwork = true
+			p.Spec.Containers[0].Image = "alpine:3"
+			return p
+		}(),
+		wantDryRun: true,
+	}, {
+		desc: "unknown field",
+		patches: []*topopb.PodPatch{{
+			Source: &topopb.PodPatch_Patch{Patch: "spec:\n  hostNetwrok: true\n"},
+		}},
+		wantErr: `unknown field "hostNetwrok"`,
+	}, {
+		desc: "invalid json patch",
+		patches: []*topopb.PodPatch{{
+			Type:   topopb.PodPatch_JSON,
+			Source: &topopb.PodPatch_Patch{Patch: `[{"op": "remove", "path": "/spec/dne"}]`},
+		}},
+		wantErr: "pod patch 0",
+	}, {
+		desc:    "empty patch",
+		patches: []*topopb.PodPatch{{}},
+		wantErr: "patch cannot be empty",
+	}, {
+		desc:    "file dne",
+		patches: []*topopb.PodPatch{{Source: &topopb.PodPatch_File{File: "dne.yaml"}}},
+		wantErr: "no such file",
+	}, {
+		desc: "dry-run failure",
+		patches: []*topopb.PodPatch{{
+			Source: &topopb.PodPatch_Patch{Patch: "spec:\n  dnsPolicy: Bogus\n"},
+		}},
+		dryRunErr:  fmt.Errorf("spec.dnsPolicy: Unsupported value"),
+		wantDryRun: true,
+		wantErr:    "failed validation",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cs := kfake.NewSimpleClientset()
+			var dryRun bool
+			cs.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				ca := action.(k8stesting.CreateActionImpl)
+				if s := cmp.Diff([]string{metav1.DryRunAll}, ca.CreateOptions.DryRun); s != "" {
+					t.Errorf("ApplyPodPatches() unexpected create options diff (-want +got):\n%s", s)
+				}
+				dryRun = true
+				return true, ca.GetObject(), tt.dryRunErr
+			})
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: cs,
+				BasePath:   dir,
+				Topology:   &topopb.Topology{VendorPodPatches: tt.vendorPatches},
+				Proto: &topopb.Node{
+					Name:   "dev1",
+					Vendor: topopb.Vendor_ALPINE,
+					Config: &topopb.Config{PodPatches: tt.patches},
+				},
+			}
+			got := pod()
+			err := n.ApplyPodPatches(ctx, got)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("ApplyPodPatches() failed: %s", s)
+			}
+			if dryRun != tt.wantDryRun {
+				t.Errorf("ApplyPodPatches() dry-run: got %v, want %v", dryRun, tt.wantDryRun)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("ApplyPodPatches() unexpected pod diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestValidatePodPatches(t *testing.T) {
+	tests := []struct {
+		desc    string
+		patches []*topopb.PodPatch
+		wantErr string
+	}{{
+		desc: "no patches",
+	}, {
+		desc: "strategic merge",
+		patches: []*topopb.PodPatch{{
+			Source: &topopb.PodPatch_Patch{Patch: "spec:\n  hostNetwork: true\n"},
+		}},
+	}, {
+		desc: "json patch of a vendor container",
+		patches: []*topopb.PodPatch{{
+			Type:   topopb.PodPatch_JSON,
+			Source: &topopb.PodPatch_Patch{Patch: `[{"op": "remove", "path": "/spec/containers/0/securityContext/privileged"}]`},
+		}},
+	}, {
+		desc: "unknown field",
+		patches: []*topopb.PodPatch{{
+			Source: &topopb.PodPatch_Patch{Patch: "spec:\n  hostNetwrok: true\n"},
+		}},
+		wantErr: `unknown field "hostNetwrok"`,
+	}, {
+		desc: "invalid json patch",
+		patches: []*topopb.PodPatch{{
+			Type:   topopb.PodPatch_JSON,
+			Source: &topopb.PodPatch_Patch{Patch: `{"op": "remove"}`},
+		}},
+		wantErr: "pod patch 0",
+	}, {
+		desc:    "empty patch",
+		patches: []*topopb.PodPatch{{}},
+		wantErr: "patch cannot be empty",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace: "test",
+				Proto: &topopb.Node{
+					Name:   "dev1",
+					Vendor: topopb.Vendor_ALPINE,
+					Config: &topopb.Config{PodPatches: tt.patches},
+				},
+			}
+			err := n.ValidatePodPatches()
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("ValidatePodPatches() failed: %s", s)
+			}
+		})
+	}
+}
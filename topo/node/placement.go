@@ -0,0 +1,260 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"fmt"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const defaultTopologyKey = "kubernetes.io/hostname"
+
+var (
+	selectorOperators = map[tpb.LabelRequirement_Operator]metav1.LabelSelectorOperator{
+		tpb.LabelRequirement_IN:             metav1.LabelSelectorOpIn,
+		tpb.LabelRequirement_NOT_IN:         metav1.LabelSelectorOpNotIn,
+		tpb.LabelRequirement_EXISTS:         metav1.LabelSelectorOpExists,
+		tpb.LabelRequirement_DOES_NOT_EXIST: metav1.LabelSelectorOpDoesNotExist,
+	}
+	nodeSelectorOperators = map[tpb.LabelRequirement_Operator]corev1.NodeSelectorOperator{
+		tpb.LabelRequirement_IN:             corev1.NodeSelectorOpIn,
+		tpb.LabelRequirement_NOT_IN:         corev1.NodeSelectorOpNotIn,
+		tpb.LabelRequirement_EXISTS:         corev1.NodeSelectorOpExists,
+		tpb.LabelRequirement_DOES_NOT_EXIST: corev1.NodeSelectorOpDoesNotExist,
+		tpb.LabelRequirement_GT:             corev1.NodeSelectorOpGt,
+		tpb.LabelRequirement_LT:             corev1.NodeSelectorOpLt,
+	}
+	taintEffects = map[string]corev1.TaintEffect{
+		"":                                   "",
+		string(corev1.TaintEffectNoSchedule): corev1.TaintEffectNoSchedule,
+		string(corev1.TaintEffectPreferNoSchedule): corev1.TaintEffectPreferNoSchedule,
+		string(corev1.TaintEffectNoExecute):        corev1.TaintEffectNoExecute,
+	}
+)
+
+func validWeight(w int32) error {
+	if w < 0 || w > 100 {
+		return fmt.Errorf("weight %d must be in the range 0-100", w)
+	}
+	return nil
+}
+
+func nodeSelectorTerm(t *tpb.NodeAffinityTerm) (corev1.NodeSelectorTerm, error) {
+	var term corev1.NodeSelectorTerm
+	if len(t.GetMatchExpressions()) == 0 {
+		return term, fmt.Errorf("node affinity term has no match expressions")
+	}
+	for _, r := range t.GetMatchExpressions() {
+		op, ok := nodeSelectorOperators[r.GetOperator()]
+		if !ok {
+			return term, fmt.Errorf("label %q: invalid operator %s", r.GetKey(), r.GetOperator())
+		}
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      r.GetKey(),
+			Operator: op,
+			Values:   r.GetValues(),
+		})
+	}
+	return term, nil
+}
+
+func labelSelector(labels map[string]string, reqs []*tpb.LabelRequirement) (*metav1.LabelSelector, error) {
+	s := &metav1.LabelSelector{MatchLabels: labels}
+	for _, r := range reqs {
+		op, ok := selectorOperators[r.GetOperator()]
+		if !ok {
+			return nil, fmt.Errorf("label %q: operator %s is not supported for pod selection", r.GetKey(), r.GetOperator())
+		}
+		s.MatchExpressions = append(s.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      r.GetKey(),
+			Operator: op,
+			Values:   r.GetValues(),
+		})
+	}
+	if len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0 {
+		return nil, fmt.Errorf("pod affinity term selects no pods")
+	}
+	return s, nil
+}
+
+// podAffinityTerms returns the required and preferred terms of ts.
+func podAffinityTerms(ts []*tpb.PodAffinityTerm) ([]corev1.PodAffinityTerm, []corev1.WeightedPodAffinityTerm, error) {
+	var required []corev1.PodAffinityTerm
+	var preferred []corev1.WeightedPodAffinityTerm
+	for _, t := range ts {
+		if err := validWeight(t.GetWeight()); err != nil {
+			return nil, nil, err
+		}
+		s, err := labelSelector(t.GetMatchLabels(), t.GetMatchExpressions())
+		if err != nil {
+			return nil, nil, err
+		}
+		term := corev1.PodAffinityTerm{
+			LabelSelector: s,
+			TopologyKey:   t.GetTopologyKey(),
+		}
+		if term.TopologyKey == "" {
+			term.TopologyKey = defaultTopologyKey
+		}
+		if t.GetWeight() == 0 {
+			required = append(required, term)
+			continue
+		}
+		preferred = append(preferred, corev1.WeightedPodAffinityTerm{
+			Weight:          t.GetWeight(),
+			PodAffinityTerm: term,
+		})
+	}
+	return required, preferred, nil
+}
+
+func toleration(t *tpb.Toleration) (corev1.Toleration, error) {
+	effect, ok := taintEffects[t.GetEffect()]
+	if !ok {
+		return corev1.Toleration{}, fmt.Errorf("toleration %q: invalid effect %q", t.GetKey(), t.GetEffect())
+	}
+	tol := corev1.Toleration{
+		Key:      t.GetKey(),
+		Operator: corev1.TolerationOpEqual,
+		Value:    t.GetValue(),
+		Effect:   effect,
+	}
+	if t.GetOperator() == tpb.Toleration_EXISTS {
+		if t.GetValue() != "" {
+			return corev1.Toleration{}, fmt.Errorf("toleration %q: value must be empty with operator EXISTS", t.GetKey())
+		}
+		tol.Operator = corev1.TolerationOpExists
+	}
+	if s := t.GetTolerationSeconds(); s != 0 {
+		if effect != corev1.TaintEffectNoExecute {
+			return corev1.Toleration{}, fmt.Errorf("toleration %q: toleration seconds require effect NoExecute", t.GetKey())
+		}
+		tol.TolerationSeconds = &s
+	}
+	return tol, nil
+}
+
+func (n *Impl) topologySpread(t *tpb.TopologySpread) (corev1.TopologySpreadConstraint, error) {
+	c := corev1.TopologySpreadConstraint{
+		MaxSkew:           t.GetMaxSkew(),
+		TopologyKey:       t.GetTopologyKey(),
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: t.GetMatchLabels()},
+	}
+	switch {
+	case c.MaxSkew < 0:
+		return c, fmt.Errorf("max skew %d must be positive", c.MaxSkew)
+	case c.MaxSkew == 0:
+		c.MaxSkew = 1
+	}
+	if c.TopologyKey == "" {
+		c.TopologyKey = defaultTopologyKey
+	}
+	if t.GetRequired() {
+		c.WhenUnsatisfiable = corev1.DoNotSchedule
+	}
+	if len(c.LabelSelector.MatchLabels) == 0 {
+		c.LabelSelector.MatchLabels = map[string]string{"topo": n.Namespace}
+	}
+	return c, nil
+}
+
+// AddPlacement applies the placement of the node to pod. The node selector
+// and affinity terms are added to those already set on the pod, the pod
+// anti-affinity of the pod is replaced if the placement sets one.
+func (n *Impl) AddPlacement(pod *corev1.Pod) error {
+	p := n.GetProto().GetPlacement()
+	if p == nil {
+		return nil
+	}
+	for k, v := range p.GetNodeSelector() {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid node selector label %q: %v", k, errs)
+		}
+		if pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = map[string]string{}
+		}
+		pod.Spec.NodeSelector[k] = v
+	}
+	if len(p.GetNodeAffinity())+len(p.GetPodAffinity())+len(p.GetPodAntiAffinity()) > 0 && pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	for _, t := range p.GetNodeAffinity() {
+		if err := validWeight(t.GetWeight()); err != nil {
+			return err
+		}
+		term, err := nodeSelectorTerm(t)
+		if err != nil {
+			return err
+		}
+		a := pod.Spec.Affinity.NodeAffinity
+		if a == nil {
+			a = &corev1.NodeAffinity{}
+			pod.Spec.Affinity.NodeAffinity = a
+		}
+		if t.GetWeight() != 0 {
+			a.PreferredDuringSchedulingIgnoredDuringExecution = append(a.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+				Weight:     t.GetWeight(),
+				Preference: term,
+			})
+			continue
+		}
+		if a.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			a.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+		}
+		a.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(a.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, term)
+	}
+	if len(p.GetPodAffinity()) > 0 {
+		required, preferred, err := podAffinityTerms(p.GetPodAffinity())
+		if err != nil {
+			return fmt.Errorf("pod affinity: %w", err)
+		}
+		a := pod.Spec.Affinity.PodAffinity
+		if a == nil {
+			a = &corev1.PodAffinity{}
+			pod.Spec.Affinity.PodAffinity = a
+		}
+		a.RequiredDuringSchedulingIgnoredDuringExecution = append(a.RequiredDuringSchedulingIgnoredDuringExecution, required...)
+		a.PreferredDuringSchedulingIgnoredDuringExecution = append(a.PreferredDuringSchedulingIgnoredDuringExecution, preferred...)
+	}
+	if len(p.GetPodAntiAffinity()) > 0 {
+		required, preferred, err := podAffinityTerms(p.GetPodAntiAffinity())
+		if err != nil {
+			return fmt.Errorf("pod anti-affinity: %w", err)
+		}
+		pod.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	for _, t := range p.GetTolerations() {
+		tol, err := toleration(t)
+		if err != nil {
+			return err
+		}
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, tol)
+	}
+	for _, t := range p.GetTopologySpread() {
+		c, err := n.topologySpread(t)
+		if err != nil {
+			return fmt.Errorf("topology spread: %w", err)
+		}
+		pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, c)
+	}
+	return nil
+}
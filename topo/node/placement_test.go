@@ -0,0 +1,241 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddPlacement(t *testing.T) {
+	seconds := int64(30)
+	antiAffinity := &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "topo",
+						Operator: "In",
+						Values:   []string{"test"},
+					}},
+				},
+				TopologyKey: "kubernetes.io/hostname",
+			},
+		}},
+	}
+	tests := []struct {
+		desc      string
+		placement *topopb.Placement
+		want      corev1.PodSpec
+		wantErr   string
+	}{{
+		desc: "no placement",
+		want: corev1.PodSpec{
+			NodeSelector: map[string]string{},
+			Affinity:     &corev1.Affinity{PodAntiAffinity: antiAffinity},
+		},
+	}, {
+		desc: "node selector and node affinity",
+		placement: &topopb.Placement{
+			NodeSelector: map[string]string{"pool": "fast"},
+			NodeAffinity: []*topopb.NodeAffinityTerm{{
+				MatchExpressions: []*topopb.LabelRequirement{{
+					Key:    "zone",
+					Values: []string{"a", "b"},
+				}},
+			}, {
+				MatchExpressions: []*topopb.LabelRequirement{{
+					Key:      "gpu",
+					Operator: topopb.LabelRequirement_DOES_NOT_EXIST,
+				}},
+				Weight: 10,
+			}},
+		},
+		want: corev1.PodSpec{
+			NodeSelector: map[string]string{"pool": "fast"},
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key:      "zone",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{"a", "b"},
+							}},
+						}},
+					},
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+						Weight: 10,
+						Preference: corev1.NodeSelectorTerm{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key:      "gpu",
+								Operator: corev1.NodeSelectorOpDoesNotExist,
+							}},
+						},
+					}},
+				},
+				PodAntiAffinity: antiAffinity,
+			},
+		},
+	}, {
+		desc: "pod affinity and anti-affinity",
+		placement: &topopb.Placement{
+			PodAffinity: []*topopb.PodAffinityTerm{{
+				MatchLabels: map[string]string{"app": "dev2"},
+			}},
+			PodAntiAffinity: []*topopb.PodAffinityTerm{{
+				MatchLabels: map[string]string{"topo": "test"},
+				TopologyKey: "topology.kubernetes.io/zone",
+				Weight:      50,
+			}},
+		},
+		want: corev1.PodSpec{
+			NodeSelector: map[string]string{},
+			Affinity: &corev1.Affinity{
+				PodAffinity: &corev1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "dev2"}},
+						TopologyKey:   "kubernetes.io/hostname",
+					}},
+				},
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+						Weight: 50,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"topo": "test"}},
+							TopologyKey:   "topology.kubernetes.io/zone",
+						},
+					}},
+				},
+			},
+		},
+	}, {
+		desc: "tolerations and topology spread",
+		placement: &topopb.Placement{
+			Tolerations: []*topopb.Toleration{{
+				Key:    "dedicated",
+				Value:  "kne",
+				Effect: "NoSchedule",
+			}, {
+				Key:               "node.kubernetes.io/unreachable",
+				Operator:          topopb.Toleration_EXISTS,
+				Effect:            "NoExecute",
+				TolerationSeconds: 30,
+			}},
+			TopologySpread: []*topopb.TopologySpread{{
+				Required: true,
+			}},
+		},
+		want: corev1.PodSpec{
+			NodeSelector: map[string]string{},
+			Affinity:     &corev1.Affinity{PodAntiAffinity: antiAffinity},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "kne",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}, {
+				Key:               "node.kubernetes.io/unreachable",
+				Operator:          corev1.TolerationOpExists,
+				Effect:            corev1.TaintEffectNoExecute,
+				TolerationSeconds: &seconds,
+			}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "kubernetes.io/hostname",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"topo": "test"}},
+			}},
+		},
+	}, {
+		desc: "invalid node selector",
+		placement: &topopb.Placement{
+			NodeSelector: map[string]string{"bad label": "a"},
+		},
+		wantErr: `invalid node selector label "bad label"`,
+	}, {
+		desc: "empty node affinity term",
+		placement: &topopb.Placement{
+			NodeAffinity: []*topopb.NodeAffinityTerm{{Weight: 1}},
+		},
+		wantErr: "has no match expressions",
+	}, {
+		desc: "invalid weight",
+		placement: &topopb.Placement{
+			PodAffinity: []*topopb.PodAffinityTerm{{
+				MatchLabels: map[string]string{"app": "dev2"},
+				Weight:      101,
+			}},
+		},
+		wantErr: "weight 101 must be in the range 0-100",
+	}, {
+		desc: "unsupported pod selector operator",
+		placement: &topopb.Placement{
+			PodAntiAffinity: []*topopb.PodAffinityTerm{{
+				MatchExpressions: []*topopb.LabelRequirement{{
+					Key:      "rank",
+					Operator: topopb.LabelRequirement_GT,
+					Values:   []string{"1"},
+				}},
+			}},
+		},
+		wantErr: "not supported for pod selection",
+	}, {
+		desc: "pod affinity selects nothing",
+		placement: &topopb.Placement{
+			PodAffinity: []*topopb.PodAffinityTerm{{}},
+		},
+		wantErr: "selects no pods",
+	}, {
+		desc: "invalid effect",
+		placement: &topopb.Placement{
+			Tolerations: []*topopb.Toleration{{Key: "a", Effect: "Never"}},
+		},
+		wantErr: `invalid effect "Never"`,
+	}, {
+		desc: "toleration seconds without NoExecute",
+		placement: &topopb.Placement{
+			Tolerations: []*topopb.Toleration{{Key: "a", TolerationSeconds: 10}},
+		},
+		wantErr: "require effect NoExecute",
+	}, {
+		desc: "negative max skew",
+		placement: &topopb.Placement{
+			TopologySpread: []*topopb.TopologySpread{{MaxSkew: -1}},
+		},
+		wantErr: "max skew -1 must be positive",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace: "test",
+				Proto: &topopb.Node{
+					Name:      "dev1",
+					Placement: tt.placement,
+				},
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{},
+					Affinity: &corev1.Affinity{
+						PodAntiAffinity: antiAffinity,
+					},
+				},
+			}
+			err := n.AddPlacement(pod)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("AddPlacement() failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, pod.Spec); s != "" {
+				t.Errorf("AddPlacement() unexpected pod spec diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
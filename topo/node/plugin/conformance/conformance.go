@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance is a test suite checking that a node plugin follows
+// the plugin protocol. Plugin authors run it from a Go test of their plugin:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, "/path/to/kne-node-example", &conformance.Options{})
+//	}
+package conformance
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	ppb "github.com/openconfig/kne/proto/plugin"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node/plugin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// Options of the conformance suite.
+type Options struct {
+	// Node the plugin is called for. Defaults to a node named
+	// "conformance" with two interfaces.
+	Node *tpb.Node
+	// Namespace and Kubecfg are passed to the plugin in every request.
+	Namespace string
+	Kubecfg   string
+	// Lifecycle runs the methods that change the cluster: the node is
+	// created, waited for, optionally configured and deleted. It requires
+	// a cluster with meshnet installed. Methods left unimplemented by the
+	// plugin are skipped, since KNE provides them.
+	Lifecycle bool
+	// Config is pushed to the node by the lifecycle checks if set.
+	Config []byte
+	// Timeout for the node to be running in the lifecycle checks. Defaults
+	// to 5 minutes.
+	Timeout time.Duration
+}
+
+func (o *Options) node() *tpb.Node {
+	if o.Node != nil {
+		return proto.Clone(o.Node).(*tpb.Node)
+	}
+	return &tpb.Node{
+		Name: "conformance",
+		Interfaces: map[string]*tpb.Interface{
+			"eth1": {Name: "eth1", PeerName: "peer", PeerIntName: "eth1", Uid: 1},
+			"eth2": {Name: "eth2", PeerName: "peer", PeerIntName: "eth2", Uid: 2},
+		},
+	}
+}
+
+// Run runs the conformance suite against the plugin executable at path.
+func Run(t *testing.T, path string, opts *Options) {
+	t.Helper()
+	if opts == nil {
+		opts = &Options{}
+	}
+	ctx := context.Background()
+	call := func(t *testing.T, m ppb.Method, n *tpb.Node, config []byte) *ppb.Response {
+		t.Helper()
+		resp, err := plugin.Call(ctx, path, &ppb.Request{
+			Version:   plugin.Version,
+			Method:    m,
+			Node:      n,
+			Namespace: opts.Namespace,
+			Kubecfg:   opts.Kubecfg,
+			Config:    config,
+		})
+		if err != nil {
+			t.Fatalf("%s failed: %v", plugin.MethodArg(m), err)
+		}
+		return resp
+	}
+
+	t.Run("info", func(t *testing.T) {
+		resp := call(t, ppb.Method_INFO, nil, nil)
+		if resp.GetVersion() != plugin.Version {
+			t.Errorf("info returned version %d, want %d", resp.GetVersion(), plugin.Version)
+		}
+		if want := strings.TrimPrefix(filepath.Base(path), plugin.Prefix); !strings.HasPrefix(filepath.Base(path), plugin.Prefix) || resp.GetName() != want {
+			t.Errorf("info returned name %q for executable %q, want name matching %s<name>", resp.GetName(), filepath.Base(path), plugin.Prefix)
+		}
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		if err := exec.CommandContext(ctx, path, "unknown").Run(); err == nil {
+			t.Errorf("plugin exited successfully for an unknown method, want non-zero exit status")
+		}
+	})
+
+	n := opts.node()
+	t.Run("defaults", func(t *testing.T) {
+		resp := call(t, ppb.Method_DEFAULTS, n, nil)
+		if resp.GetUnimplemented() {
+			return
+		}
+		got := resp.GetNode()
+		if got.GetName() != n.GetName() {
+			t.Fatalf("defaults renamed node %q to %q", n.GetName(), got.GetName())
+		}
+		if s := cmp.Diff(n.GetInterfaces(), got.GetInterfaces(), protocmp.Transform()); s != "" {
+			t.Errorf("defaults changed the interfaces of the node (-want +got):\n%s", s)
+		}
+		again := call(t, ppb.Method_DEFAULTS, got, nil)
+		if s := cmp.Diff(got, again.GetNode(), protocmp.Transform()); s != "" {
+			t.Errorf("defaults is not idempotent (-first +second):\n%s", s)
+		}
+		n = got
+	})
+
+	t.Run("topology specs", func(t *testing.T) {
+		resp := call(t, ppb.Method_TOPOLOGY_SPECS, n, nil)
+		if resp.GetUnimplemented() {
+			return
+		}
+		if len(resp.GetTopologySpecs()) == 0 {
+			t.Errorf("topology_specs returned no specs")
+		}
+		for _, s := range resp.GetTopologySpecs() {
+			if s.GetName() == "" {
+				t.Errorf("topology_specs returned a spec without a name: %v", s)
+			}
+		}
+	})
+
+	if !opts.Lifecycle {
+		return
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	t.Run("lifecycle", func(t *testing.T) {
+		call(t, ppb.Method_CREATE, n, nil)
+		defer call(t, ppb.Method_DELETE, n, nil)
+		deadline := time.Now().Add(timeout)
+		for {
+			resp := call(t, ppb.Method_STATUS, n, nil)
+			if resp.GetUnimplemented() || resp.GetStatus() == ppb.Status_RUNNING {
+				break
+			}
+			if resp.GetStatus() == ppb.Status_FAILED {
+				t.Fatalf("node %q failed", n.GetName())
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("node %q not running after %v, status %s", n.GetName(), timeout, resp.GetStatus())
+			}
+			time.Sleep(time.Second)
+		}
+		if len(opts.Config) != 0 {
+			call(t, ppb.Method_CONFIG_PUSH, n, opts.Config)
+			call(t, ppb.Method_RESET_CFG, n, nil)
+		}
+		if n.GetConfig().GetCert() != nil {
+			call(t, ppb.Method_GENERATE_SELF_SIGNED, n, nil)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin implements nodes with out-of-tree plugins, so that vendors
+// can be integrated without recompiling KNE. A plugin is an executable named
+// kne-node-<name> that speaks the protocol defined in proto/plugin.proto.
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	ppb "github.com/openconfig/kne/proto/plugin"
+	topologyv1 "github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/homedir"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// Version is the version of the plugin protocol.
+	Version = 1
+	// Prefix is the prefix of the name of plugin executables.
+	Prefix = "kne-node-"
+	// PathEnv is the environment variable with the list of directories
+	// searched for plugins, separated by the OS path list separator.
+	PathEnv = "KNE_PLUGIN_PATH"
+)
+
+var nameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// loadTimeout bounds the INFO and DEFAULTS calls made to load a node, as
+// nodes are loaded by all commands, including those without a deadline.
+var loadTimeout = 30 * time.Second
+
+// waitDelay bounds the time to wait for the output of a plugin once it is
+// killed, such as when child processes of the plugin keep its output open.
+const waitDelay = 5 * time.Second
+
+// Dirs returns the directories searched for plugins in order: the
+// directories of KNE_PLUGIN_PATH followed by ~/.config/kne/plugins.
+func Dirs() []string {
+	var dirs []string
+	for _, d := range filepath.SplitList(os.Getenv(PathEnv)) {
+		if d != "" {
+			dirs = append(dirs, d)
+		}
+	}
+	if home := homedir.HomeDir(); home != "" {
+		dirs = append(dirs, filepath.Join(home, ".config", "kne", "plugins"))
+	}
+	return dirs
+}
+
+// Find returns the path of the executable of the named plugin.
+func Find(name string) (string, error) {
+	if !nameRE.MatchString(name) {
+		return "", fmt.Errorf("invalid plugin name %q", name)
+	}
+	dirs := Dirs()
+	for _, d := range dirs {
+		p := filepath.Join(d, Prefix+name)
+		fi, err := os.Stat(p)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		if fi.Mode()&0111 == 0 {
+			return "", fmt.Errorf("plugin %q is not executable", p)
+		}
+		return p, nil
+	}
+	return "", fmt.Errorf("plugin %q not found in %v", Prefix+name, dirs)
+}
+
+// MethodArg returns the command line argument of m.
+func MethodArg(m ppb.Method) string {
+	return strings.ToLower(m.String())
+}
+
+// Call runs the plugin executable at path with req and returns its
+// response. An error is returned if the plugin fails to run or responds with
+// an error.
+func Call(ctx context.Context, path string, req *ppb.Request) (*ppb.Response, error) {
+	arg := MethodArg(req.GetMethod())
+	b, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, arg)
+	cmd.Stdin = bytes.NewReader(b)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+	err = cmd.Run()
+	logStderr(filepath.Base(path), &stderr)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s %s failed: %w", filepath.Base(path), arg, err)
+	}
+	resp := &ppb.Response{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("plugin %s %s returned an invalid response: %w", filepath.Base(path), arg, err)
+	}
+	if resp.GetError() != "" {
+		return nil, fmt.Errorf("plugin %s %s: %s", filepath.Base(path), arg, resp.GetError())
+	}
+	return resp, nil
+}
+
+func logStderr(name string, r io.Reader) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		log.Infof("%s: %s", name, s.Text())
+	}
+}
+
+// Node is a node implemented by a plugin. Methods not implemented by the
+// plugin use the default implementation of node.Impl.
+type Node struct {
+	*node.Impl
+	path string
+}
+
+var (
+	_ node.ConfigPusher = (*Node)(nil)
+	_ node.Resetter     = (*Node)(nil)
+	_ node.Certer       = (*Node)(nil)
+)
+
+// New returns a node implemented by the plugin named by the plugin field of
+// the node, with the defaults of the plugin applied.
+func New(nodeImpl *node.Impl) (node.Node, error) {
+	if nodeImpl == nil {
+		return nil, fmt.Errorf("nodeImpl cannot be nil")
+	}
+	if nodeImpl.Proto == nil {
+		return nil, fmt.Errorf("nodeImpl.Proto cannot be nil")
+	}
+	path, err := Find(nodeImpl.Proto.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{Impl: nodeImpl, path: path}
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+	resp, err := n.call(ctx, ppb.Method_INFO, nil)
+	if err != nil {
+		return nil, n.loadError(ctx, err)
+	}
+	if resp.GetVersion() != Version {
+		return nil, fmt.Errorf("plugin %q speaks protocol version %d, want %d", nodeImpl.Proto.GetPlugin(), resp.GetVersion(), Version)
+	}
+	resp, err = n.call(ctx, ppb.Method_DEFAULTS, nil)
+	if err != nil {
+		return nil, n.loadError(ctx, err)
+	}
+	if !resp.GetUnimplemented() && resp.GetNode() != nil {
+		if got, want := resp.GetNode().GetName(), nodeImpl.Proto.GetName(); got != want {
+			return nil, fmt.Errorf("plugin %q renamed node %q to %q", nodeImpl.Proto.GetPlugin(), want, got)
+		}
+		n.Proto = resp.GetNode()
+	}
+	return n, nil
+}
+
+// loadError returns err, reporting a plugin which did not respond in time
+// when loading the node.
+func (n *Node) loadError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("plugin %q did not respond within %s: %w", n.Proto.GetPlugin(), loadTimeout, err)
+	}
+	return err
+}
+
+func (n *Node) call(ctx context.Context, m ppb.Method, config []byte) (*ppb.Response, error) {
+	return Call(ctx, n.path, &ppb.Request{
+		Version:   Version,
+		Method:    m,
+		Node:      n.Proto,
+		Namespace: n.Namespace,
+		Kubecfg:   n.Kubecfg,
+		BasePath:  n.BasePath,
+		Config:    config,
+	})
+}
+
+func (n *Node) unimplemented(m ppb.Method) error {
+	return status.Errorf(codes.Unimplemented, "plugin %q of node %q does not implement %s", n.Proto.GetPlugin(), n.Name(), MethodArg(m))
+}
+
+func (n *Node) Create(ctx context.Context) error {
+	resp, err := n.call(ctx, ppb.Method_CREATE, nil)
+	if err != nil {
+		return err
+	}
+	if resp.GetUnimplemented() {
+		return n.Impl.Create(ctx)
+	}
+	return nil
+}
+
+func (n *Node) Delete(ctx context.Context) error {
+	resp, err := n.call(ctx, ppb.Method_DELETE, nil)
+	if err != nil {
+		return err
+	}
+	if resp.GetUnimplemented() {
+		return n.Impl.Delete(ctx)
+	}
+	return nil
+}
+
+func (n *Node) Status(ctx context.Context) (node.Status, error) {
+	resp, err := n.call(ctx, ppb.Method_STATUS, nil)
+	if err != nil {
+		return node.StatusUnknown, err
+	}
+	if resp.GetUnimplemented() {
+		return n.Impl.Status(ctx)
+	}
+	switch resp.GetStatus() {
+	case ppb.Status_PENDING:
+		return node.StatusPending, nil
+	case ppb.Status_RUNNING:
+		return node.StatusRunning, nil
+	case ppb.Status_FAILED:
+		return node.StatusFailed, nil
+	default:
+		return node.StatusUnknown, nil
+	}
+}
+
+func (n *Node) TopologySpecs(ctx context.Context) ([]*topologyv1.Topology, error) {
+	resp, err := n.call(ctx, ppb.Method_TOPOLOGY_SPECS, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetUnimplemented() {
+		return n.Impl.TopologySpecs(ctx)
+	}
+	var specs []*topologyv1.Topology
+	for _, s := range resp.GetTopologySpecs() {
+		t := &topologyv1.Topology{
+			ObjectMeta: metav1.ObjectMeta{Name: s.GetName()},
+		}
+		for _, l := range s.GetLinks() {
+			t.Spec.Links = append(t.Spec.Links, topologyv1.Link{
+				UID:       int(l.GetUid()),
+				PeerPod:   l.GetPeerPod(),
+				LocalIntf: l.GetLocalIntf(),
+				PeerIntf:  l.GetPeerIntf(),
+				LocalIP:   l.GetLocalIp(),
+				PeerIP:    l.GetPeerIp(),
+			})
+		}
+		specs = append(specs, t)
+	}
+	return specs, nil
+}
+
+func (n *Node) ConfigPush(ctx context.Context, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	resp, err := n.call(ctx, ppb.Method_CONFIG_PUSH, b)
+	if err != nil {
+		return err
+	}
+	if resp.GetUnimplemented() {
+		return n.unimplemented(ppb.Method_CONFIG_PUSH)
+	}
+	return nil
+}
+
+func (n *Node) ResetCfg(ctx context.Context) error {
+	resp, err := n.call(ctx, ppb.Method_RESET_CFG, nil)
+	if err != nil {
+		return err
+	}
+	if resp.GetUnimplemented() {
+		return n.unimplemented(ppb.Method_RESET_CFG)
+	}
+	return nil
+}
+
+func (n *Node) GenerateSelfSigned(ctx context.Context) error {
+	resp, err := n.call(ctx, ppb.Method_GENERATE_SELF_SIGNED, nil)
+	if err != nil {
+		return err
+	}
+	if resp.GetUnimplemented() {
+		return n.unimplemented(ppb.Method_GENERATE_SELF_SIGNED)
+	}
+	return nil
+}
+
+func init() {
+	node.Plugins(New)
+}
@@ -0,0 +1,293 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	ppb "github.com/openconfig/kne/proto/plugin"
+	tpb "github.com/openconfig/kne/proto/topo"
+	topologyv1 "github.com/openconfig/kne/third_party/meshnet/api/types/v1beta1"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const serveEnv = "KNE_PLUGIN_TEST_SERVE"
+
+var fakeHandlers = Handlers{
+	ppb.Method_DEFAULTS: func(_ context.Context, req *ppb.Request) (*ppb.Response, error) {
+		n := proto.Clone(req.GetNode()).(*tpb.Node)
+		n.Config = &tpb.Config{Image: "fake:latest"}
+		return &ppb.Response{Node: n}, nil
+	},
+	ppb.Method_STATUS: func(context.Context, *ppb.Request) (*ppb.Response, error) {
+		return &ppb.Response{Status: ppb.Status_RUNNING}, nil
+	},
+	ppb.Method_TOPOLOGY_SPECS: func(_ context.Context, req *ppb.Request) (*ppb.Response, error) {
+		return &ppb.Response{TopologySpecs: []*ppb.TopologySpec{{
+			Name:  req.GetNode().GetName(),
+			Links: []*ppb.Link{{Uid: 1, PeerPod: "r2", LocalIntf: "eth1", PeerIntf: "eth2"}},
+		}}}, nil
+	},
+	ppb.Method_DELETE: func(context.Context, *ppb.Request) (*ppb.Response, error) {
+		return &ppb.Response{}, nil
+	},
+	ppb.Method_CONFIG_PUSH: func(_ context.Context, req *ppb.Request) (*ppb.Response, error) {
+		if string(req.GetConfig()) != "hostname r1" {
+			return nil, fmt.Errorf("invalid config %q", req.GetConfig())
+		}
+		fmt.Fprintln(os.Stderr, "config pushed")
+		return &ppb.Response{}, nil
+	},
+}
+
+func TestMain(m *testing.M) {
+	// The test binary acts as the fake plugin when run by Call.
+	if os.Getenv(serveEnv) != "" {
+		Serve("fake", fakeHandlers)
+	}
+	os.Exit(m.Run())
+}
+
+// installFake installs the test binary as the plugin named name in a
+// temporary plugin directory.
+func installFake(t *testing.T, name string) string {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("failed to get test executable: %v", err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, Prefix+name)
+	if err := os.Symlink(exe, path); err != nil {
+		t.Fatalf("failed to link plugin: %v", err)
+	}
+	t.Setenv(PathEnv, dir)
+	t.Setenv(serveEnv, "1")
+	return path
+}
+
+func TestFind(t *testing.T) {
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	for _, f := range []struct {
+		path string
+		perm os.FileMode
+	}{
+		{filepath.Join(dir1, Prefix+"first"), 0755},
+		{filepath.Join(dir2, Prefix+"first"), 0755},
+		{filepath.Join(dir2, Prefix+"second"), 0755},
+		{filepath.Join(dir1, Prefix+"noexec"), 0644},
+	} {
+		if err := os.WriteFile(f.path, []byte("#!/bin/sh\n"), f.perm); err != nil {
+			t.Fatalf("failed to write %s: %v", f.path, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir1, Prefix+"second"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	t.Setenv(PathEnv, dir1+string(os.PathListSeparator)+dir2)
+	tests := []struct {
+		desc    string
+		name    string
+		want    string
+		wantErr string
+	}{{
+		desc: "first dir",
+		name: "first",
+		want: filepath.Join(dir1, Prefix+"first"),
+	}, {
+		desc: "skips dirs",
+		name: "second",
+		want: filepath.Join(dir2, Prefix+"second"),
+	}, {
+		desc:    "not executable",
+		name:    "noexec",
+		wantErr: "is not executable",
+	}, {
+		desc:    "not found",
+		name:    "missing",
+		wantErr: `plugin "kne-node-missing" not found`,
+	}, {
+		desc:    "invalid name",
+		name:    "../first",
+		wantErr: "invalid plugin name",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Find(tt.name)
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("Find() unexpected error: %s", s)
+			}
+			if got != tt.want {
+				t.Errorf("Find() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	req := func(m ppb.Method) string {
+		b, err := protojson.Marshal(&ppb.Request{Version: Version, Method: m, Node: &tpb.Node{Name: "r1"}})
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		return string(b)
+	}
+	tests := []struct {
+		desc    string
+		args    []string
+		stdin   string
+		want    *ppb.Response
+		wantErr string
+	}{{
+		desc:  "info",
+		args:  []string{"info"},
+		stdin: req(ppb.Method_INFO),
+		want:  &ppb.Response{Version: Version, Name: "fake"},
+	}, {
+		desc:  "unimplemented",
+		args:  []string{"reset_cfg"},
+		stdin: req(ppb.Method_RESET_CFG),
+		want:  &ppb.Response{Unimplemented: true},
+	}, {
+		desc:  "handler error",
+		args:  []string{"config_push"},
+		stdin: req(ppb.Method_CONFIG_PUSH),
+		want:  &ppb.Response{Error: `invalid config ""`},
+	}, {
+		desc:    "no method",
+		wantErr: "usage: kne-node-fake <method>",
+	}, {
+		desc:    "unknown method",
+		args:    []string{"method_unspecified"},
+		wantErr: `unknown method "method_unspecified"`,
+	}, {
+		desc:    "invalid request",
+		args:    []string{"status"},
+		stdin:   "{",
+		wantErr: "invalid request",
+	}, {
+		desc:    "method mismatch",
+		args:    []string{"status"},
+		stdin:   req(ppb.Method_CREATE),
+		wantErr: "does not match argument",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(context.Background(), "fake", fakeHandlers, tt.args, strings.NewReader(tt.stdin), &out)
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("Run() unexpected error: %s", s)
+			}
+			if err != nil {
+				return
+			}
+			got := &ppb.Response{}
+			if err := protojson.Unmarshal(out.Bytes(), got); err != nil {
+				t.Fatalf("Run() wrote invalid response %q: %v", out.String(), err)
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("Run() unexpected response (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestNode(t *testing.T) {
+	installFake(t, "fake")
+	ctx := context.Background()
+	kClient := kfake.NewSimpleClientset()
+	n, err := node.New("test", &tpb.Node{Name: "r1", Plugin: "fake"}, kClient, nil, "", "", nil)
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	if got, want := n.GetProto().GetConfig().GetImage(), "fake:latest"; got != want {
+		t.Errorf("New() did not apply plugin defaults: got image %q, want %q", got, want)
+	}
+
+	// Create is not implemented by the plugin and falls back to the default
+	// implementation.
+	if err := n.Create(ctx); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if _, err := kClient.CoreV1().Pods("test").Get(ctx, "r1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Create() did not create pod: %v", err)
+	}
+	st, err := n.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() unexpected error: %v", err)
+	}
+	if st != node.StatusRunning {
+		t.Errorf("Status() got %v, want %v", st, node.StatusRunning)
+	}
+	specs, err := n.TopologySpecs(ctx)
+	if err != nil {
+		t.Fatalf("TopologySpecs() unexpected error: %v", err)
+	}
+	wantSpecs := []*topologyv1.Topology{{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+		Spec: topologyv1.TopologySpec{
+			Links: []topologyv1.Link{{UID: 1, PeerPod: "r2", LocalIntf: "eth1", PeerIntf: "eth2"}},
+		},
+	}}
+	if s := cmp.Diff(wantSpecs, specs); s != "" {
+		t.Errorf("TopologySpecs() unexpected diff (-want +got):\n%s", s)
+	}
+
+	if err := n.(node.ConfigPusher).ConfigPush(ctx, strings.NewReader("hostname r1")); err != nil {
+		t.Errorf("ConfigPush() unexpected error: %v", err)
+	}
+	err = n.(node.ConfigPusher).ConfigPush(ctx, strings.NewReader("bad"))
+	if s := errdiff.Substring(err, `plugin kne-node-fake config_push: invalid config "bad"`); s != "" {
+		t.Errorf("ConfigPush() unexpected error: %s", s)
+	}
+	if err := n.(node.Resetter).ResetCfg(ctx); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ResetCfg() got error %v, want code %s", err, codes.Unimplemented)
+	}
+	if err := n.Delete(ctx); err != nil {
+		t.Errorf("Delete() unexpected error: %v", err)
+	}
+	// Delete is implemented by the plugin, so the pod is left alone.
+	if _, err := kClient.CoreV1().Pods("test").Get(ctx, "r1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Delete() unexpectedly deleted pod: %v", err)
+	}
+
+	_, err = node.New("test", &tpb.Node{Name: "r1", Plugin: "missing"}, kClient, nil, "", "", nil)
+	if s := errdiff.Substring(err, `plugin "kne-node-missing" not found`); s != "" {
+		t.Errorf("New() unexpected error: %s", s)
+	}
+}
+
+func TestNewTimeout(t *testing.T) {
+	origLoadTimeout := loadTimeout
+	defer func() {
+		loadTimeout = origLoadTimeout
+	}()
+	loadTimeout = 100 * time.Millisecond
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, Prefix+"hang"), []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+	t.Setenv(PathEnv, dir)
+	start := time.Now()
+	_, err := New(&node.Impl{Proto: &tpb.Node{Name: "r1", Plugin: "hang"}})
+	if s := errdiff.Substring(err, `plugin "hang" did not respond within 100ms`); s != "" {
+		t.Errorf("New() unexpected error: %s", s)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("New() returned after %s, want it bounded by the load timeout", d)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	ppb "github.com/openconfig/kne/proto/plugin"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// HandlerFunc handles a method of the node contract in a plugin.
+type HandlerFunc func(ctx context.Context, req *ppb.Request) (*ppb.Response, error)
+
+// Handlers maps the methods implemented by a plugin to their handlers.
+// Methods without a handler respond as unimplemented. INFO is handled by
+// Run.
+type Handlers map[ppb.Method]HandlerFunc
+
+// Run handles a single call of the plugin named name, as made by Call. args
+// are the command line arguments without the program name. Errors returned
+// by the handler are sent in the response, other errors are returned.
+func Run(ctx context.Context, name string, h Handlers, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s%s <method>", Prefix, name)
+	}
+	m := ppb.Method_METHOD_UNSPECIFIED
+	for v := range ppb.Method_name {
+		if MethodArg(ppb.Method(v)) == args[0] && v != int32(ppb.Method_METHOD_UNSPECIFIED) {
+			m = ppb.Method(v)
+		}
+	}
+	if m == ppb.Method_METHOD_UNSPECIFIED {
+		return fmt.Errorf("unknown method %q", args[0])
+	}
+	b, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	req := &ppb.Request{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	if req.GetMethod() != m {
+		return fmt.Errorf("request method %s does not match argument %q", req.GetMethod(), args[0])
+	}
+	var resp *ppb.Response
+	switch fn, ok := h[m]; {
+	case m == ppb.Method_INFO:
+		resp = &ppb.Response{Version: Version, Name: name}
+	case !ok:
+		resp = &ppb.Response{Unimplemented: true}
+	default:
+		resp, err = fn(ctx, req)
+		if err != nil {
+			resp = &ppb.Response{Error: err.Error()}
+		}
+		if resp == nil {
+			resp = &ppb.Response{}
+		}
+	}
+	b, err = protojson.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(b)
+	return err
+}
+
+// Serve runs the plugin named name with the handlers h. It is meant to be
+// called from the main function of the plugin and exits the process.
+func Serve(name string, h Handlers) {
+	if err := Run(context.Background(), name, h, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s%s: %v\n", Prefix, name, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
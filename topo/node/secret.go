@@ -0,0 +1,242 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	log "k8s.io/klog/v2"
+)
+
+// maxObjectSize is the maximum total size of the data in a ConfigMap or
+// Secret accepted by the API server.
+const maxObjectSize = 1048576
+
+// CreateSecrets creates the Secrets referenced by the node config in the
+// topology namespace. Secrets are either created from local files or copied
+// from an existing Secret in another namespace. Secrets without a source must
+// already exist in the topology namespace. The same Secret may be referenced
+// by several nodes, in which case it is only created once.
+func (n *Impl) CreateSecrets(ctx context.Context) error {
+	for _, s := range n.GetProto().GetConfig().GetSecrets() {
+		if err := n.createSecret(ctx, s); err != nil {
+			return fmt.Errorf("failed to create secret %q: %w", s.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func (n *Impl) createSecret(ctx context.Context, s *tpb.SecretRef) error {
+	if s.GetName() == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: s.GetName(),
+			Labels: map[string]string{
+				"topo": n.Namespace,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	switch {
+	case len(s.GetFiles()) > 0:
+		secret.Data = map[string][]byte{}
+		size := 0
+		for k, f := range s.GetFiles() {
+			if errs := validation.IsConfigMapKey(k); len(errs) > 0 {
+				return fmt.Errorf("invalid key %q: %v", k, errs)
+			}
+			b, err := os.ReadFile(filepath.Join(n.BasePath, f))
+			if err != nil {
+				return err
+			}
+			size += len(b)
+			secret.Data[k] = b
+		}
+		if size > maxObjectSize {
+			return fmt.Errorf("size %d exceeds the maximum of %d bytes", size, maxObjectSize)
+		}
+	case s.GetNamespace() != "" && s.GetNamespace() != n.Namespace:
+		src, err := n.KubeClient.CoreV1().Secrets(s.GetNamespace()).Get(ctx, s.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		secret.Type = src.Type
+		secret.Data = src.Data
+	default:
+		_, err := n.KubeClient.CoreV1().Secrets(n.Namespace).Get(ctx, s.GetName(), metav1.GetOptions{})
+		return err
+	}
+	if err := n.createOrUpdateSecret(ctx, secret); err != nil {
+		return err
+	}
+	log.Infof("Created Secret %q for node %s", s.GetName(), n.Name())
+	return nil
+}
+
+// createOrUpdateSecret creates s in the topology namespace, replacing the data
+// of an existing Secret, such as one created for another node or one which
+// outlived the pod of the node.
+func (n *Impl) createOrUpdateSecret(ctx context.Context, s *corev1.Secret) error {
+	secrets := n.KubeClient.CoreV1().Secrets(n.Namespace)
+	_, err := secrets.Create(ctx, s, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	cur, err := secrets.Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cur.Data = s.Data
+	_, err = secrets.Update(ctx, cur, metav1.UpdateOptions{})
+	return err
+}
+
+// SecretEnv returns the environment variables of the node that reference
+// Secret keys, sorted by name.
+func (n *Impl) SecretEnv() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, s := range n.GetProto().GetConfig().GetSecrets() {
+		for name, key := range s.GetEnv() {
+			env = append(env, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: s.GetName(),
+						},
+						Key: key,
+					},
+				},
+			})
+		}
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
+// SecretVolumes returns the volumes and volume mounts for the Secrets of the
+// node with a mount path.
+func (n *Impl) SecretVolumes() ([]corev1.Volume, []corev1.VolumeMount) {
+	var vols []corev1.Volume
+	var mounts []corev1.VolumeMount
+	for i, s := range n.GetProto().GetConfig().GetSecrets() {
+		if s.GetMountPath() == "" {
+			continue
+		}
+		name := fmt.Sprintf("secret-%d", i)
+		vols = append(vols, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s.GetName(),
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: s.GetMountPath(),
+			ReadOnly:  true,
+		})
+	}
+	return vols, mounts
+}
+
+// AddSecrets creates the Secrets of the node and adds their volumes and
+// environment variables to all containers of the pod.
+func (n *Impl) AddSecrets(ctx context.Context, pod *corev1.Pod) error {
+	if len(n.GetProto().GetConfig().GetSecrets()) == 0 {
+		return nil
+	}
+	if err := n.CreateSecrets(ctx); err != nil {
+		return err
+	}
+	vols, mounts := n.SecretVolumes()
+	env := n.SecretEnv()
+	pod.Spec.Volumes = append(pod.Spec.Volumes, vols...)
+	for i, c := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(c.Env, env...)
+		pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, mounts...)
+	}
+	return nil
+}
+
+// CustomizePod adds the resources of the node config that are common to all
+// vendors, such as Secrets, file mounts and persistent volumes, and the
+// placement and sidecars of the node to pod before it is created. The pod
+// patches of the node are applied last.
+func (n *Impl) CustomizePod(ctx context.Context, pod *corev1.Pod) error {
+	if err := n.AddPlacement(pod); err != nil {
+		return err
+	}
+	if err := n.AddSecrets(ctx, pod); err != nil {
+		return err
+	}
+	if err := n.AddFiles(ctx, pod); err != nil {
+		return err
+	}
+	if err := n.AddVolumes(ctx, pod); err != nil {
+		return err
+	}
+	if err := n.AddSidecars(pod); err != nil {
+		return err
+	}
+	return n.ApplyPodPatches(ctx, pod)
+}
+
+// ValidateCRConfig returns an error if the node config uses features which
+// cannot be passed through the custom resources of vendor controllers, such
+// as mounting Secrets, files or persistent volumes, placing or patching the
+// pod, or adding sidecars. secretEnv is whether the custom resource of the
+// vendor accepts environment variables referencing Secret keys; Secret values
+// are never copied into custom resources.
+func (n *Impl) ValidateCRConfig(secretEnv bool) error {
+	if n.GetProto().GetPlacement() != nil {
+		return fmt.Errorf("placement is not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	if len(n.GetProto().GetSidecars()) > 0 {
+		return fmt.Errorf("sidecars are not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	if len(n.PodPatches()) > 0 {
+		return fmt.Errorf("pod patches are not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	config := n.GetProto().GetConfig()
+	for _, s := range config.GetSecrets() {
+		if s.GetMountPath() != "" {
+			return fmt.Errorf("secret %q: mounting secrets is not supported by vendor %s, use env instead", s.GetName(), n.GetProto().GetVendor())
+		}
+		if !secretEnv && len(s.GetEnv()) > 0 {
+			return fmt.Errorf("secret %q: environment variables from secrets are not supported by vendor %s", s.GetName(), n.GetProto().GetVendor())
+		}
+	}
+	if len(config.GetFileMounts()) > 0 {
+		return fmt.Errorf("file mounts are not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	if len(config.GetVolumes()) > 0 {
+		return fmt.Errorf("persistent volumes are not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	return nil
+}
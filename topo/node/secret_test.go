@@ -0,0 +1,248 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateSecrets(t *testing.T) {
+	ctx := context.Background()
+	existing := []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "test"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "license", Namespace: "other"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"license.key": []byte("key")},
+	}}
+	tests := []struct {
+		desc    string
+		secrets []*topopb.SecretRef
+		want    *corev1.Secret
+		wantErr string
+	}{{
+		desc: "no secrets",
+	}, {
+		desc: "existing secret",
+		secrets: []*topopb.SecretRef{{
+			Name: "creds",
+		}},
+		want: existing[0],
+	}, {
+		desc: "existing secret dne",
+		secrets: []*topopb.SecretRef{{
+			Name: "dne",
+		}},
+		wantErr: `secret "dne": secrets "dne" not found`,
+	}, {
+		desc: "copied secret",
+		secrets: []*topopb.SecretRef{{
+			Name:      "license",
+			Namespace: "other",
+		}},
+		want: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "license",
+				Namespace: "test",
+				Labels:    map[string]string{"topo": "test"},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{"license.key": []byte("key")},
+		},
+	}, {
+		desc: "secret from files",
+		secrets: []*topopb.SecretRef{{
+			Name:  "files",
+			Files: map[string]string{"small": "testdata/small.cfg"},
+		}, {
+			Name:  "files",
+			Files: map[string]string{"small": "testdata/small.cfg"},
+		}},
+		want: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "files",
+				Namespace: "test",
+				Labels:    map[string]string{"topo": "test"},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{"small": []byte("test config\n")},
+		},
+	}, {
+		desc: "secret from files too large",
+		secrets: []*topopb.SecretRef{{
+			Name:  "files",
+			Files: map[string]string{"large": "testdata/large.cfg"},
+		}},
+		wantErr: "exceeds the maximum",
+	}, {
+		desc: "secret from files dne",
+		secrets: []*topopb.SecretRef{{
+			Name:  "files",
+			Files: map[string]string{"dne": "testdata/dne.cfg"},
+		}},
+		wantErr: "no such file",
+	}, {
+		desc: "invalid key",
+		secrets: []*topopb.SecretRef{{
+			Name:  "files",
+			Files: map[string]string{"a/b": "testdata/small.cfg"},
+		}},
+		wantErr: `invalid key "a/b"`,
+	}, {
+		desc:    "missing name",
+		secrets: []*topopb.SecretRef{{}},
+		wantErr: "name cannot be empty",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(existing[0], existing[1]),
+				Proto: &topopb.Node{
+					Name:   "dev1",
+					Config: &topopb.Config{Secrets: tt.secrets},
+				},
+			}
+			err := n.CreateSecrets(ctx)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("CreateSecrets() failed: %s", s)
+			}
+			if tt.want == nil {
+				return
+			}
+			got, err := n.KubeClient.CoreV1().Secrets("test").Get(ctx, tt.want.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("CreateSecrets() did not create the expected secret: %v", err)
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("CreateSecrets() created secret unexpected diff: %s", s)
+			}
+		})
+	}
+}
+
+func TestAddSecrets(t *testing.T) {
+	ctx := context.Background()
+	n := &Impl{
+		Namespace: "test",
+		KubeClient: kfake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "test"},
+		}),
+		Proto: &topopb.Node{
+			Name: "dev1",
+			Config: &topopb.Config{
+				Secrets: []*topopb.SecretRef{{
+					Name: "creds",
+					Env:  map[string]string{"USER": "username", "PASS": "password"},
+				}, {
+					Name:      "creds",
+					MountPath: "/etc/creds",
+				}},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "dev1"}},
+		},
+	}
+	if err := n.AddSecrets(ctx, pod); err != nil {
+		t.Fatalf("AddSecrets() failed: %v", err)
+	}
+	ref := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+				Key:                  key,
+			},
+		}
+	}
+	want := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "secret-1",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "creds"},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "dev1",
+				Env: []corev1.EnvVar{
+					{Name: "PASS", ValueFrom: ref("password")},
+					{Name: "USER", ValueFrom: ref("username")},
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "secret-1",
+					MountPath: "/etc/creds",
+					ReadOnly:  true,
+				}},
+			}},
+		},
+	}
+	if s := cmp.Diff(want, pod); s != "" {
+		t.Errorf("AddSecrets() unexpected diff: %s", s)
+	}
+}
+
+func TestValidateCRConfig(t *testing.T) {
+	tests := []struct {
+		desc      string
+		config    *topopb.Config
+		secretEnv bool
+		wantErr   string
+	}{{
+		desc:   "no secrets",
+		config: &topopb.Config{Env: map[string]string{"FOO": "bar"}},
+	}, {
+		desc: "secret env",
+		config: &topopb.Config{
+			Secrets: []*topopb.SecretRef{{
+				Name: "creds",
+				Env:  map[string]string{"PASS": "password"},
+			}},
+		},
+		secretEnv: true,
+	}, {
+		desc: "secret env not supported",
+		config: &topopb.Config{
+			Secrets: []*topopb.SecretRef{{
+				Name: "creds",
+				Env:  map[string]string{"PASS": "password"},
+			}},
+		},
+		wantErr: `secret "creds": environment variables from secrets are not supported`,
+	}, {
+		desc: "secret mount",
+		config: &topopb.Config{
+			Secrets: []*topopb.SecretRef{{
+				Name:      "license",
+				MountPath: "/mnt/license",
+			}},
+		},
+		secretEnv: true,
+		wantErr:   `secret "license": mounting secrets is not supported`,
+	}, {
+		desc:    "file mounts",
+		config:  &topopb.Config{FileMounts: []*topopb.FileMount{{Source: "certs", Destination: "/certs"}}},
+		wantErr: "file mounts are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace: "test",
+				Proto:     &topopb.Node{Name: "dev1", Config: tt.config},
+			}
+			err := n.ValidateCRConfig(tt.secretEnv)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("ValidateCRConfig() failed: %s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// MetalLBIPsAnnotation requests specific external IPs from MetalLB.
+	MetalLBIPsAnnotation = "metallb.io/loadBalancerIPs"
+	// MetalLBPoolAnnotation requests an external IP from a MetalLB address pool.
+	MetalLBPoolAnnotation = "metallb.io/address-pool"
+)
+
+var (
+	serviceProtocols = map[tpb.Protocol]corev1.Protocol{
+		tpb.Protocol_TCP:  corev1.ProtocolTCP,
+		tpb.Protocol_UDP:  corev1.ProtocolUDP,
+		tpb.Protocol_SCTP: corev1.ProtocolSCTP,
+	}
+	serviceTypes = map[tpb.ServiceOptions_Type]corev1.ServiceType{
+		tpb.ServiceOptions_LOAD_BALANCER: corev1.ServiceTypeLoadBalancer,
+		tpb.ServiceOptions_NODE_PORT:     corev1.ServiceTypeNodePort,
+		tpb.ServiceOptions_CLUSTER_IP:    corev1.ServiceTypeClusterIP,
+	}
+)
+
+// applyServiceOptions applies the service options of the node to s.
+func (n *Impl) applyServiceOptions(s *corev1.Service) error {
+	opts := n.GetProto().GetServiceOptions()
+	if opts == nil {
+		return nil
+	}
+	t, ok := serviceTypes[opts.GetType()]
+	if !ok {
+		return fmt.Errorf("invalid service type %s", opts.GetType())
+	}
+	s.Spec.Type = t
+	if t != corev1.ServiceTypeLoadBalancer {
+		s.Spec.AllocateLoadBalancerNodePorts = nil
+		if len(opts.GetLoadBalancerIps()) > 0 || opts.GetAddressPool() != "" {
+			return fmt.Errorf("load balancer IPs and address pool are only valid for type %s", tpb.ServiceOptions_LOAD_BALANCER)
+		}
+	}
+	if t == corev1.ServiceTypeClusterIP {
+		for _, p := range s.Spec.Ports {
+			if p.NodePort != 0 {
+				return fmt.Errorf("node port %d is not valid for type %s", p.NodePort, opts.GetType())
+			}
+		}
+	}
+	if len(opts.GetAnnotations())+len(opts.GetLoadBalancerIps()) > 0 || opts.GetAddressPool() != "" {
+		s.Annotations = map[string]string{}
+	}
+	for k, v := range opts.GetAnnotations() {
+		s.Annotations[k] = v
+	}
+	var v4, v6 int
+	for _, ip := range opts.GetLoadBalancerIps() {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return fmt.Errorf("invalid load balancer IP: %w", err)
+		}
+		if addr.Is4() {
+			v4++
+		} else {
+			v6++
+		}
+	}
+	if v4 > 1 || v6 > 1 {
+		return fmt.Errorf("at most one load balancer IP per IP family can be requested, got %v", opts.GetLoadBalancerIps())
+	}
+	if len(opts.GetLoadBalancerIps()) > 0 {
+		s.Annotations[MetalLBIPsAnnotation] = strings.Join(opts.GetLoadBalancerIps(), ",")
+	}
+	if p := opts.GetAddressPool(); p != "" {
+		s.Annotations[MetalLBPoolAnnotation] = p
+	}
+	return nil
+}
+
+// ValidateCRServices returns an error if the services of the node cannot be
+// passed through the custom resources of vendor controllers which create the
+// Service of the node, as those only expose TCP ports on a LoadBalancer.
+func (n *Impl) ValidateCRServices() error {
+	if n.GetProto().GetServiceOptions() != nil {
+		return fmt.Errorf("service options are not supported by vendor %s", n.GetProto().GetVendor())
+	}
+	for k, v := range n.GetProto().GetServices() {
+		if v.GetProtocol() != tpb.Protocol_TCP {
+			return fmt.Errorf("service %d: protocol %s is not supported by vendor %s", k, v.GetProtocol(), n.GetProto().GetVendor())
+		}
+	}
+	return nil
+}
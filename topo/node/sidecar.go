@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+)
+
+func sidecarContainer(s *tpb.Sidecar) (corev1.Container, error) {
+	c := corev1.Container{
+		Name:            s.GetName(),
+		Image:           s.GetImage(),
+		Command:         s.GetCommand(),
+		Args:            s.GetArgs(),
+		ImagePullPolicy: "IfNotPresent",
+	}
+	if c.Image == "" {
+		return c, fmt.Errorf("image cannot be empty")
+	}
+	for k, v := range s.GetEnv() {
+		c.Env = append(c.Env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(c.Env, func(i, j int) bool { return c.Env[i].Name < c.Env[j].Name })
+	for name, p := range s.GetPorts() {
+		if errs := validation.IsValidPortName(name); len(errs) > 0 {
+			return c, fmt.Errorf("invalid port name %q: %v", name, errs)
+		}
+		if errs := validation.IsValidPortNum(int(p)); len(errs) > 0 {
+			return c, fmt.Errorf("invalid port %d: %v", p, errs)
+		}
+		c.Ports = append(c.Ports, corev1.ContainerPort{
+			Name:          name,
+			ContainerPort: int32(p),
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	sort.Slice(c.Ports, func(i, j int) bool { return c.Ports[i].Name < c.Ports[j].Name })
+	if s.GetPrivileged() {
+		c.SecurityContext = &corev1.SecurityContext{
+			Privileged: pointer.Bool(true),
+		}
+	}
+	return c, nil
+}
+
+// AddSidecars appends the sidecar containers of the node to pod. Volumes of
+// the sidecars which are not already volumes of the pod are created as empty
+// directories and mounted into the node container as well.
+func (n *Impl) AddSidecars(pod *corev1.Pod) error {
+	if len(n.GetProto().GetSidecars()) == 0 {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, c := range pod.Spec.InitContainers {
+		names[c.Name] = true
+	}
+	for _, c := range pod.Spec.Containers {
+		names[c.Name] = true
+	}
+	volumes := map[string]bool{}
+	for _, v := range pod.Spec.Volumes {
+		volumes[v.Name] = true
+	}
+	var sidecars []corev1.Container
+	for _, s := range n.GetProto().GetSidecars() {
+		if errs := validation.IsDNS1123Label(s.GetName()); len(errs) > 0 {
+			return fmt.Errorf("invalid sidecar name %q: %v", s.GetName(), errs)
+		}
+		if names[s.GetName()] {
+			return fmt.Errorf("sidecar %q: container name already used in pod", s.GetName())
+		}
+		names[s.GetName()] = true
+		c, err := sidecarContainer(s)
+		if err != nil {
+			return fmt.Errorf("sidecar %q: %w", s.GetName(), err)
+		}
+		for _, v := range s.GetVolumes() {
+			if !filepath.IsAbs(v.GetMountPath()) {
+				return fmt.Errorf("sidecar %q: mount path %q must be an absolute path", s.GetName(), v.GetMountPath())
+			}
+			vm := corev1.VolumeMount{
+				Name:      v.GetName(),
+				MountPath: v.GetMountPath(),
+				ReadOnly:  v.GetReadOnly(),
+			}
+			if !volumes[v.GetName()] {
+				if errs := validation.IsDNS1123Label(v.GetName()); len(errs) > 0 {
+					return fmt.Errorf("sidecar %q: invalid volume name %q: %v", s.GetName(), v.GetName(), errs)
+				}
+				volumes[v.GetName()] = true
+				pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+					Name: v.GetName(),
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				})
+				for i, pc := range pod.Spec.Containers {
+					pod.Spec.Containers[i].VolumeMounts = append(pc.VolumeMounts, corev1.VolumeMount{
+						Name:      v.GetName(),
+						MountPath: v.GetMountPath(),
+					})
+				}
+			}
+			c.VolumeMounts = append(c.VolumeMounts, vm)
+		}
+		if s.GetShareProcessNamespace() {
+			pod.Spec.ShareProcessNamespace = pointer.Bool(true)
+		}
+		sidecars = append(sidecars, c)
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, sidecars...)
+	return nil
+}
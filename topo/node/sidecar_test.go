@@ -0,0 +1,151 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestAddSidecars(t *testing.T) {
+	tests := []struct {
+		desc     string
+		sidecars []*topopb.Sidecar
+		want     corev1.PodSpec
+		wantErr  string
+	}{{
+		desc: "no sidecars",
+		want: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:         "dev1",
+				VolumeMounts: []corev1.VolumeMount{{Name: "secret-0", MountPath: "/etc/certs"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "secret-0"}},
+		},
+	}, {
+		desc: "sidecars",
+		sidecars: []*topopb.Sidecar{{
+			Name:    "collector",
+			Image:   "gnmic:latest",
+			Command: []string{"gnmic"},
+			Args:    []string{"subscribe"},
+			Env:     map[string]string{"B": "2", "A": "1"},
+			Ports:   map[string]uint32{"metrics": 9804, "api": 7890},
+			Volumes: []*topopb.SidecarVolume{{
+				Name:      "secret-0",
+				MountPath: "/certs",
+				ReadOnly:  true,
+			}, {
+				Name:      "captures",
+				MountPath: "/captures",
+			}},
+		}, {
+			Name:                  "tcpdump",
+			Image:                 "tcpdump:latest",
+			Privileged:            true,
+			ShareProcessNamespace: true,
+			Volumes: []*topopb.SidecarVolume{{
+				Name:      "captures",
+				MountPath: "/data",
+			}},
+		}},
+		want: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "dev1",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "secret-0", MountPath: "/etc/certs"},
+					{Name: "captures", MountPath: "/captures"},
+				},
+			}, {
+				Name:    "collector",
+				Image:   "gnmic:latest",
+				Command: []string{"gnmic"},
+				Args:    []string{"subscribe"},
+				Env:     []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+				Ports: []corev1.ContainerPort{
+					{Name: "api", ContainerPort: 7890, Protocol: corev1.ProtocolTCP},
+					{Name: "metrics", ContainerPort: 9804, Protocol: corev1.ProtocolTCP},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "secret-0", MountPath: "/certs", ReadOnly: true},
+					{Name: "captures", MountPath: "/captures"},
+				},
+				ImagePullPolicy: "IfNotPresent",
+			}, {
+				Name:            "tcpdump",
+				Image:           "tcpdump:latest",
+				VolumeMounts:    []corev1.VolumeMount{{Name: "captures", MountPath: "/data"}},
+				ImagePullPolicy: "IfNotPresent",
+				SecurityContext: &corev1.SecurityContext{Privileged: pointer.Bool(true)},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "secret-0",
+			}, {
+				Name:         "captures",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+			ShareProcessNamespace: pointer.Bool(true),
+		},
+	}, {
+		desc:     "duplicate name",
+		sidecars: []*topopb.Sidecar{{Name: "dev1", Image: "alpine"}},
+		wantErr:  `container name already used`,
+	}, {
+		desc:     "invalid name",
+		sidecars: []*topopb.Sidecar{{Name: "Proxy", Image: "alpine"}},
+		wantErr:  `invalid sidecar name "Proxy"`,
+	}, {
+		desc:     "missing image",
+		sidecars: []*topopb.Sidecar{{Name: "proxy"}},
+		wantErr:  "image cannot be empty",
+	}, {
+		desc: "invalid port",
+		sidecars: []*topopb.Sidecar{{
+			Name:  "proxy",
+			Image: "alpine",
+			Ports: map[string]uint32{"ssh": 70000},
+		}},
+		wantErr: "invalid port 70000",
+	}, {
+		desc: "relative mount path",
+		sidecars: []*topopb.Sidecar{{
+			Name:    "proxy",
+			Image:   "alpine",
+			Volumes: []*topopb.SidecarVolume{{Name: "data", MountPath: "data"}},
+		}},
+		wantErr: "must be an absolute path",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace: "test",
+				Proto: &topopb.Node{
+					Name:     "dev1",
+					Sidecars: tt.sidecars,
+				},
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:         "dev1",
+						VolumeMounts: []corev1.VolumeMount{{Name: "secret-0", MountPath: "/etc/certs"}},
+					}},
+					Volumes: []corev1.Volume{{Name: "secret-0"}},
+				},
+			}
+			err := n.AddSidecars(pod)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("AddSidecars() failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, pod.Spec); s != "" {
+				t.Errorf("AddSidecars() unexpected pod spec diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
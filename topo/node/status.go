@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	log "k8s.io/klog/v2"
+)
+
+// StatusDetails is the status of a node with the reason it is in that state.
+type StatusDetails struct {
+	Status Status
+	// Reason is a short CamelCase reason of the status, such as
+	// ImagePullBackOff or Unschedulable.
+	Reason  string
+	Message string
+	// LastTransitionTime is the time of the last status change, if known.
+	LastTransitionTime time.Time
+	// Details are vendor specific details, such as the conditions of the
+	// custom resource of the node or the restart counts of its containers.
+	Details map[string]string
+}
+
+// StatusDetailer provides an interface for nodes reporting the details of
+// their status. Impl implements it with the pod of the node.
+type StatusDetailer interface {
+	StatusDetails(ctx context.Context) (*StatusDetails, error)
+}
+
+// StatusDetails returns the details of the status of the pod of the node.
+func (n *Impl) StatusDetails(ctx context.Context) (*StatusDetails, error) {
+	p, err := n.Pods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) != 1 {
+		return nil, fmt.Errorf("expected exactly one pod for node %s", n.Name())
+	}
+	return PodStatusDetails(p[0]), nil
+}
+
+// podConditions are the pod conditions in the order they become true.
+var podConditions = []corev1.PodConditionType{
+	corev1.PodScheduled,
+	corev1.PodInitialized,
+	corev1.ContainersReady,
+	corev1.PodReady,
+}
+
+// PodStatusDetails returns the details of the status of a pod. The reason is
+// the first of the reason of the pod, the reason a container is waiting or
+// was terminated, and the reason of the first pod condition which is not
+// true.
+func PodStatusDetails(p *corev1.Pod) *StatusDetails {
+	d := &StatusDetails{
+		Status:  StatusPending,
+		Reason:  p.Status.Reason,
+		Message: p.Status.Message,
+		Details: map[string]string{},
+	}
+	conds := map[corev1.PodConditionType]corev1.PodCondition{}
+	for _, c := range p.Status.Conditions {
+		conds[c.Type] = c
+		if t := c.LastTransitionTime.Time; t.After(d.LastTransitionTime) {
+			d.LastTransitionTime = t
+		}
+	}
+	switch p.Status.Phase {
+	case corev1.PodFailed:
+		d.Status = StatusFailed
+	case corev1.PodRunning:
+		if conds[corev1.PodReady].Status == corev1.ConditionTrue {
+			d.Status = StatusRunning
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.RestartCount > 0 {
+			d.Details[cs.Name+".restarts"] = fmt.Sprint(cs.RestartCount)
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			d.Details[cs.Name+".last_termination"] = fmt.Sprintf("%s (exit code %d)", t.Reason, t.ExitCode)
+		}
+		if d.Reason != "" {
+			continue
+		}
+		switch {
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+			d.Reason = cs.State.Waiting.Reason
+			d.Message = containerMessage(cs.Name, cs.State.Waiting.Message)
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			d.Reason = cs.State.Terminated.Reason
+			d.Message = containerMessage(cs.Name, fmt.Sprintf("exited with code %d", cs.State.Terminated.ExitCode))
+		}
+	}
+	if d.Reason == "" && d.Status != StatusRunning {
+		for _, t := range podConditions {
+			c, ok := conds[t]
+			if !ok || c.Status == corev1.ConditionTrue {
+				continue
+			}
+			d.Reason, d.Message = c.Reason, c.Message
+			if d.Reason == "" {
+				d.Reason = fmt.Sprintf("%sFalse", c.Type)
+			}
+			break
+		}
+	}
+	if len(d.Details) == 0 {
+		d.Details = nil
+	}
+	return d
+}
+
+func containerMessage(name, msg string) string {
+	if msg == "" {
+		return fmt.Sprintf("container %s", name)
+	}
+	return fmt.Sprintf("container %s: %s", name, msg)
+}
+
+// CRStatusDetails returns the status details of a node managed through a
+// custom resource of a vendor controller. The phase and message of the
+// resource are added to the details of the pod of the node, prefixed by
+// kind, and are the reason of the status if the pod has none.
+func CRStatusDetails(ctx context.Context, n *Impl, kind, phase, message string) *StatusDetails {
+	d, err := n.StatusDetails(ctx)
+	if err != nil {
+		log.V(1).Infof("No pod status details for node %s: %v", n.Name(), err)
+		d = &StatusDetails{}
+	}
+	if d.Details == nil {
+		d.Details = map[string]string{}
+	}
+	if phase != "" {
+		d.Details[kind+".phase"] = phase
+	}
+	if message != "" {
+		d.Details[kind+".message"] = message
+	}
+	if d.Reason == "" {
+		d.Reason, d.Message = phase, message
+	}
+	return d
+}
@@ -0,0 +1,187 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodStatusDetails(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		desc string
+		pod  *corev1.Pod
+		want *StatusDetails
+	}{{
+		desc: "running",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(ts)},
+			},
+		}},
+		want: &StatusDetails{Status: StatusRunning, LastTransitionTime: ts},
+	}, {
+		desc: "unschedulable",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Unschedulable",
+				Message: "0/1 nodes are available: 1 Insufficient cpu.",
+			}},
+		}},
+		want: &StatusDetails{
+			Status:  StatusPending,
+			Reason:  "Unschedulable",
+			Message: "0/1 nodes are available: 1 Insufficient cpu.",
+		},
+	}, {
+		desc: "image pull backoff",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "r1",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "ImagePullBackOff",
+					Message: `Back-off pulling image "ceos:latest"`,
+				}},
+			}},
+		}},
+		want: &StatusDetails{
+			Status:  StatusPending,
+			Reason:  "ImagePullBackOff",
+			Message: `container r1: Back-off pulling image "ceos:latest"`,
+		},
+	}, {
+		desc: "crash loop",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "init-r1",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "r1",
+				RestartCount:         3,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			}},
+		}},
+		want: &StatusDetails{
+			Status:  StatusPending,
+			Reason:  "CrashLoopBackOff",
+			Message: "container r1",
+			Details: map[string]string{
+				"r1.restarts":         "3",
+				"r1.last_termination": "Error (exit code 1)",
+			},
+		},
+	}, {
+		desc: "failed",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "r1",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}},
+		}},
+		want: &StatusDetails{
+			Status:  StatusFailed,
+			Reason:  "OOMKilled",
+			Message: "container r1: exited with code 137",
+		},
+	}, {
+		desc: "evicted",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		}},
+		want: &StatusDetails{
+			Status:  StatusFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}, {
+		desc: "not ready",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+				{Type: corev1.ContainersReady, Status: corev1.ConditionFalse},
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+			},
+		}},
+		want: &StatusDetails{Status: StatusPending, Reason: "ContainersReadyFalse"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := PodStatusDetails(tt.pod)
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("PodStatusDetails() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestCRStatusDetails(t *testing.T) {
+	ctx := context.Background()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "test", Labels: map[string]string{"app": "r1"}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodPending,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		},
+	}
+	tests := []struct {
+		desc    string
+		objs    []runtime.Object
+		phase   string
+		message string
+		want    *StatusDetails
+	}{{
+		desc:    "reason from resource",
+		objs:    []runtime.Object{pod},
+		phase:   "Provisioning",
+		message: "waiting for license",
+		want: &StatusDetails{
+			Status:  StatusPending,
+			Reason:  "Provisioning",
+			Message: "waiting for license",
+			Details: map[string]string{
+				"lemming.phase":   "Provisioning",
+				"lemming.message": "waiting for license",
+			},
+		},
+	}, {
+		desc:  "no pod",
+		phase: "Pending",
+		want: &StatusDetails{
+			Reason:  "Pending",
+			Details: map[string]string{"lemming.phase": "Pending"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(tt.objs...),
+				Proto:      &topopb.Node{Name: "r1"},
+			}
+			got := CRStatusDetails(ctx, n, "lemming", tt.phase, tt.message)
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("CRStatusDetails() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+)
+
+// TemplateData is the data a startup config template is rendered with. The
+// fields of the node being rendered are available at the top level, for
+// example {{.Name}}.
+type TemplateData struct {
+	*TemplateNode
+	Topology *TemplateTopology
+}
+
+// TemplateTopology describes the topology a node is part of.
+type TemplateTopology struct {
+	Name  string
+	Nodes map[string]*TemplateNode
+	Links []*tpb.Link
+}
+
+// TemplateNode describes a node of the topology.
+type TemplateNode struct {
+	Name       string
+	Vendor     string
+	Model      string
+	Version    string
+	Labels     map[string]string
+	Interfaces []*TemplateInterface // Sorted by interface number.
+	Services   []*TemplateService   // Sorted by inside port.
+}
+
+// TemplateInterface describes an interface of a node.
+type TemplateInterface struct {
+	Name          string // Name of the interface in the topology, e.g. eth1.
+	VendorName    string // Vendor specific name, e.g. Ethernet1.
+	IPAddress     string
+	MTU           uint32
+	Group         string
+	PeerName      string
+	PeerIntName   string
+	PeerIPAddress string
+}
+
+// TemplateService describes a service of a node.
+type TemplateService struct {
+	Name    string
+	Inside  uint32
+	Outside uint32
+}
+
+var ethIntfRe = regexp.MustCompile(`^eth(\d+)$`)
+
+// IntfNameFn returns the vendor specific name of the interface name, such as
+// eth1, of the node pb.
+type IntfNameFn func(pb *tpb.Node, name string) (string, error)
+
+var intfNameFns = map[tpb.Vendor]IntfNameFn{}
+
+// VendorIntfNames registers the interface naming scheme of the nodes of
+// vendor v, which names the interfaces of their startup config templates.
+func VendorIntfNames(v tpb.Vendor, fn IntfNameFn) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := intfNameFns[v]; ok {
+		panic(fmt.Sprintf("duplicate interface naming registration for %v", v))
+	}
+	intfNameFns[v] = fn
+}
+
+// VendorIntfName returns the vendor specific name of the interface name of
+// the node pb: the name set in the interface config if any, or else the name
+// given by the naming scheme of the vendor of pb. Names of interfaces of
+// vendors without a naming scheme are returned unchanged.
+func VendorIntfName(pb *tpb.Node, name string) (string, error) {
+	if n := pb.GetInterfaces()[name].GetName(); n != "" {
+		return n, nil
+	}
+	mu.Lock()
+	fn, ok := intfNameFns[pb.GetVendor()]
+	mu.Unlock()
+	if !ok {
+		return name, nil
+	}
+	return fn(pb, name)
+}
+
+// EthIntfNum returns N of an interface name of the form ethN with N > 0.
+func EthIntfNum(name string) (int, bool) {
+	m := ethIntfRe.FindStringSubmatch(name)
+	if m == nil {
+		return 0, false
+	}
+	i, err := strconv.Atoi(m[1])
+	if err != nil || i == 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+// intfLess orders interface names naturally, so eth2 sorts before eth10.
+func intfLess(a, b string) bool {
+	ma, mb := ethIntfRe.FindStringSubmatch(a), ethIntfRe.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return a < b
+	}
+	ia, _ := strconv.Atoi(ma[1])
+	ib, _ := strconv.Atoi(mb[1])
+	return ia < ib
+}
+
+// templateNodes returns the nodes of the topology t keyed by name, with pb
+// replacing the node of the same name. t may be nil.
+func templateNodes(pb *tpb.Node, t *tpb.Topology) map[string]*tpb.Node {
+	nodes := map[string]*tpb.Node{pb.GetName(): pb}
+	for _, n := range t.GetNodes() {
+		if n.GetName() != pb.GetName() {
+			nodes[n.GetName()] = n
+		}
+	}
+	return nodes
+}
+
+// NewTemplateData returns the data used to render the startup config
+// template of the node pb in the topology t. t may be nil.
+func NewTemplateData(pb *tpb.Node, t *tpb.Topology) (*TemplateData, error) {
+	nodes := templateNodes(pb, t)
+	td := &TemplateData{
+		Topology: &TemplateTopology{
+			Name:  t.GetName(),
+			Nodes: map[string]*TemplateNode{},
+			Links: t.GetLinks(),
+		},
+	}
+	for name, n := range nodes {
+		tn, err := newTemplateNode(n, nodes)
+		if err != nil {
+			return nil, err
+		}
+		td.Topology.Nodes[name] = tn
+	}
+	td.TemplateNode = td.Topology.Nodes[pb.GetName()]
+	return td, nil
+}
+
+func newTemplateNode(pb *tpb.Node, nodes map[string]*tpb.Node) (*TemplateNode, error) {
+	tn := &TemplateNode{
+		Name:    pb.GetName(),
+		Vendor:  pb.GetVendor().String(),
+		Model:   pb.GetModel(),
+		Version: pb.GetVersion(),
+		Labels:  pb.GetLabels(),
+	}
+	for name, intf := range pb.GetInterfaces() {
+		vendorName, err := VendorIntfName(pb, name)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", pb.GetName(), err)
+		}
+		ti := &TemplateInterface{
+			Name:        name,
+			VendorName:  vendorName,
+			IPAddress:   intf.GetIpAddress(),
+			MTU:         intf.GetMtu(),
+			Group:       intf.GetGroup(),
+			PeerName:    intf.GetPeerName(),
+			PeerIntName: intf.GetPeerIntName(),
+		}
+		if peer, ok := nodes[ti.PeerName]; ok {
+			ti.PeerIPAddress = peer.GetInterfaces()[ti.PeerIntName].GetIpAddress()
+		}
+		tn.Interfaces = append(tn.Interfaces, ti)
+	}
+	sort.Slice(tn.Interfaces, func(i, j int) bool {
+		return intfLess(tn.Interfaces[i].Name, tn.Interfaces[j].Name)
+	})
+	for _, s := range pb.GetServices() {
+		tn.Services = append(tn.Services, &TemplateService{
+			Name:    s.GetName(),
+			Inside:  s.GetInside(),
+			Outside: s.GetOutside(),
+		})
+	}
+	sort.Slice(tn.Services, func(i, j int) bool {
+		return tn.Services[i].Inside < tn.Services[j].Inside
+	})
+	return tn, nil
+}
+
+// parseIP parses an address with an optional prefix length, e.g. 10.0.0.1/31.
+func parseIP(s string) (net.IP, *net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		return ip, nil, nil
+	}
+	return net.ParseCIDR(s)
+}
+
+func templateFuncs(pb *tpb.Node, nodes map[string]*tpb.Node) template.FuncMap {
+	return template.FuncMap{
+		// intf returns the vendor specific name of an interface of the node.
+		"intf": func(name string) (string, error) {
+			return VendorIntfName(pb, name)
+		},
+		// nodeIntf returns the vendor specific name of an interface of the
+		// named node, e.g. {{nodeIntf .PeerName .PeerIntName}}.
+		"nodeIntf": func(node, name string) (string, error) {
+			n, ok := nodes[node]
+			if !ok {
+				return "", fmt.Errorf("unknown node %q", node)
+			}
+			return VendorIntfName(n, name)
+		},
+		// vendorIntf returns the vendor specific name of an interface of a
+		// node of the named vendor with the default model, e.g.
+		// {{vendorIntf "ARISTA" "eth1"}}.
+		"vendorIntf": func(vendor, name string) (string, error) {
+			v, ok := tpb.Vendor_value[vendor]
+			if !ok {
+				return "", fmt.Errorf("unknown vendor %q", vendor)
+			}
+			return VendorIntfName(&tpb.Node{Vendor: tpb.Vendor(v)}, name)
+		},
+		// ip returns the address of an address with a prefix length.
+		"ip": func(s string) (string, error) {
+			ip, _, err := parseIP(s)
+			if err != nil {
+				return "", err
+			}
+			return ip.String(), nil
+		},
+		// prefixLen returns the prefix length of an address.
+		"prefixLen": func(s string) (int, error) {
+			_, n, err := parseIP(s)
+			if err != nil {
+				return 0, err
+			}
+			if n == nil {
+				return 0, fmt.Errorf("address %q has no prefix length", s)
+			}
+			l, _ := n.Mask.Size()
+			return l, nil
+		},
+		// netmask returns the dotted netmask of an IPv4 address.
+		"netmask": func(s string) (string, error) {
+			_, n, err := parseIP(s)
+			if err != nil {
+				return "", err
+			}
+			if n == nil || len(n.Mask) != net.IPv4len {
+				return "", fmt.Errorf("address %q is not an IPv4 address with a prefix length", s)
+			}
+			return net.IP(n.Mask).String(), nil
+		},
+	}
+}
+
+// RenderConfig renders the startup config template data of the node pb in
+// the topology t. Missing map keys are an error so typos in label names are
+// caught early.
+func RenderConfig(data []byte, pb *tpb.Node, t *tpb.Topology) ([]byte, error) {
+	tmpl, err := template.New(pb.GetName()).Funcs(templateFuncs(pb, templateNodes(pb, t))).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config template for node %q: %w", pb.GetName(), err)
+	}
+	td, err := NewTemplateData(pb, t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render config template for node %q: %w", pb.GetName(), err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, td); err != nil {
+		return nil, fmt.Errorf("failed to render config template for node %q: %w", pb.GetName(), err)
+	}
+	return b.Bytes(), nil
+}
+
+// ReadConfig returns the startup config of the node pb in the topology t,
+// rendered if it is a template. Config files are relative to basePath. nil is
+// returned if the node has no startup config.
+func ReadConfig(basePath string, pb *tpb.Node, t *tpb.Topology) ([]byte, error) {
+	var data []byte
+	switch v := pb.GetConfig().GetConfigData().(type) {
+	case *tpb.Config_File:
+		if v == nil {
+			return nil, nil
+		}
+		p := v.File
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(basePath, p)
+		}
+		var err error
+		if data, err = os.ReadFile(p); err != nil {
+			return nil, err
+		}
+	case *tpb.Config_Data:
+		if v == nil {
+			return nil, nil
+		}
+		data = v.Data
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("config type not supported: %T", v)
+	}
+	if !pb.GetConfig().GetConfigTemplate() {
+		return data, nil
+	}
+	return RenderConfig(data, pb, t)
+}
+
+// ReadConfig returns the startup config of the node, rendered if it is a
+// template.
+func (n *Impl) ReadConfig() ([]byte, error) {
+	return ReadConfig(n.BasePath, n.Proto, n.Topology)
+}
@@ -0,0 +1,243 @@
+package node
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+)
+
+// fakeIntfVendor names the interfaces ethN of its nodes portN, or
+// port<model>/N if the node has a model.
+var fakeIntfVendor = topopb.Vendor(1003)
+
+func fakeIntfName(pb *topopb.Node, name string) (string, error) {
+	i, ok := EthIntfNum(name)
+	if !ok {
+		return name, nil
+	}
+	if i > 10 {
+		return "", fmt.Errorf("interface %q can not be mapped", name)
+	}
+	if pb.GetModel() != "" {
+		return fmt.Sprintf("port%s/%d", pb.GetModel(), i), nil
+	}
+	return fmt.Sprintf("port%d", i), nil
+}
+
+func init() {
+	VendorIntfNames(fakeIntfVendor, fakeIntfName)
+}
+
+func TestVendorIntfName(t *testing.T) {
+	tests := []struct {
+		desc    string
+		node    *topopb.Node
+		name    string
+		want    string
+		wantErr string
+	}{{
+		desc: "vendor name",
+		node: &topopb.Node{Vendor: fakeIntfVendor},
+		name: "eth2",
+		want: "port2",
+	}, {
+		desc: "model name",
+		node: &topopb.Node{Vendor: fakeIntfVendor, Model: "x"},
+		name: "eth2",
+		want: "portx/2",
+	}, {
+		desc: "interface config name",
+		node: &topopb.Node{
+			Vendor:     fakeIntfVendor,
+			Interfaces: map[string]*topopb.Interface{"eth2": {Name: "port2:1"}},
+		},
+		name: "eth2",
+		want: "port2:1",
+	}, {
+		desc: "not ethN",
+		node: &topopb.Node{Vendor: fakeIntfVendor},
+		name: "eth0",
+		want: "eth0",
+	}, {
+		desc: "no naming scheme",
+		node: &topopb.Node{Vendor: topopb.Vendor_HOST},
+		name: "eth1",
+		want: "eth1",
+	}, {
+		desc:    "invalid interface",
+		node:    &topopb.Node{Vendor: fakeIntfVendor},
+		name:    "eth11",
+		wantErr: `interface "eth11" can not be mapped`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := VendorIntfName(tt.node, tt.name)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("VendorIntfName() failed: %s", s)
+			}
+			if got != tt.want {
+				t.Errorf("VendorIntfName() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderConfig(t *testing.T) {
+	r1 := &topopb.Node{
+		Name:   "r1",
+		Vendor: topopb.Vendor_ARISTA,
+		Model:  "ceos",
+		Labels: map[string]string{"loopback": "10.1.1.1/32"},
+		Interfaces: map[string]*topopb.Interface{
+			"eth10": {
+				Name:        "Ethernet10",
+				IpAddress:   "192.168.0.2/31",
+				PeerName:    "r2",
+				PeerIntName: "eth2",
+			},
+			"eth2": {
+				Name:        "Ethernet2/1",
+				IpAddress:   "192.168.0.0/31",
+				PeerName:    "r2",
+				PeerIntName: "eth1",
+			},
+		},
+		Services: map[uint32]*topopb.Service{
+			6030: {Name: "gnmi", Inside: 6030, Outside: 6030},
+			22:   {Name: "ssh", Inside: 22, Outside: 22},
+		},
+	}
+	r2 := &topopb.Node{
+		Name:   "r2",
+		Vendor: fakeIntfVendor,
+		Interfaces: map[string]*topopb.Interface{
+			"eth1": {IpAddress: "192.168.0.1/31"},
+			"eth2": {IpAddress: "192.168.0.3/31"},
+		},
+	}
+	topo := &topopb.Topology{
+		Name:  "test",
+		Nodes: []*topopb.Node{r1, r2},
+	}
+	tests := []struct {
+		desc    string
+		tmpl    string
+		want    string
+		wantErr string
+	}{{
+		desc: "node",
+		tmpl: "hostname {{.Name}}\nvendor {{.Vendor}} {{.Model}}\nloopback {{ip .Labels.loopback}}",
+		want: "hostname r1\nvendor ARISTA ceos\nloopback 10.1.1.1",
+	}, {
+		desc: "interfaces",
+		tmpl: `{{range .Interfaces}}interface {{.VendorName}}
+  ip address {{ip .IPAddress}}/{{prefixLen .IPAddress}} peer {{.PeerName}} {{nodeIntf .PeerName .PeerIntName}} {{ip .PeerIPAddress}}
+{{end}}`,
+		want: `interface Ethernet2/1
+  ip address 192.168.0.0/31 peer r2 port1 192.168.0.1
+interface Ethernet10
+  ip address 192.168.0.2/31 peer r2 port2 192.168.0.3
+`,
+	}, {
+		desc: "services",
+		tmpl: "{{range .Services}}{{.Name}}:{{.Inside}} {{end}}",
+		want: "ssh:22 gnmi:6030 ",
+	}, {
+		desc: "helpers",
+		tmpl: `{{intf "eth2"}} {{intf "eth10"}} {{vendorIntf "HOST" "eth1"}} {{netmask "10.0.0.1/24"}}`,
+		want: "Ethernet2/1 Ethernet10 eth1 255.255.255.0",
+	}, {
+		desc: "topology",
+		tmpl: `{{.Topology.Name}} {{with index .Topology.Nodes "r2"}}{{.Vendor}} {{(index .Interfaces 0).IPAddress}}{{end}}`,
+		want: "test 1003 192.168.0.1/31",
+	}, {
+		desc:    "missing label",
+		tmpl:    "{{.Labels.dne}}",
+		wantErr: `map has no entry for key "dne"`,
+	}, {
+		desc:    "invalid template",
+		tmpl:    "{{.Name",
+		wantErr: `failed to parse config template for node "r1"`,
+	}, {
+		desc:    "invalid address",
+		tmpl:    `{{ip "foo"}}`,
+		wantErr: `invalid IP address "foo"`,
+	}, {
+		desc:    "unknown vendor",
+		tmpl:    `{{vendorIntf "FOO" "eth1"}}`,
+		wantErr: `unknown vendor "FOO"`,
+	}, {
+		desc:    "unknown node",
+		tmpl:    `{{nodeIntf "r3" "eth1"}}`,
+		wantErr: `unknown node "r3"`,
+	}, {
+		desc:    "invalid interface",
+		tmpl:    `{{nodeIntf "r2" "eth11"}}`,
+		wantErr: `interface "eth11" can not be mapped`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := RenderConfig([]byte(tt.tmpl), r1, topo)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("RenderConfig() failed: %s", s)
+			}
+			if s := cmp.Diff(tt.want, string(got)); s != "" {
+				t.Errorf("RenderConfig() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		desc    string
+		config  *topopb.Config
+		want    string
+		wantErr string
+	}{{
+		desc: "no config",
+	}, {
+		desc: "data",
+		config: &topopb.Config{
+			ConfigData: &topopb.Config_Data{Data: []byte("hostname {{.Name}}")},
+		},
+		want: "hostname {{.Name}}",
+	}, {
+		desc: "data template",
+		config: &topopb.Config{
+			ConfigTemplate: true,
+			ConfigData:     &topopb.Config_Data{Data: []byte("hostname {{.Name}}")},
+		},
+		want: "hostname r1",
+	}, {
+		desc: "file",
+		config: &topopb.Config{
+			ConfigData: &topopb.Config_File{File: "testdata/small.cfg"},
+		},
+		want: "test config\n",
+	}, {
+		desc: "file dne",
+		config: &topopb.Config{
+			ConfigTemplate: true,
+			ConfigData:     &topopb.Config_File{File: "testdata/dne.cfg"},
+		},
+		wantErr: "no such file",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Proto: &topopb.Node{Name: "r1", Config: tt.config},
+			}
+			got, err := n.ReadConfig()
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("ReadConfig() failed: %s", s)
+			}
+			if s := cmp.Diff(tt.want, string(got)); s != "" {
+				t.Errorf("ReadConfig() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
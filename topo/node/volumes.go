@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package node
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	log "k8s.io/klog/v2"
+)
+
+// volumeLabel is the label of retained PersistentVolumes holding the name of
+// the node volume. The topology and node are stored in the topo and app
+// labels.
+const volumeLabel = "volume"
+
+func (n *Impl) volumeClaimName(v *tpb.PersistentVolume) string {
+	return fmt.Sprintf("%s-%s", n.Name(), v.GetName())
+}
+
+func (n *Impl) volumeLabels(v *tpb.PersistentVolume) map[string]string {
+	return map[string]string{
+		"app":       n.Name(),
+		"topo":      n.Namespace,
+		volumeLabel: v.GetName(),
+	}
+}
+
+// retainedVolume returns the PersistentVolume retained for v when the
+// topology was last deleted, or nil if there is none. The claim reference of
+// the volume is cleared so that it can be bound by a new claim.
+func (n *Impl) retainedVolume(ctx context.Context, v *tpb.PersistentVolume) (*corev1.PersistentVolume, error) {
+	pvs, err := n.KubeClient.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(n.volumeLabels(v)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pvs.Items {
+		pv := &pvs.Items[i]
+		if pv.Status.Phase != corev1.VolumeReleased && pv.Status.Phase != corev1.VolumeAvailable {
+			continue
+		}
+		if pv.Spec.ClaimRef != nil {
+			pv.Spec.ClaimRef = nil
+			if pv, err = n.KubeClient.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{}); err != nil {
+				return nil, err
+			}
+		}
+		return pv, nil
+	}
+	return nil, nil
+}
+
+// createVolumeClaim creates the PersistentVolumeClaim for v, bound to the
+// volume retained for it if any. An existing claim, for example when the
+// node is restarted, is reused.
+func (n *Impl) createVolumeClaim(ctx context.Context, v *tpb.PersistentVolume) error {
+	if errs := validation.IsDNS1123Label(v.GetName()); len(errs) > 0 {
+		return fmt.Errorf("invalid name %q: %v", v.GetName(), errs)
+	}
+	if !filepath.IsAbs(v.GetMountPath()) {
+		return fmt.Errorf("mount path %q must be an absolute path", v.GetMountPath())
+	}
+	size, err := resource.ParseQuantity(v.GetSize())
+	if err != nil {
+		return fmt.Errorf("invalid size %q: %w", v.GetSize(), err)
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: n.volumeClaimName(v),
+			Labels: map[string]string{
+				"app":  n.Name(),
+				"topo": n.Namespace,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if sc := v.GetStorageClass(); sc != "" {
+		pvc.Spec.StorageClassName = &sc
+	}
+	if _, err := n.KubeClient.CoreV1().PersistentVolumeClaims(n.Namespace).Get(ctx, pvc.Name, metav1.GetOptions{}); err == nil {
+		log.Infof("Reusing PersistentVolumeClaim %q for node %s", pvc.Name, n.Name())
+		return nil
+	}
+	pv, err := n.retainedVolume(ctx, v)
+	if err != nil {
+		return fmt.Errorf("failed to find retained volume: %w", err)
+	}
+	if pv != nil {
+		log.Infof("Reattaching retained PersistentVolume %q to node %s", pv.Name, n.Name())
+		pvc.Spec.VolumeName = pv.Name
+	}
+	if _, err := n.KubeClient.CoreV1().PersistentVolumeClaims(n.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	log.Infof("Created PersistentVolumeClaim %q for node %s", pvc.Name, n.Name())
+	return nil
+}
+
+// AddVolumes creates the PersistentVolumeClaims for the persistent volumes of
+// the node and mounts them into all containers of the pod.
+func (n *Impl) AddVolumes(ctx context.Context, pod *corev1.Pod) error {
+	for _, v := range n.GetProto().GetConfig().GetVolumes() {
+		if err := n.createVolumeClaim(ctx, v); err != nil {
+			return fmt.Errorf("failed to create volume %q: %w", v.GetName(), err)
+		}
+		name := fmt.Sprintf("volume-%s", v.GetName())
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: n.volumeClaimName(v),
+				},
+			},
+		})
+		for i, c := range pod.Spec.Containers {
+			pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+				Name:      name,
+				MountPath: v.GetMountPath(),
+			})
+		}
+	}
+	return nil
+}
+
+// ReleaseVolumes applies the policy of each persistent volume of the node
+// before the topology is deleted. The PersistentVolume bound to a volume with
+// the RETAIN policy is labeled and kept when its claim is deleted, so that it
+// is reattached when the topology is created again. policy overrides the
+// policy of all volumes if not nil.
+func (n *Impl) ReleaseVolumes(ctx context.Context, policy *tpb.PersistentVolume_Policy) error {
+	for _, v := range n.GetProto().GetConfig().GetVolumes() {
+		p := v.GetPolicy()
+		if policy != nil {
+			p = *policy
+		}
+		pvc, err := n.KubeClient.CoreV1().PersistentVolumeClaims(n.Namespace).Get(ctx, n.volumeClaimName(v), metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return err
+		}
+		if pvc.Spec.VolumeName == "" {
+			if p == tpb.PersistentVolume_RETAIN {
+				log.Warningf("PersistentVolumeClaim %q is not bound, nothing to retain", pvc.Name)
+			}
+			continue
+		}
+		pv, err := n.KubeClient.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch p {
+		case tpb.PersistentVolume_RETAIN:
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			if pv.Labels == nil {
+				pv.Labels = map[string]string{}
+			}
+			for k, v := range n.volumeLabels(v) {
+				pv.Labels[k] = v
+			}
+			log.Infof("Retaining PersistentVolume %q of node %s", pv.Name, n.Name())
+		default:
+			// Only volumes retained by a previous topology are changed, others
+			// keep the reclaim policy of their storage class.
+			if pv.Labels[volumeLabel] == "" {
+				continue
+			}
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+			delete(pv.Labels, volumeLabel)
+			log.Infof("Deleting PersistentVolume %q of node %s with the topology", pv.Name, n.Name())
+		}
+		if _, err := n.KubeClient.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
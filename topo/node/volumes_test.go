@@ -0,0 +1,286 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	topopb "github.com/openconfig/kne/proto/topo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddVolumes(t *testing.T) {
+	ctx := context.Background()
+	fast := "fast"
+	retained := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pv-1",
+			Labels: map[string]string{"app": "dev1", "topo": "test", "volume": "logs"},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "test", Name: "dev1-logs"},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+	}
+	tests := []struct {
+		desc      string
+		objs      []runtime.Object
+		volumes   []*topopb.PersistentVolume
+		wantPVC   *corev1.PersistentVolumeClaim
+		wantPV    *corev1.PersistentVolume
+		wantMount []corev1.VolumeMount
+		wantErr   string
+	}{{
+		desc: "no volumes",
+	}, {
+		desc: "new claim",
+		volumes: []*topopb.PersistentVolume{{
+			Name:         "logs",
+			Size:         "1Gi",
+			StorageClass: "fast",
+			MountPath:    "/var/log",
+		}},
+		wantPVC: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev1-logs",
+				Namespace: "test",
+				Labels:    map[string]string{"app": "dev1", "topo": "test"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+				StorageClassName: &fast,
+			},
+		},
+		wantMount: []corev1.VolumeMount{{Name: "volume-logs", MountPath: "/var/log"}},
+	}, {
+		desc: "existing claim",
+		objs: []runtime.Object{&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-logs", Namespace: "test"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+		}},
+		volumes: []*topopb.PersistentVolume{{
+			Name:      "logs",
+			Size:      "1Gi",
+			MountPath: "/var/log",
+		}},
+		wantPVC: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-logs", Namespace: "test"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+		},
+		wantMount: []corev1.VolumeMount{{Name: "volume-logs", MountPath: "/var/log"}},
+	}, {
+		desc: "retained volume",
+		objs: []runtime.Object{retained},
+		volumes: []*topopb.PersistentVolume{{
+			Name:      "logs",
+			Size:      "1Gi",
+			MountPath: "/var/log",
+			Policy:    topopb.PersistentVolume_RETAIN,
+		}},
+		wantPVC: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev1-logs",
+				Namespace: "test",
+				Labels:    map[string]string{"app": "dev1", "topo": "test"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+				VolumeName: "pv-1",
+			},
+		},
+		wantPV: &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pv-1",
+				Labels: map[string]string{"app": "dev1", "topo": "test", "volume": "logs"},
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+		},
+		wantMount: []corev1.VolumeMount{{Name: "volume-logs", MountPath: "/var/log"}},
+	}, {
+		desc: "invalid size",
+		volumes: []*topopb.PersistentVolume{{
+			Name:      "logs",
+			Size:      "big",
+			MountPath: "/var/log",
+		}},
+		wantErr: `invalid size "big"`,
+	}, {
+		desc: "invalid name",
+		volumes: []*topopb.PersistentVolume{{
+			Name:      "Logs",
+			Size:      "1Gi",
+			MountPath: "/var/log",
+		}},
+		wantErr: `invalid name "Logs"`,
+	}, {
+		desc: "relative mount path",
+		volumes: []*topopb.PersistentVolume{{
+			Name:      "logs",
+			Size:      "1Gi",
+			MountPath: "log",
+		}},
+		wantErr: "must be an absolute path",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(tt.objs...),
+				Proto: &topopb.Node{
+					Name:   "dev1",
+					Config: &topopb.Config{Volumes: tt.volumes},
+				},
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "dev1"}},
+				},
+			}
+			err := n.AddVolumes(ctx, pod)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("AddVolumes() failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.wantMount, pod.Spec.Containers[0].VolumeMounts); s != "" {
+				t.Errorf("AddVolumes() unexpected volume mounts diff (-want +got):\n%s", s)
+			}
+			if tt.wantPVC != nil {
+				got, err := n.KubeClient.CoreV1().PersistentVolumeClaims("test").Get(ctx, tt.wantPVC.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("AddVolumes() did not create claim %q: %v", tt.wantPVC.Name, err)
+				}
+				if s := cmp.Diff(tt.wantPVC, got); s != "" {
+					t.Errorf("AddVolumes() unexpected claim diff (-want +got):\n%s", s)
+				}
+			}
+			if tt.wantPV != nil {
+				got, err := n.KubeClient.CoreV1().PersistentVolumes().Get(ctx, tt.wantPV.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("failed to get volume %q: %v", tt.wantPV.Name, err)
+				}
+				if s := cmp.Diff(tt.wantPV, got); s != "" {
+					t.Errorf("AddVolumes() unexpected volume diff (-want +got):\n%s", s)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseVolumes(t *testing.T) {
+	ctx := context.Background()
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev1-logs", Namespace: "test"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-0"},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+		},
+	}
+	retainedPV := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pv-0",
+			Labels: map[string]string{"app": "dev1", "topo": "test", "volume": "logs"},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+		},
+	}
+	retain := topopb.PersistentVolume_RETAIN
+	del := topopb.PersistentVolume_DELETE
+	tests := []struct {
+		desc     string
+		objs     []runtime.Object
+		policy   topopb.PersistentVolume_Policy
+		override *topopb.PersistentVolume_Policy
+		want     *corev1.PersistentVolume
+		wantErr  string
+	}{{
+		desc: "no claim",
+	}, {
+		desc: "unbound claim",
+		objs: []runtime.Object{&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev1-logs", Namespace: "test"},
+		}},
+		policy: topopb.PersistentVolume_RETAIN,
+	}, {
+		desc:   "retain",
+		objs:   []runtime.Object{claim, pv},
+		policy: topopb.PersistentVolume_RETAIN,
+		want:   retainedPV,
+	}, {
+		desc:     "retain override",
+		objs:     []runtime.Object{claim, pv},
+		override: &retain,
+		want:     retainedPV,
+	}, {
+		desc: "delete",
+		objs: []runtime.Object{claim, pv},
+		want: pv,
+	}, {
+		desc:     "delete previously retained",
+		objs:     []runtime.Object{claim, retainedPV},
+		policy:   topopb.PersistentVolume_RETAIN,
+		override: &del,
+		want: &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pv-0",
+				Labels: map[string]string{"app": "dev1", "topo": "test"},
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+			},
+		},
+	}, {
+		desc:    "volume dne",
+		objs:    []runtime.Object{claim},
+		wantErr: `"pv-0" not found`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n := &Impl{
+				Namespace:  "test",
+				KubeClient: kfake.NewSimpleClientset(tt.objs...),
+				Proto: &topopb.Node{
+					Name: "dev1",
+					Config: &topopb.Config{
+						Volumes: []*topopb.PersistentVolume{{
+							Name:      "logs",
+							Size:      "1Gi",
+							MountPath: "/var/log",
+							Policy:    tt.policy,
+						}},
+					},
+				},
+			}
+			err := n.ReleaseVolumes(ctx, tt.override)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("ReleaseVolumes() failed: %s", s)
+			}
+			if tt.want == nil {
+				return
+			}
+			got, err := n.KubeClient.CoreV1().PersistentVolumes().Get(ctx, tt.want.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get volume %q: %v", tt.want.Name, err)
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("ReleaseVolumes() unexpected volume diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
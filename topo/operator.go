@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// operator returns the Operator of the provided node if it supports op. If the
+// node does not fulfill Operator or does not support op then
+// status.Unimplemented error will be returned.
+func (m *Manager) operator(nodeName string, op node.Operation) (node.Operator, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+	o, ok := n.(node.Operator)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "node %q does not implement Operator interface", nodeName)
+	}
+	if !slices.Contains(o.Operations(), op) {
+		return nil, status.Errorf(codes.Unimplemented, "node %q does not support %s", nodeName, op)
+	}
+	return o, nil
+}
+
+// Operations returns the operations supported by the provided node.
+func (m *Manager) Operations(nodeName string) ([]node.Operation, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+	o, ok := n.(node.Operator)
+	if !ok {
+		return nil, nil
+	}
+	return o.Operations(), nil
+}
+
+// Reboot reboots the provided node.
+func (m *Manager) Reboot(ctx context.Context, nodeName string, opts *node.RebootOptions) error {
+	o, err := m.operator(nodeName, node.OperationReboot)
+	if err != nil {
+		return err
+	}
+	return o.Reboot(ctx, opts)
+}
+
+// PutFile writes the contents of r to the remote file on the provided node.
+func (m *Manager) PutFile(ctx context.Context, nodeName, remote string, r io.Reader, perm os.FileMode) error {
+	o, err := m.operator(nodeName, node.OperationFilePut)
+	if err != nil {
+		return err
+	}
+	return o.PutFile(ctx, remote, r, perm)
+}
+
+// GetFile writes the contents of the remote file on the provided node to w.
+func (m *Manager) GetFile(ctx context.Context, nodeName, remote string, w io.Writer) error {
+	o, err := m.operator(nodeName, node.OperationFileGet)
+	if err != nil {
+		return err
+	}
+	return o.GetFile(ctx, remote, w)
+}
+
+// InstallCertificate issues a cert signed by the topology CA to the provided
+// node and installs it on the node with the provided id.
+func (m *Manager) InstallCertificate(ctx context.Context, nodeName, id string) error {
+	o, err := m.operator(nodeName, node.OperationCertInstall)
+	if err != nil {
+		return err
+	}
+	cert, err := m.issueCert(ctx, m.nodes[nodeName], "", 0)
+	if err != nil {
+		return err
+	}
+	return o.InstallCertificate(ctx, id, cert)
+}
+
+// Ping pings a destination from the provided node.
+func (m *Manager) Ping(ctx context.Context, nodeName string, opts *node.PingOptions) (*node.PingResult, error) {
+	o, err := m.operator(nodeName, node.OperationPing)
+	if err != nil {
+		return nil, err
+	}
+	return o.Ping(ctx, opts)
+}
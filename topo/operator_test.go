@@ -0,0 +1,146 @@
+package topo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeOperator struct {
+	*node.Impl
+	ops   []node.Operation
+	calls []string
+	cert  *node.Cert
+}
+
+func (f *fakeOperator) Operations() []node.Operation {
+	return f.ops
+}
+
+func (f *fakeOperator) Reboot(_ context.Context, opts *node.RebootOptions) error {
+	f.calls = append(f.calls, "reboot "+opts.Message)
+	return nil
+}
+
+func (f *fakeOperator) PutFile(_ context.Context, remote string, r io.Reader, perm os.FileMode) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.calls = append(f.calls, "put "+remote+" "+perm.String()+" "+string(b))
+	return nil
+}
+
+func (f *fakeOperator) GetFile(_ context.Context, remote string, w io.Writer) error {
+	f.calls = append(f.calls, "get "+remote)
+	_, err := io.WriteString(w, "contents of "+remote)
+	return err
+}
+
+func (f *fakeOperator) InstallCertificate(_ context.Context, id string, cert *node.Cert) error {
+	f.calls = append(f.calls, "cert "+id)
+	f.cert = cert
+	return nil
+}
+
+func (f *fakeOperator) Ping(_ context.Context, opts *node.PingOptions) (*node.PingResult, error) {
+	f.calls = append(f.calls, "ping "+opts.Destination)
+	return &node.PingResult{Sent: opts.Count, Received: opts.Count}, nil
+}
+
+func TestOperator(t *testing.T) {
+	origRetryInterval := certSANsRetryInterval
+	defer func() {
+		certSANsRetryInterval = origRetryInterval
+	}()
+	certSANsRetryInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	kClient := kfake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "test"},
+		Status:     corev1.PodStatus{PodIP: "10.244.0.5"},
+	})
+	impl := &node.Impl{Namespace: "test", KubeClient: kClient, Proto: &tpb.Node{Name: "r1"}}
+	all := &fakeOperator{Impl: impl, ops: node.AllOperations}
+	rebootOnly := &fakeOperator{Impl: impl, ops: []node.Operation{node.OperationReboot}}
+	m := &Manager{
+		topo: &tpb.Topology{
+			Name: "test",
+			Ca:   &tpb.CertificateAuthority{KeySize: 1024, BundleFile: filepath.Join(t.TempDir(), "ca.pem")},
+		},
+		nodes: map[string]node.Node{
+			"r1": all,
+			"r2": rebootOnly,
+			"r3": &node.Impl{Proto: &tpb.Node{Name: "r3"}},
+		},
+		kClient: kClient,
+	}
+
+	if err := m.Reboot(ctx, "r1", &node.RebootOptions{Message: "test"}); err != nil {
+		t.Errorf("Reboot() unexpected error: %v", err)
+	}
+	if err := m.PutFile(ctx, "r1", "/tmp/f", strings.NewReader("data"), 0644); err != nil {
+		t.Errorf("PutFile() unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := m.GetFile(ctx, "r1", "/tmp/f", &buf); err != nil {
+		t.Errorf("GetFile() unexpected error: %v", err)
+	}
+	if got, want := buf.String(), "contents of /tmp/f"; got != want {
+		t.Errorf("GetFile() got %q, want %q", got, want)
+	}
+	if err := m.InstallCertificate(ctx, "r1", "kne"); err != nil {
+		t.Errorf("InstallCertificate() unexpected error: %v", err)
+	}
+	if all.cert == nil || !bytes.Equal(all.cert.CAPEM, m.ca.CertPEM()) {
+		t.Errorf("InstallCertificate() did not install a cert issued by the topology CA")
+	}
+	res, err := m.Ping(ctx, "r1", &node.PingOptions{Destination: "10.0.0.2", Count: 3})
+	if err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+	if res.Received != 3 {
+		t.Errorf("Ping() received %d replies, want 3", res.Received)
+	}
+	want := []string{"reboot test", "put /tmp/f -rw-r--r-- data", "get /tmp/f", "cert kne", "ping 10.0.0.2"}
+	if s := cmp.Diff(want, all.calls); s != "" {
+		t.Errorf("unexpected operator calls (-want +got):\n%s", s)
+	}
+
+	if err := m.Reboot(ctx, "r2", &node.RebootOptions{}); err != nil {
+		t.Errorf("Reboot() unexpected error: %v", err)
+	}
+	if _, err := m.Ping(ctx, "r2", &node.PingOptions{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Ping() of unsupported operation got error %v, want code %s", err, codes.Unimplemented)
+	}
+	if _, err := m.Ping(ctx, "r3", &node.PingOptions{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Ping() of node without gnoi service got error %v, want code %s", err, codes.Unimplemented)
+	}
+	_, err = m.Ping(ctx, "r4", &node.PingOptions{})
+	if s := errdiff.Check(err, `node "r4" not found`); s != "" {
+		t.Errorf("Ping() unexpected error: %s", s)
+	}
+
+	ops, err := m.Operations("r2")
+	if err != nil {
+		t.Fatalf("Operations() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]node.Operation{node.OperationReboot}, ops); s != "" {
+		t.Errorf("Operations() unexpected diff (-want +got):\n%s", s)
+	}
+}
@@ -0,0 +1,89 @@
+package topo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/kne/proto/controller"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo/node"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	ktest "k8s.io/client-go/testing"
+)
+
+func TestCreateProgress(t *testing.T) {
+	ctx := context.Background()
+	origKindClusterIsKind := kindClusterIsKind
+	defer func() {
+		kindClusterIsKind = origKindClusterIsKind
+	}()
+	kindClusterIsKind = func() (bool, error) {
+		return false, nil
+	}
+	node.Vendor(tpb.Vendor(1010), NewConfigurable)
+	topo := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1010),
+			Config: &tpb.Config{},
+		}},
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	kf := kfake.NewSimpleClientset()
+	kf.PrependReactor("get", "pods", func(action ktest.Action) (bool, runtime.Object, error) {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: action.(ktest.GetAction).GetName()}}
+		p.Status.Phase = corev1.PodRunning
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		return true, p, nil
+	})
+
+	var mu sync.Mutex
+	var phases []string
+	var nodes []*cpb.NodeStatus
+	f := func(p *cpb.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if p.GetTime() == nil {
+			t.Errorf("Create() reported progress without time: %v", p)
+		}
+		switch u := p.GetUpdate().(type) {
+		case *cpb.Progress_Phase:
+			phases = append(phases, u.Phase)
+		case *cpb.Progress_Node:
+			nodes = append(nodes, u.Node)
+		}
+	}
+	m, err := New(topo, WithClusterConfig(&rest.Config{}), WithKubeClient(kf), WithTopoClient(tf), WithProgressFunc(f))
+	if err != nil {
+		t.Fatalf("New() failed to create new topology manager: %v", err)
+	}
+	if err := m.Create(ctx, 0); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	wantPhases := []string{
+		"Creating namespace",
+		"Creating meshnet topologies",
+		"Creating nodes",
+		"Waiting for nodes",
+		"Topology created",
+	}
+	if s := cmp.Diff(wantPhases, phases); s != "" {
+		t.Errorf("Create() unexpected phases (-want +got):\n%s", s)
+	}
+	if len(nodes) != 1 || nodes[0].GetName() != "r1" || nodes[0].GetState() != cpb.NodeState_NODE_STATE_RUNNING {
+		t.Errorf("Create() reported node statuses %v, want r1 running", nodes)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/kne/topo/node"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+)
+
+// Reconcile creates the resources of the topology missing from the cluster:
+// its namespace, its meshnet topologies and the nodes without a pod, such as
+// nodes whose pod was deleted. Unlike Create it does not wait for the nodes
+// to be running. It returns the sorted names of the nodes it created.
+func (m *Manager) Reconcile(ctx context.Context) ([]string, error) {
+	for _, n := range m.nodes {
+		if err := n.ValidateConstraints(); err != nil {
+			return nil, fmt.Errorf("failed to validate node %s: %w", n, err)
+		}
+	}
+	if err := m.createNamespace(ctx); err != nil {
+		return nil, err
+	}
+	m.saveTopology(ctx)
+	if err := m.createMissingMeshnetTopologies(ctx); err != nil {
+		return nil, err
+	}
+
+	var missing []node.Node
+	for name, n := range m.nodes {
+		pods, err := n.Pods(ctx)
+		switch {
+		case apierrors.IsNotFound(err), err == nil && len(pods) == 0:
+			log.Infof("Node %q has no pod", name)
+			missing = append(missing, n)
+		case err != nil:
+			return nil, fmt.Errorf("could not get pods for node %s: %w", name, err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs errlist.List
+	var created []string
+	for _, n := range missing {
+		wg.Add(1)
+		go func(n node.Node) {
+			defer wg.Done()
+			// The ConfigMaps, Secrets and services of the node which outlived
+			// its pod are updated when the node is created.
+			err := m.createNode(ctx, n)
+			mu.Lock()
+			defer mu.Unlock()
+			if apierrors.IsAlreadyExists(err) {
+				// The pod is managed by the vendor controller from the
+				// custom resource of the node, which recreates it.
+				log.Infof("Node %q is already being recreated: %v", n.Name(), err)
+				return
+			}
+			if err != nil {
+				errs.Add(err)
+				return
+			}
+			created = append(created, n.Name())
+		}(n)
+	}
+	wg.Wait()
+	sort.Strings(created)
+	return created, errs.Err()
+}
+
+// createMissingMeshnetTopologies creates the meshnet resources of the nodes
+// which do not exist in the cluster.
+func (m *Manager) createMissingMeshnetTopologies(ctx context.Context) error {
+	existing, err := m.topologyResources(ctx)
+	if err != nil {
+		return err
+	}
+	exists := map[string]bool{}
+	for _, t := range existing {
+		exists[t.Name] = true
+	}
+	specs, err := m.topologySpecs(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get meshnet topologies: %v", err)
+	}
+	for _, t := range specs {
+		if exists[t.Name] {
+			continue
+		}
+		log.Infof("Creating topology for meshnet node %s", t.Name)
+		if _, err := m.tClient.Topology(m.topo.Name).Create(ctx, t, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("could not create topology for meshnet node %s: %v", t.Name, err)
+		}
+	}
+	return nil
+}
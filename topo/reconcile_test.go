@@ -0,0 +1,99 @@
+package topo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo/node"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	node.Vendor(tpb.Vendor(1008), NewConfigurable)
+	topo := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:     "r1",
+			Vendor:   tpb.Vendor(1008),
+			Services: map[uint32]*tpb.Service{22: {Name: "ssh"}},
+			Config: &tpb.Config{
+				ConfigPath: "/etc",
+				ConfigFile: "config.cfg",
+				ConfigData: &tpb.Config_Data{Data: []byte("hostname r1")},
+			},
+		}, {
+			Name:   "r2",
+			Vendor: tpb.Vendor(1008),
+			Config: &tpb.Config{},
+		}},
+		Links: []*tpb.Link{{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1"}},
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	kf := kfake.NewSimpleClientset()
+	m, err := New(topo, WithClusterConfig(&rest.Config{}), WithKubeClient(kf), WithTopoClient(tf))
+	if err != nil {
+		t.Fatalf("New() failed to create new topology manager: %v", err)
+	}
+
+	got, err := m.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]string{"r1", "r2"}, got); s != "" {
+		t.Errorf("Reconcile() unexpected created nodes (-want +got):\n%s", s)
+	}
+	if _, err := kf.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{}); err != nil {
+		t.Errorf("Reconcile() did not create namespace: %v", err)
+	}
+	ts, err := tf.Topology("test").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list meshnet topologies: %v", err)
+	}
+	if len(ts.Items) != 2 {
+		t.Errorf("Reconcile() created %d meshnet topologies, want 2", len(ts.Items))
+	}
+
+	got, err = m.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Reconcile() of existing topology created nodes %v, want none", got)
+	}
+
+	// The config map and service of the node outlive its pod.
+	if err := kf.CoreV1().Pods("test").Delete(ctx, "r1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	kf.ClearActions()
+	got, err = m.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if s := cmp.Diff([]string{"r1"}, got); s != "" {
+		t.Errorf("Reconcile() unexpected recreated nodes (-want +got):\n%s", s)
+	}
+	if _, err := kf.CoreV1().Pods("test").Get(ctx, "r1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Reconcile() did not recreate pod: %v", err)
+	}
+	for _, a := range kf.Actions() {
+		if a.GetVerb() == "delete" {
+			t.Errorf("Reconcile() deleted %s of recreated node", a.GetResource().Resource)
+		}
+	}
+	if _, err := kf.CoreV1().ConfigMaps("test").Get(ctx, "r1-config", metav1.GetOptions{}); err != nil {
+		t.Errorf("Reconcile() did not keep config map: %v", err)
+	}
+	if _, err := kf.CoreV1().Services("test").Get(ctx, "service-r1", metav1.GetOptions{}); err != nil {
+		t.Errorf("Reconcile() did not keep service: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package topo
+
+import (
+	"context"
+	"fmt"
+
+	tpb "github.com/openconfig/kne/proto/topo"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// TopologyLabel is the label of the namespaces of topologies.
+	TopologyLabel = "kne-topology"
+	// TopologyConfigMap is the config map recording the topology in its
+	// namespace.
+	TopologyConfigMap = "kne-topology"
+	// topologyKey is the key of the topology in TopologyConfigMap.
+	topologyKey = "topology.textproto"
+)
+
+// StripPasswords returns a copy of t without the inline passwords of its
+// nodes, so that the topology can be recorded or returned to clients.
+// Credentials from Secrets and files are kept as references.
+func StripPasswords(t *tpb.Topology) *tpb.Topology {
+	t = proto.Clone(t).(*tpb.Topology)
+	for i, n := range t.GetNodes() {
+		t.Nodes[i] = node.StripPassword(n)
+	}
+	return t
+}
+
+// saveTopology records the topology in its namespace, so that it can be
+// loaded by LoadTopology by clients which did not create it. Inline passwords
+// are not recorded, as the ConfigMap can be read by all users of the
+// namespace. Failing to record the topology does not prevent creating it.
+func (m *Manager) saveTopology(ctx context.Context) {
+	b, err := prototext.Marshal(StripPasswords(m.spec))
+	if err != nil {
+		log.Warningf("Failed to marshal topology %q: %v", m.topo.Name, err)
+		return
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   TopologyConfigMap,
+			Labels: map[string]string{TopologyLabel: "true"},
+		},
+		Data: map[string]string{topologyKey: string(b)},
+	}
+	_, err = m.kClient.CoreV1().ConfigMaps(m.topo.Name).Create(ctx, cm, metav1.CreateOptions{})
+	switch {
+	case err == nil, apierrors.IsAlreadyExists(err):
+	default:
+		log.Warningf("Failed to record topology %q in its namespace: %v", m.topo.Name, err)
+	}
+}
+
+// LoadTopology returns the topology recorded in namespace when it was
+// created.
+func LoadTopology(ctx context.Context, kClient kubernetes.Interface, namespace string) (*tpb.Topology, error) {
+	cm, err := kClient.CoreV1().ConfigMaps(namespace).Get(ctx, TopologyConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	t := &tpb.Topology{}
+	if err := prototext.Unmarshal([]byte(cm.Data[topologyKey]), t); err != nil {
+		return nil, fmt.Errorf("invalid topology recorded in namespace %q: %w", namespace, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,83 @@
+package topo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	tpb "github.com/openconfig/kne/proto/topo"
+	tfake "github.com/openconfig/kne/third_party/meshnet/api/clientset/v1beta1/fake"
+	"github.com/openconfig/kne/topo/node"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestLoadTopology(t *testing.T) {
+	ctx := context.Background()
+	node.Vendor(tpb.Vendor(1009), NewConfigurable)
+	topo := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1009),
+			Config: &tpb.Config{},
+			Credentials: &tpb.Credentials{
+				Username: "admin",
+				Password: "admin",
+			},
+		}},
+	}
+	// Inline passwords are not recorded.
+	want := proto.Clone(topo).(*tpb.Topology)
+	want.Nodes[0].Credentials.Password = ""
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	kf := kfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: TopologyConfigMap, Namespace: "invalid"},
+		Data:       map[string]string{topologyKey: "nodes: {"},
+	})
+	m, err := New(topo, WithClusterConfig(&rest.Config{}), WithKubeClient(kf), WithTopoClient(tf))
+	if err != nil {
+		t.Fatalf("New() failed to create new topology manager: %v", err)
+	}
+	if _, err := m.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc      string
+		namespace string
+		want      *tpb.Topology
+		wantErr   string
+	}{{
+		desc:      "recorded",
+		namespace: "test",
+		want:      want,
+	}, {
+		desc:      "not recorded",
+		namespace: "missing",
+		wantErr:   "not found",
+	}, {
+		desc:      "invalid",
+		namespace: "invalid",
+		wantErr:   "invalid topology recorded",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := LoadTopology(ctx, kf, tt.namespace)
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("LoadTopology() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("LoadTopology() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
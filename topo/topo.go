@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/openconfig/kne/exec/run"
 	"github.com/openconfig/kne/metrics"
 	"github.com/openconfig/kne/pods"
+	"github.com/openconfig/kne/progress"
 	cpb "github.com/openconfig/kne/proto/controller"
 	epb "github.com/openconfig/kne/proto/event"
 	tpb "github.com/openconfig/kne/proto/topo"
@@ -42,9 +44,12 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -57,6 +62,7 @@ import (
 	_ "github.com/openconfig/kne/topo/node/cisco"
 	_ "github.com/openconfig/kne/topo/node/drivenets"
 	_ "github.com/openconfig/kne/topo/node/forward"
+	_ "github.com/openconfig/kne/topo/node/generic"
 	_ "github.com/openconfig/kne/topo/node/gobgp"
 	_ "github.com/openconfig/kne/topo/node/host"
 	_ "github.com/openconfig/kne/topo/node/inclusterproxy"
@@ -64,6 +70,7 @@ import (
 	_ "github.com/openconfig/kne/topo/node/keysight"
 	_ "github.com/openconfig/kne/topo/node/nokia"
 	_ "github.com/openconfig/kne/topo/node/openconfig"
+	_ "github.com/openconfig/kne/topo/node/plugin"
 	_ "github.com/openconfig/kne/topo/node/sonic"
 )
 
@@ -86,9 +93,12 @@ type Manager struct {
 	kubecfg        string
 	kClient        kubernetes.Interface
 	tClient        topologyclientv1.Interface
+	dClient        dynamic.Interface
 	rCfg           *rest.Config
 	basePath       string
 	skipDeleteWait bool
+	// volumePolicy overrides the policy of persistent volumes on delete.
+	volumePolicy *tpb.PersistentVolume_Policy
 
 	// If reportUsage is set, report anonymous usage metrics.
 	reportUsage bool
@@ -102,6 +112,17 @@ type Manager struct {
 	// ReportUsage is unset. An empty string will result in the
 	// default topic being used.
 	reportUsageTopicID string
+
+	// caMu guards ca, the CA of the topology once loaded.
+	caMu sync.Mutex
+	ca   *node.CA
+
+	// progressFunc is called with the progress of Create.
+	progressFunc progress.Func
+
+	// spec is the topology as passed to New, before the nodes set their
+	// defaults. It is recorded in the namespace of the topology.
+	spec *tpb.Topology
 }
 
 type Option func(m *Manager)
@@ -124,6 +145,12 @@ func WithTopoClient(c topologyclientv1.Interface) Option {
 	}
 }
 
+func WithDynamicClient(c dynamic.Interface) Option {
+	return func(m *Manager) {
+		m.dClient = c
+	}
+}
+
 func WithClusterConfig(r *rest.Config) Option {
 	return func(m *Manager) {
 		m.rCfg = r
@@ -152,6 +179,23 @@ func WithProgress(b bool) Option {
 	}
 }
 
+// WithProgressFunc returns a Manager Option where f is called with the
+// phases, pod and container state changes, warning events and node status
+// changes of Create.
+func WithProgressFunc(f progress.Func) Option {
+	return func(m *Manager) {
+		m.progressFunc = f
+	}
+}
+
+// WithVolumePolicy overrides the retain or delete policy of the persistent
+// volumes of all nodes when the topology is deleted.
+func WithVolumePolicy(p tpb.PersistentVolume_Policy) Option {
+	return func(m *Manager) {
+		m.volumePolicy = &p
+	}
+}
+
 // WithSkipDeleteWait will not wait for resources to be cleaned up before Delete returns.
 func WithSkipDeleteWait(b bool) Option {
 	return func(m *Manager) {
@@ -169,6 +213,7 @@ func New(topo *tpb.Topology, opts ...Option) (*Manager, error) {
 	}
 	m := &Manager{
 		topo:  topo,
+		spec:  proto.Clone(topo).(*tpb.Topology),
 		nodes: map[string]node.Node{},
 	}
 	for _, o := range opts {
@@ -206,10 +251,17 @@ func New(topo *tpb.Topology, opts ...Option) (*Manager, error) {
 		}
 		m.tClient = tClient
 	}
+	if m.dClient == nil {
+		dClient, err := dynamic.NewForConfig(m.rCfg)
+		if err != nil {
+			return nil, err
+		}
+		m.dClient = dClient
+	}
 	if err := m.load(); err != nil {
 		return nil, fmt.Errorf("failed to load topology: %w", err)
 	}
-	log.V(1).Infof("Created manager for topology:\n%v", prototext.Format(m.topo))
+	log.V(1).Infof("Created manager for topology:\n%v", prototext.Format(StripPasswords(m.topo)))
 	return m, nil
 }
 
@@ -262,7 +314,7 @@ func (m *Manager) reportCreateEvent(ctx context.Context) func(error) {
 
 // Create creates the topology in the cluster.
 func (m *Manager) Create(ctx context.Context, timeout time.Duration) (rerr error) {
-	log.V(1).Infof("Topology:\n%v", prototext.Format(m.topo))
+	log.V(1).Infof("Topology:\n%v", prototext.Format(StripPasswords(m.topo)))
 	if m.reportUsage {
 		finish := m.reportCreateEvent(ctx)
 		defer func() { finish(rerr) }()
@@ -279,6 +331,7 @@ func (m *Manager) Create(ctx context.Context, timeout time.Duration) (rerr error
 		log.Warningf("Failed to start pod watcher: %v", err)
 	} else {
 		w.SetProgress(m.progress)
+		w.SetUpdateFunc(m.progressFunc.Pod)
 		defer func() {
 			cancel()
 			rerr = w.Cleanup(rerr)
@@ -288,6 +341,7 @@ func (m *Manager) Create(ctx context.Context, timeout time.Duration) (rerr error
 		log.Warningf("Failed to start event watcher: %v", err)
 	} else {
 		w.SetProgress(m.progress)
+		w.SetWarningFunc(m.progressFunc.Event)
 		defer func() {
 			cancel()
 			rerr = w.Cleanup(rerr)
@@ -296,20 +350,33 @@ func (m *Manager) Create(ctx context.Context, timeout time.Duration) (rerr error
 	if err := m.push(ctx); err != nil {
 		return fmt.Errorf("failed to create topology %q: %w", m.topo.GetName(), err)
 	}
+	m.progressFunc.Phase("Waiting for nodes")
 	if err := m.checkNodeStatus(ctx, timeout); err != nil {
 		return fmt.Errorf("failed to check status of nodes in topology %q: %w", m.topo.GetName(), err)
 	}
 	log.Infof("Topology %q created", m.topo.GetName())
+	m.progressFunc.Phase("Topology created")
 	return nil
 }
 
 // Delete deletes the topology from the cluster.
 func (m *Manager) Delete(ctx context.Context) error {
-	log.Infof("Topology:\n%v", prototext.Format(m.topo))
+	log.Infof("Topology:\n%v", prototext.Format(StripPasswords(m.topo)))
 	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.topo.Name, metav1.GetOptions{}); err != nil {
 		return fmt.Errorf("topology %q does not exist in cluster", m.topo.Name)
 	}
 
+	// Apply the policy of persistent volumes before their claims are deleted.
+	for name, n := range m.nodes {
+		r, ok := n.(node.VolumeReleaser)
+		if !ok {
+			continue
+		}
+		if err := r.ReleaseVolumes(ctx, m.volumePolicy); err != nil {
+			return fmt.Errorf("failed to release volumes of node %q: %w", name, err)
+		}
+	}
+
 	// Delete topology nodes.
 	for _, n := range m.nodes {
 		if err := n.Delete(ctx); err != nil {
@@ -390,7 +457,7 @@ func waitNSDeleted(ctx context.Context, kClient kubernetes.Interface, ns string,
 
 // Show returns the topology information including services and node health.
 func (m *Manager) Show(ctx context.Context) (*cpb.ShowTopologyResponse, error) {
-	log.Infof("Topology:\n%v", prototext.Format(m.topo))
+	log.Infof("Topology:\n%v", prototext.Format(StripPasswords(m.topo)))
 	r, err := m.Resources(ctx)
 	if err != nil {
 		return nil, err
@@ -415,23 +482,108 @@ func (m *Manager) Show(ctx context.Context) (*cpb.ShowTopologyResponse, error) {
 		}
 	}
 	stateMap := &stateMap{}
+	for name, d := range r.details {
+		stateMap.setNodeState(name, d.Status)
+	}
+	// The topology is returned by the controller server and printed by the
+	// CLI, so only the references to the credentials of the nodes are kept.
+	return &cpb.ShowTopologyResponse{
+		State:        stateMap.topologyState(),
+		Topology:     StripPasswords(m.topo),
+		NodeStatuses: r.GetNodeStatuses(),
+	}, nil
+}
+
+// Credentials returns the resolved credentials of the nodes with
+// credentials by node name, including passwords read from Secrets and
+// files. Nodes whose credentials cannot be resolved are logged and omitted.
+func (m *Manager) Credentials(ctx context.Context) map[string]*tpb.Credentials {
+	creds := map[string]*tpb.Credentials{}
+	for name, n := range m.nodes {
+		c, ok := n.(node.Credentialer)
+		if !ok {
+			continue
+		}
+		cred, err := c.Credentials(ctx)
+		switch {
+		case err != nil:
+			log.Warningf("Failed to resolve credentials of node %q: %v", name, err)
+		case cred != nil:
+			creds[name] = cred
+		}
+	}
+	return creds
+}
+
+// NodeStatuses returns the status of the nodes of the topology with the
+// reason they are in that state, sorted by name.
+func (m *Manager) NodeStatuses(ctx context.Context) []*cpb.NodeStatus {
+	return nodeStatuses(m.statusDetails(ctx))
+}
+
+// statusDetails returns the status details of all nodes. The status comes
+// from the Status of the node, the reason from its StatusDetails if
+// implemented.
+func (m *Manager) statusDetails(ctx context.Context) map[string]*node.StatusDetails {
+	details := map[string]*node.StatusDetails{}
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	for _, n := range m.nodes {
 		wg.Add(1)
 		go func(nod node.Node) {
 			defer wg.Done()
-			phase, _ := nod.Status(ctx)
+			phase, err := nod.Status(ctx)
+			d := &node.StatusDetails{}
+			if sd, ok := nod.(node.StatusDetailer); ok {
+				v, derr := sd.StatusDetails(ctx)
+				if derr != nil {
+					log.V(1).Infof("Failed to get status details of node %s: %v", nod.Name(), derr)
+				} else {
+					d = v
+				}
+			}
+			d.Status = phase
+			switch {
+			case err != nil:
+				d.Reason, d.Message = "StatusError", err.Error()
+			case phase == node.StatusRunning:
+				d.Reason, d.Message = "", ""
+			}
 			mu.Lock()
-			stateMap.setNodeState(nod.Name(), phase)
+			details[nod.Name()] = d
 			mu.Unlock()
 		}(n)
 	}
 	wg.Wait()
-	return &cpb.ShowTopologyResponse{
-		State:    stateMap.topologyState(),
-		Topology: m.topo,
-	}, nil
+	return details
+}
+
+var nodeStates = map[node.Status]cpb.NodeState{
+	node.StatusPending: cpb.NodeState_NODE_STATE_PENDING,
+	node.StatusRunning: cpb.NodeState_NODE_STATE_RUNNING,
+	node.StatusFailed:  cpb.NodeState_NODE_STATE_FAILED,
+	node.StatusUnknown: cpb.NodeState_NODE_STATE_UNKNOWN,
+}
+
+func nodeStatuses(details map[string]*node.StatusDetails) []*cpb.NodeStatus {
+	var statuses []*cpb.NodeStatus
+	for name, d := range details {
+		s := &cpb.NodeStatus{
+			Name:    name,
+			State:   nodeStates[d.Status],
+			Reason:  d.Reason,
+			Message: d.Message,
+			Details: d.Details,
+		}
+		if !d.LastTransitionTime.IsZero() {
+			s.LastTransitionTime = timestamppb.New(d.LastTransitionTime)
+		}
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
 }
 
 func (m *Manager) Watch(ctx context.Context) error {
@@ -514,6 +666,12 @@ func (m *Manager) load() error {
 		zInt.Uid = int64(uid)
 		uid++
 	}
+	for i, mi := range m.topo.Mirrors {
+		if err := loadMirror(nMap, i, mi, int64(uid)); err != nil {
+			return err
+		}
+		uid++
+	}
 	for k, n := range nMap {
 		// Bug: Some vendors incorrectly increase the value of kernel.pid_max which
 		// causes other vendors to have issues. Run this script as a temporary
@@ -524,10 +682,15 @@ func (m *Manager) load() error {
 			}
 		}
 		log.Infof("Adding Node: %s:%s", n.Name, n.Vendor)
-		nn, err := node.New(m.topo.Name, n, m.kClient, m.rCfg, m.basePath, m.kubecfg)
+		nn, err := node.New(m.topo.Name, n, m.kClient, m.rCfg, m.basePath, m.kubecfg, m.topo)
 		if err != nil {
 			return fmt.Errorf("failed to load topology: %w", err)
 		}
+		if n.GetConfig().GetCert().GetCaIssued() != nil {
+			if _, ok := nn.(node.CertInstaller); !ok {
+				return fmt.Errorf("node %q: ca_issued certs are not supported by vendor %s", n.Name, n.Vendor)
+			}
+		}
 		m.nodes[k] = nn
 	}
 	for _, l := range m.topo.Links {
@@ -541,6 +704,104 @@ func (m *Manager) load() error {
 	return nil
 }
 
+var mirrorDirections = map[tpb.Mirror_Direction]string{
+	tpb.Mirror_BOTH:    topologyv1.MirrorBoth,
+	tpb.Mirror_INGRESS: topologyv1.MirrorIngress,
+	tpb.Mirror_EGRESS:  topologyv1.MirrorEgress,
+}
+
+// mirrorIntf returns the name of the interface on the source node of the
+// mirror at index i that the mirrored traffic is sent on.
+func mirrorIntf(i int) string {
+	return fmt.Sprintf("mirror%d", i)
+}
+
+// loadMirror validates the mirror at index i and connects its destination
+// interface to the source node using the provided link uid.
+func loadMirror(nMap map[string]*tpb.Node, i int, mi *tpb.Mirror, uid int64) error {
+	if len(mi.GetSources()) == 0 {
+		return fmt.Errorf("invalid mirror %q: no sources", mi.GetName())
+	}
+	srcNode := mi.GetSources()[0].GetNode()
+	src, ok := nMap[srcNode]
+	if !ok {
+		return fmt.Errorf("invalid mirror %q: missing node %q", mi.GetName(), srcNode)
+	}
+	for _, e := range mi.GetSources() {
+		if e.GetNode() != srcNode {
+			return fmt.Errorf("invalid mirror %q: sources must be on the same node, found %q and %q", mi.GetName(), srcNode, e.GetNode())
+		}
+		if src.Interfaces[e.GetInt()].GetPeerName() == "" {
+			return fmt.Errorf("invalid mirror %q: interface %s:%s is not connected", mi.GetName(), e.GetNode(), e.GetInt())
+		}
+	}
+	if _, ok := src.Interfaces[mirrorIntf(i)]; ok {
+		return fmt.Errorf("invalid mirror %q: interface %s:%s is reserved for the mirror", mi.GetName(), srcNode, mirrorIntf(i))
+	}
+	dstNode, dstInt := mi.GetDestination().GetNode(), mi.GetDestination().GetInt()
+	if dstNode == srcNode {
+		return fmt.Errorf("invalid mirror %q: destination must not be on the source node", mi.GetName())
+	}
+	dst, ok := nMap[dstNode]
+	if !ok {
+		return fmt.Errorf("invalid mirror %q: missing node %q", mi.GetName(), dstNode)
+	}
+	if dstInt == "" {
+		return fmt.Errorf("invalid mirror %q: missing destination interface", mi.GetName())
+	}
+	intf, ok := dst.Interfaces[dstInt]
+	if !ok {
+		intf = &tpb.Interface{
+			IntName: dstInt,
+		}
+		dst.Interfaces[dstInt] = intf
+	}
+	if intf.PeerName != "" {
+		return fmt.Errorf("interface %s:%s already connected", dstNode, dstInt)
+	}
+	log.Infof("Adding Mirror: %s %s:%s", mi.GetName(), dstNode, dstInt)
+	intf.PeerName = srcNode
+	intf.PeerIntName = mirrorIntf(i)
+	intf.Uid = uid
+	return nil
+}
+
+// mirrorSpec adds the mirror at index i to the meshnet resource of its
+// source node: the link to the destination interface and the session sending
+// the traffic of the source interfaces on it.
+func (m *Manager) mirrorSpec(i int, mi *tpb.Mirror, specs []*topologyv1.Topology) error {
+	var spec *topologyv1.Topology
+	srcInt := mi.GetSources()[0].GetInt()
+	for _, s := range specs {
+		for _, l := range s.Spec.Links {
+			if l.LocalIntf == srcInt {
+				spec = s
+			}
+		}
+	}
+	if spec == nil {
+		return fmt.Errorf("could not find meshnet resource for mirror %q source %s:%s", mi.GetName(), mi.GetSources()[0].GetNode(), srcInt)
+	}
+	dst := mi.GetDestination()
+	spec.Spec.Links = append(spec.Spec.Links, topologyv1.Link{
+		UID:       int(m.nodes[dst.GetNode()].GetProto().GetInterfaces()[dst.GetInt()].GetUid()),
+		LocalIntf: mirrorIntf(i),
+		PeerIntf:  dst.GetInt(),
+		PeerPod:   dst.GetNode(),
+	})
+	var srcIntfs []string
+	for _, e := range mi.GetSources() {
+		srcIntfs = append(srcIntfs, e.GetInt())
+	}
+	spec.Spec.Mirrors = append(spec.Spec.Mirrors, topologyv1.Mirror{
+		Name:      mi.GetName(),
+		SrcIntfs:  srcIntfs,
+		Direction: mirrorDirections[mi.GetDirection()],
+		DstIntf:   mirrorIntf(i),
+	})
+	return nil
+}
+
 // setLinkPeer finds the peer pod name and peer interface name for a given interface.
 func setLinkPeer(nodeName string, podName string, link *topologyv1.Link, peerSpecs []*topologyv1.Topology) error {
 	for _, peerSpec := range peerSpecs {
@@ -574,6 +835,13 @@ func (m *Manager) topologySpecs(ctx context.Context) ([]*topologyv1.Topology, er
 		nodeSpecs[n.Name()] = specs
 	}
 
+	// add the mirror links and sessions to the specs of the source nodes
+	for i, mi := range m.topo.GetMirrors() {
+		if err := m.mirrorSpec(i, mi, nodeSpecs[mi.GetSources()[0].GetNode()]); err != nil {
+			return nil, err
+		}
+	}
+
 	// replace node name with pod name, for peer pod attribute in each link
 	for nodeName, specs := range nodeSpecs {
 		for _, spec := range specs {
@@ -603,28 +871,22 @@ func (m *Manager) push(ctx context.Context) error {
 			return fmt.Errorf("failed to validate node %s: %w", n, err)
 		}
 	}
+	if err := m.validatePodPatches(); err != nil {
+		return err
+	}
 
-	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.topo.Name, metav1.GetOptions{}); err != nil {
-		log.Infof("Creating namespace for topology: %q", m.topo.Name)
-		ns := &corev1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: m.topo.Name,
-				Labels: map[string]string{
-					"kne-topology": "true",
-				},
-			},
-		}
-		sNs, err := m.kClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to create namespace %q: %w", ns, err)
-		}
-		log.Infof("Server Namespace: %+v", sNs)
+	m.progressFunc.Phase("Creating namespace")
+	if err := m.createNamespace(ctx); err != nil {
+		return err
 	}
+	m.saveTopology(ctx)
 
+	m.progressFunc.Phase("Creating meshnet topologies")
 	if err := m.createMeshnetTopologies(ctx); err != nil {
 		return fmt.Errorf("failed to create meshnet topologies: %w", err)
 	}
 
+	m.progressFunc.Phase("Creating nodes")
 	log.Infof("Creating Node Pods and Generating certs")
 
 	var wg sync.WaitGroup
@@ -634,24 +896,8 @@ func (m *Manager) push(ctx context.Context) error {
 		wg.Add(1)
 		go func(node node.Node) {
 			defer wg.Done()
-
-			for key, service := range node.GetProto().Services {
-				updateServicePortName(service, key)
-			}
-
-			if err := node.Create(ctx); err != nil {
-				errCh <- fmt.Errorf("failed to create node %s: %w", node, err)
-				return
-			}
-			log.Infof("Node %s resource created", node)
-
-			log.Infof("Generating Self-Signed Certificates for node %s", node)
-
-			err := m.GenerateSelfSigned(ctx, node.Name())
-			switch {
-			case err == nil, status.Code(err) == codes.Unimplemented:
-			default:
-				errCh <- fmt.Errorf("failed to generate cert for node %s: %w", node, err)
+			if err := m.createNode(ctx, node); err != nil {
+				errCh <- err
 			}
 		}(n)
 	}
@@ -667,6 +913,77 @@ func (m *Manager) push(ctx context.Context) error {
 	return nil
 }
 
+// Validate validates the nodes of the topology without creating them: the
+// host constraints and the pod patches of all nodes.
+func (m *Manager) Validate() error {
+	for _, n := range m.nodes {
+		if err := n.ValidateConstraints(); err != nil {
+			return fmt.Errorf("failed to validate node %s: %w", n, err)
+		}
+	}
+	return m.validatePodPatches()
+}
+
+// validatePodPatches validates the pod patches of all nodes, so that an
+// invalid patch fails the topology before any node is created.
+func (m *Manager) validatePodPatches() error {
+	for _, n := range m.nodes {
+		v, ok := n.(node.PodPatchValidator)
+		if !ok {
+			continue
+		}
+		if err := v.ValidatePodPatches(); err != nil {
+			return fmt.Errorf("failed to validate pod patches of node %s: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// createNode creates the resources of the node and generates its
+// self-signed certificates.
+func (m *Manager) createNode(ctx context.Context, n node.Node) error {
+	for key, service := range n.GetProto().Services {
+		updateServicePortName(service, key)
+	}
+
+	if err := n.Create(ctx); err != nil {
+		return fmt.Errorf("failed to create node %s: %w", n, err)
+	}
+	log.Infof("Node %s resource created", n)
+
+	log.Infof("Generating Self-Signed Certificates for node %s", n)
+
+	err := m.GenerateSelfSigned(ctx, n.Name())
+	switch {
+	case err == nil, status.Code(err) == codes.Unimplemented:
+		return nil
+	default:
+		return fmt.Errorf("failed to generate cert for node %s: %w", n, err)
+	}
+}
+
+// createNamespace creates the namespace of the topology if it does not exist.
+func (m *Manager) createNamespace(ctx context.Context) error {
+	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.topo.Name, metav1.GetOptions{}); err == nil {
+		return nil
+	}
+	log.Infof("Creating namespace for topology: %q", m.topo.Name)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.topo.Name,
+			Labels: map[string]string{
+				TopologyLabel: "true",
+			},
+		},
+	}
+	sNs, err := m.kClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create namespace %q: %w", ns, err)
+	}
+	log.Infof("Server Namespace: %+v", sNs)
+	return nil
+}
+
 func updateServicePortName(s *tpb.Service, port uint32) {
 	i := 0
 	for _, name := range s.Names {
@@ -731,6 +1048,18 @@ func (m *Manager) deleteMeshnetTopologies(ctx context.Context) error {
 	return errs.Err()
 }
 
+// reportNodeStatuses reports the status of the nodes whose state or reason
+// changed since they were last reported.
+func (m *Manager) reportNodeStatuses(ctx context.Context, reported map[string]*cpb.NodeStatus) {
+	for _, s := range m.NodeStatuses(ctx) {
+		if r := reported[s.GetName()]; r != nil && r.GetState() == s.GetState() && r.GetReason() == s.GetReason() && r.GetMessage() == s.GetMessage() {
+			continue
+		}
+		reported[s.GetName()] = s
+		m.progressFunc.Node(s)
+	}
+}
+
 // checkNodeStatus reports node status, ignores for unimplemented nodes.
 func (m *Manager) checkNodeStatus(ctx context.Context, timeout time.Duration) error {
 	foundAll := false
@@ -743,6 +1072,7 @@ func (m *Manager) checkNodeStatus(ctx context.Context, timeout time.Duration) er
 		err   error
 	}
 
+	reported := map[string]*cpb.NodeStatus{}
 	// Check until end state or timeout sec expired
 	start := time.Now()
 	for (timeout == 0 || time.Since(start) < timeout) && !foundAll {
@@ -763,6 +1093,9 @@ func (m *Manager) checkNodeStatus(ctx context.Context, timeout time.Duration) er
 		}
 		wg.Wait()
 		close(resCh)
+		if m.progressFunc != nil {
+			m.reportNodeStatuses(ctx, reported)
+		}
 
 		for res := range resCh {
 			if res.err != nil || res.phase == node.StatusFailed {
@@ -786,10 +1119,22 @@ func (m *Manager) checkNodeStatus(ctx context.Context, timeout time.Duration) er
 }
 
 type Resources struct {
-	Services   map[string][]*corev1.Service
-	Pods       map[string][]*corev1.Pod
-	ConfigMaps map[string]*corev1.ConfigMap
-	Topologies map[string]*topologyv1.Topology
+	Services     map[string][]*corev1.Service
+	Pods         map[string][]*corev1.Pod
+	ConfigMaps   map[string]*corev1.ConfigMap
+	Topologies   map[string]*topologyv1.Topology
+	NodeStatuses []*cpb.NodeStatus
+
+	details map[string]*node.StatusDetails
+}
+
+// GetNodeStatuses returns the status of the nodes of the topology, sorted by
+// name.
+func (r *Resources) GetNodeStatuses() []*cpb.NodeStatus {
+	if r == nil {
+		return nil
+	}
+	return r.NodeStatuses
 }
 
 // Resources gets the currently configured resources from the topology.
@@ -845,6 +1190,8 @@ func (m *Manager) Resources(ctx context.Context) (*Resources, error) {
 	for _, t := range tList {
 		r.Topologies[t.Name] = t
 	}
+	r.details = m.statusDetails(ctx)
+	r.NodeStatuses = nodeStatuses(r.details)
 
 	return &r, nil
 }
@@ -892,7 +1239,8 @@ func (m *Manager) ResetCfg(ctx context.Context, nodeName string) error {
 	return r.ResetCfg(ctx)
 }
 
-// GenerateSelfSigned will create self signed certs on the provided node.
+// GenerateSelfSigned will create self signed certs on the provided node, or
+// install a cert issued by the topology CA if the node requests one.
 // If the node does not have cert info then it is a noop. If the node does
 // not fulfill Certer then status.Unimplemented error will be returned.
 func (m *Manager) GenerateSelfSigned(ctx context.Context, nodeName string) error {
@@ -904,6 +1252,9 @@ func (m *Manager) GenerateSelfSigned(ctx context.Context, nodeName string) error
 		log.V(1).Infof("No cert info for %q, skipping cert generation", nodeName)
 		return nil
 	}
+	if n.GetProto().GetConfig().GetCert().GetCaIssued() != nil {
+		return m.IssueCert(ctx, nodeName)
+	}
 	c, ok := n.(node.Certer)
 	if !ok {
 		return status.Errorf(codes.Unimplemented, "node %q does not implement Certer interface", nodeName)
@@ -911,12 +1262,72 @@ func (m *Manager) GenerateSelfSigned(ctx context.Context, nodeName string) error
 	return c.GenerateSelfSigned(ctx)
 }
 
+// Exec runs cmd in the container of the provided node. If the node does
+// not fulfill Execer then status.Unimplemented error will be returned.
+func (m *Manager) Exec(ctx context.Context, nodeName string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	e, ok := n.(node.Execer)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "node %q does not implement Execer interface", nodeName)
+	}
+	return e.Exec(ctx, cmd, stdin, stdout, stderr)
+}
+
+// CLI starts the vendor native CLI on the provided node and attaches the
+// provided streams to it. If the node does not fulfill CLIer then
+// status.Unimplemented error will be returned.
+func (m *Manager) CLI(ctx context.Context, nodeName string, stdin io.Reader, stdout, stderr io.Writer) error {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	c, ok := n.(node.CLIer)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "node %q does not implement CLIer interface", nodeName)
+	}
+	return m.Exec(ctx, nodeName, c.CLICommand(), stdin, stdout, stderr)
+}
+
+// RunCommands runs cmds on the vendor CLI of the provided node. If the node
+// does not fulfill Commander then status.Unimplemented error will be
+// returned. Vendor CLI connections retry until the node is reachable, so
+// ctx should carry a deadline to bound the time spent on unreachable nodes.
+func (m *Manager) RunCommands(ctx context.Context, nodeName string, cmds []string) ([]*node.CommandResult, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+	c, ok := n.(node.Commander)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "node %q does not implement Commander interface", nodeName)
+	}
+	results, err := c.RunCommands(ctx, cmds)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("node %q: %w", nodeName, ctx.Err())
+	}
+	return results, err
+}
+
+// RenderConfig returns the startup config of the provided node, rendered if
+// it is a template. nil is returned if the node has no startup config.
+func (m *Manager) RenderConfig(nodeName string) ([]byte, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+	return node.ReadConfig(m.basePath, n.GetProto(), m.topo)
+}
+
 // populateServiceMap modifies m to contain the full service info.
 var populateServiceMap = func(s *corev1.Service, m map[uint32]*tpb.Service) error {
 	if s == nil || m == nil {
 		return fmt.Errorf("service and map must not be nil")
 	}
-	if len(s.Status.LoadBalancer.Ingress) == 0 {
+	lb := s.Spec.Type != corev1.ServiceTypeClusterIP && s.Spec.Type != corev1.ServiceTypeNodePort
+	if lb && len(s.Status.LoadBalancer.Ingress) == 0 {
 		return fmt.Errorf("service %s has no external loadbalancer configured", s.Name)
 	}
 	for _, p := range s.Spec.Ports {
@@ -924,7 +1335,8 @@ var populateServiceMap = func(s *corev1.Service, m map[uint32]*tpb.Service) erro
 		service, ok := m[k]
 		if !ok {
 			service = &tpb.Service{
-				Name: p.Name,
+				Name:     p.Name,
+				Protocol: tpb.Protocol(tpb.Protocol_value[string(p.Protocol)]),
 			}
 			m[k] = service
 		}
@@ -935,7 +1347,9 @@ var populateServiceMap = func(s *corev1.Service, m map[uint32]*tpb.Service) erro
 		service.Inside = uint32(p.TargetPort.IntVal)
 		service.NodePort = uint32(p.NodePort)
 		service.InsideIp = s.Spec.ClusterIP
-		service.OutsideIp = s.Status.LoadBalancer.Ingress[0].IP
+		if lb {
+			service.OutsideIp = s.Status.LoadBalancer.Ingress[0].IP
+		}
 	}
 	return nil
 }
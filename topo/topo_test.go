@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -158,6 +159,48 @@ func (nc *notCertable) GetProto() *tpb.Node {
 	return nc.proto
 }
 
+type execable struct {
+	*node.Impl
+	eErr string
+}
+
+func (e *execable) Exec(_ context.Context, cmd []string, _ io.Reader, stdout, _ io.Writer) error {
+	if e.eErr != "" {
+		return fmt.Errorf("%s", e.eErr)
+	}
+	_, err := fmt.Fprint(stdout, strings.Join(cmd, " "))
+	return err
+}
+
+type clier struct {
+	execable
+}
+
+func (c *clier) CLICommand() []string {
+	return []string{"cli", "-d"}
+}
+
+type commander struct {
+	*node.Impl
+	cErr  string
+	block bool
+}
+
+func (c *commander) RunCommands(ctx context.Context, cmds []string) ([]*node.CommandResult, error) {
+	if c.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if c.cErr != "" {
+		return nil, fmt.Errorf("%s", c.cErr)
+	}
+	var results []*node.CommandResult
+	for _, cmd := range cmds {
+		results = append(results, &node.CommandResult{Command: cmd, Output: "output of " + cmd})
+	}
+	return results, nil
+}
+
 func TestNew(t *testing.T) {
 	node.Vendor(tpb.Vendor(1001), NewConfigurable)
 	node.Vendor(tpb.Vendor(1006), NewLoopbackable)
@@ -658,6 +701,21 @@ func TestCreate(t *testing.T) {
 			}},
 		},
 		wantErr: "failed to validate node",
+	}, {
+		desc: "failed pod patch validation",
+		topo: &tpb.Topology{
+			Name: "test",
+			Nodes: []*tpb.Node{{
+				Name:   "r1",
+				Vendor: tpb.Vendor(1002),
+				Config: &tpb.Config{
+					PodPatches: []*tpb.PodPatch{{
+						Source: &tpb.PodPatch_Patch{Patch: "spec:\n  hostNetwrok: true\n"},
+					}},
+				},
+			}},
+		},
+		wantErr: "failed to validate pod patches of node",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -702,6 +760,55 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	node.Vendor(tpb.Vendor(1013), NewConfigurable)
+	patched := func(p string) *tpb.Node {
+		return &tpb.Node{
+			Name:   "r1",
+			Vendor: tpb.Vendor(1013),
+			Config: &tpb.Config{PodPatches: []*tpb.PodPatch{{Source: &tpb.PodPatch_Patch{Patch: p}}}},
+		}
+	}
+	tests := []struct {
+		desc    string
+		node    *tpb.Node
+		wantErr string
+	}{{
+		desc: "valid patch",
+		node: patched("spec:\n  hostNetwork: true\n"),
+	}, {
+		desc:    "invalid patch",
+		node:    patched("spec:\n  hostNetwrok: true\n"),
+		wantErr: `unknown field "hostNetwrok"`,
+	}, {
+		desc: "host constraints",
+		node: &tpb.Node{
+			Name:            "r1",
+			Vendor:          tpb.Vendor(1013),
+			Config:          &tpb.Config{},
+			HostConstraints: []*tpb.HostConstraint{{Constraint: &tpb.HostConstraint_KernelConstraint{}}},
+		},
+		wantErr: "failed to validate node",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tf, err := tfake.NewSimpleClientset()
+			if err != nil {
+				t.Fatalf("cannot create fake topology clientset: %v", err)
+			}
+			kf := kfake.NewSimpleClientset()
+			m, err := New(&tpb.Topology{Name: "test", Nodes: []*tpb.Node{tt.node}}, WithClusterConfig(&rest.Config{}), WithKubeClient(kf), WithTopoClient(tf))
+			if err != nil {
+				t.Fatalf("New() failed to create new topology manager: %v", err)
+			}
+			err = m.Validate()
+			if s := errdiff.Substring(err, tt.wantErr); s != "" {
+				t.Fatalf("Validate() unexpected error: %s", s)
+			}
+		})
+	}
+}
+
 type fakeWatch struct {
 	ch   chan watch.Event
 	done chan struct{}
@@ -1071,8 +1178,9 @@ func TestShow(t *testing.T) {
 				},
 			},
 			{
-				Name:   "r3",
-				Vendor: tpb.Vendor(1004),
+				Name:        "r3",
+				Vendor:      tpb.Vendor(1004),
+				Credentials: &tpb.Credentials{Username: "admin", Password: "secret"},
 			},
 		},
 	}
@@ -1096,6 +1204,7 @@ func TestShow(t *testing.T) {
 	wantTopo.Nodes[1].Services[9339].OutsideIp = "192.168.16.51"
 	wantTopo.Nodes[1].Services[9339].NodePort = 20003
 	wantTopo.Nodes[2].PodIp = "10.0.1.3"
+	wantTopo.Nodes[2].Credentials.Password = ""
 
 	topoRemapPorts := proto.Clone(wantTopo).(*tpb.Topology)
 	topoRemapPorts.Nodes[1].Services[9337].Inside = 9339
@@ -1104,6 +1213,14 @@ func TestShow(t *testing.T) {
 	wantTopoPodUnhealthy := proto.Clone(wantTopo).(*tpb.Topology)
 	wantTopoPodUnhealthy.Nodes[0].PodIp = ""
 
+	nodeStatuses := func(r1 cpb.NodeState) []*cpb.NodeStatus {
+		return []*cpb.NodeStatus{
+			{Name: "r1", State: r1},
+			{Name: "r2", State: cpb.NodeState_NODE_STATE_RUNNING},
+			{Name: "r3", State: cpb.NodeState_NODE_STATE_RUNNING},
+		}
+	}
+
 	tests := []struct {
 		desc       string
 		k8sObjects []runtime.Object
@@ -1206,8 +1323,9 @@ func TestShow(t *testing.T) {
 			},
 		},
 		want: &cpb.ShowTopologyResponse{
-			State:    cpb.TopologyState_TOPOLOGY_STATE_RUNNING,
-			Topology: wantTopo,
+			State:        cpb.TopologyState_TOPOLOGY_STATE_RUNNING,
+			Topology:     wantTopo,
+			NodeStatuses: nodeStatuses(cpb.NodeState_NODE_STATE_RUNNING),
 		},
 	}, {
 		desc: "success with remapped ports",
@@ -1306,8 +1424,9 @@ func TestShow(t *testing.T) {
 			},
 		},
 		want: &cpb.ShowTopologyResponse{
-			State:    cpb.TopologyState_TOPOLOGY_STATE_RUNNING,
-			Topology: wantTopoRemapPorts,
+			State:        cpb.TopologyState_TOPOLOGY_STATE_RUNNING,
+			Topology:     wantTopoRemapPorts,
+			NodeStatuses: nodeStatuses(cpb.NodeState_NODE_STATE_RUNNING),
 		},
 	}, {
 		desc: "no pods",
@@ -1464,8 +1583,9 @@ func TestShow(t *testing.T) {
 			},
 		},
 		want: &cpb.ShowTopologyResponse{
-			State:    cpb.TopologyState_TOPOLOGY_STATE_CREATING,
-			Topology: wantTopoPodUnhealthy,
+			State:        cpb.TopologyState_TOPOLOGY_STATE_CREATING,
+			Topology:     wantTopoPodUnhealthy,
+			NodeStatuses: nodeStatuses(cpb.NodeState_NODE_STATE_PENDING),
 		},
 	}, {
 		desc: "success - unhealthy",
@@ -1560,8 +1680,9 @@ func TestShow(t *testing.T) {
 			},
 		},
 		want: &cpb.ShowTopologyResponse{
-			State:    cpb.TopologyState_TOPOLOGY_STATE_ERROR,
-			Topology: wantTopoPodUnhealthy,
+			State:        cpb.TopologyState_TOPOLOGY_STATE_ERROR,
+			Topology:     wantTopoPodUnhealthy,
+			NodeStatuses: nodeStatuses(cpb.NodeState_NODE_STATE_FAILED),
 		},
 	}}
 	for _, tt := range tests {
@@ -1594,6 +1715,53 @@ func TestShow(t *testing.T) {
 	}
 }
 
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+	node.Vendor(tpb.Vendor(1011), NewConfigurable)
+	topo := &tpb.Topology{
+		Name: "test",
+		Nodes: []*tpb.Node{{
+			Name:        "r1",
+			Vendor:      tpb.Vendor(1011),
+			Credentials: &tpb.Credentials{Username: "admin", Password: "inline"},
+		}, {
+			Name:        "r2",
+			Vendor:      tpb.Vendor(1011),
+			Credentials: &tpb.Credentials{Source: &tpb.Credentials_Secret{Secret: "lab-admin"}},
+		}, {
+			Name:        "r3",
+			Vendor:      tpb.Vendor(1011),
+			Credentials: &tpb.Credentials{Source: &tpb.Credentials_Secret{Secret: "missing"}},
+		}, {
+			Name:   "r4",
+			Vendor: tpb.Vendor(1011),
+		}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "lab-admin", Namespace: "test"},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("root"),
+			corev1.BasicAuthPasswordKey: []byte("fromsecret"),
+		},
+	}
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	m, err := New(topo, WithClusterConfig(&rest.Config{}), WithKubeClient(kfake.NewSimpleClientset(secret)), WithTopoClient(tf))
+	if err != nil {
+		t.Fatalf("New() failed to create new topology manager: %v", err)
+	}
+	want := map[string]*tpb.Credentials{
+		"r1": {Username: "admin", Password: "inline"},
+		"r2": {Username: "root", Password: "fromsecret"},
+	}
+	if s := cmp.Diff(want, m.Credentials(ctx), protocmp.Transform()); s != "" {
+		t.Errorf("Credentials() unexpected diff (-want +got):\n%s", s)
+	}
+}
+
 func TestResources(t *testing.T) {
 	ctx := context.Background()
 	node.Vendor(tpb.Vendor(1005), NewConfigurable)
@@ -1712,6 +1880,10 @@ func TestResources(t *testing.T) {
 					},
 				},
 			},
+			NodeStatuses: []*cpb.NodeStatus{
+				{Name: "r1", State: cpb.NodeState_NODE_STATE_PENDING},
+				{Name: "r2", State: cpb.NodeState_NODE_STATE_PENDING},
+			},
 		},
 	}, {
 		desc: "no pods",
@@ -1780,13 +1952,150 @@ func TestResources(t *testing.T) {
 			if s := errdiff.Check(err, tt.wantErr); s != "" {
 				t.Errorf("Resources() unexpected err: %s", s)
 			}
-			if s := cmp.Diff(tt.want, got); s != "" {
+			if s := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(Resources{}), protocmp.Transform()); s != "" {
 				t.Errorf("Resources() unexpected diff (-want +got):\n%s", s)
 			}
 		})
 	}
 }
 
+func TestMirrors(t *testing.T) {
+	node.Vendor(tpb.Vendor(1007), NewConfigurable)
+	tf, err := tfake.NewSimpleClientset()
+	if err != nil {
+		t.Fatalf("cannot create fake topology clientset: %v", err)
+	}
+	opts := []Option{
+		WithClusterConfig(&rest.Config{}),
+		WithKubeClient(kfake.NewSimpleClientset()),
+		WithTopoClient(tf),
+	}
+	newTopo := func(mirrors ...*tpb.Mirror) *tpb.Topology {
+		return &tpb.Topology{
+			Name: "test",
+			Nodes: []*tpb.Node{
+				{Name: "r1", Vendor: tpb.Vendor(1007)},
+				{Name: "r2", Vendor: tpb.Vendor(1007)},
+				{Name: "r3", Vendor: tpb.Vendor(1007)},
+			},
+			Links: []*tpb.Link{
+				{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1"},
+				{ANode: "r1", AInt: "eth2", ZNode: "r2", ZInt: "eth2"},
+			},
+			Mirrors: mirrors,
+		}
+	}
+	tests := []struct {
+		desc     string
+		topo     *tpb.Topology
+		wantSpec map[string]topologyv1.TopologySpec
+		wantErr  string
+	}{{
+		desc: "success",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth1"}, {Node: "r1", Int: "eth2"}},
+			Direction:   tpb.Mirror_INGRESS,
+			Destination: &tpb.Endpoint{Node: "r3", Int: "eth1"},
+		}),
+		wantSpec: map[string]topologyv1.TopologySpec{
+			"r1": {
+				Links: []topologyv1.Link{
+					{UID: 0, LocalIntf: "eth1", PeerIntf: "eth1", PeerPod: "r2"},
+					{UID: 1, LocalIntf: "eth2", PeerIntf: "eth2", PeerPod: "r2"},
+					{UID: 2, LocalIntf: "mirror0", PeerIntf: "eth1", PeerPod: "r3"},
+				},
+				Mirrors: []topologyv1.Mirror{{
+					Name:      "ids",
+					SrcIntfs:  []string{"eth1", "eth2"},
+					Direction: topologyv1.MirrorIngress,
+					DstIntf:   "mirror0",
+				}},
+			},
+			"r2": {
+				Links: []topologyv1.Link{
+					{UID: 0, LocalIntf: "eth1", PeerIntf: "eth1", PeerPod: "r1"},
+					{UID: 1, LocalIntf: "eth2", PeerIntf: "eth2", PeerPod: "r1"},
+				},
+			},
+			"r3": {
+				Links: []topologyv1.Link{
+					{UID: 2, LocalIntf: "eth1", PeerIntf: "mirror0", PeerPod: "r1"},
+				},
+			},
+		},
+	}, {
+		desc: "no sources",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Destination: &tpb.Endpoint{Node: "r3", Int: "eth1"},
+		}),
+		wantErr: "no sources",
+	}, {
+		desc: "sources on different nodes",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth1"}, {Node: "r2", Int: "eth2"}},
+			Destination: &tpb.Endpoint{Node: "r3", Int: "eth1"},
+		}),
+		wantErr: "sources must be on the same node",
+	}, {
+		desc: "source not connected",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth3"}},
+			Destination: &tpb.Endpoint{Node: "r3", Int: "eth1"},
+		}),
+		wantErr: "is not connected",
+	}, {
+		desc: "missing destination node",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth1"}},
+			Destination: &tpb.Endpoint{Node: "r4", Int: "eth1"},
+		}),
+		wantErr: "missing node",
+	}, {
+		desc: "destination on source node",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth1"}},
+			Destination: &tpb.Endpoint{Node: "r1", Int: "eth3"},
+		}),
+		wantErr: "must not be on the source node",
+	}, {
+		desc: "destination already connected",
+		topo: newTopo(&tpb.Mirror{
+			Name:        "ids",
+			Sources:     []*tpb.Endpoint{{Node: "r1", Int: "eth1"}},
+			Destination: &tpb.Endpoint{Node: "r2", Int: "eth2"},
+		}),
+		wantErr: "already connected",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m, err := New(tt.topo, opts...)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("New() unexpected error: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			specs, err := m.topologySpecs(context.Background())
+			if err != nil {
+				t.Fatalf("topologySpecs() failed: %v", err)
+			}
+			got := map[string]topologyv1.TopologySpec{}
+			for _, s := range specs {
+				got[s.Name] = s.Spec
+			}
+			if s := cmp.Diff(tt.wantSpec, got, cmpopts.SortSlices(func(a, b topologyv1.Link) bool { return a.UID < b.UID })); s != "" {
+				t.Errorf("topologySpecs() unexpected specs (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
 func TestNodes(t *testing.T) {
 	aNode := &configurable{}
 	bNode := &configurable{}
@@ -1893,6 +2202,146 @@ func TestResetCfg(t *testing.T) {
 	}
 }
 
+func TestExec(t *testing.T) {
+	m := &Manager{
+		nodes: map[string]node.Node{
+			"execable":     &execable{},
+			"execable_err": &execable{eErr: "failed to exec"},
+		},
+	}
+	tests := []struct {
+		desc    string
+		name    string
+		cmd     []string
+		want    string
+		wantErr string
+	}{{
+		desc: "execable",
+		name: "execable",
+		cmd:  []string{"ls", "-l"},
+		want: "ls -l",
+	}, {
+		desc:    "execable failure",
+		name:    "execable_err",
+		cmd:     []string{"ls"},
+		wantErr: "failed to exec",
+	}, {
+		desc:    "node not found",
+		name:    "nonexistent",
+		cmd:     []string{"ls"},
+		wantErr: "not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := m.Exec(context.Background(), tt.name, tt.cmd, nil, &stdout, io.Discard)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("Exec() unexpected error: %s", s)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("Exec() got output %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLI(t *testing.T) {
+	m := &Manager{
+		nodes: map[string]node.Node{
+			"clier":     &clier{},
+			"clier_err": &clier{execable: execable{eErr: "failed to exec"}},
+			"not_clier": &execable{},
+		},
+	}
+	tests := []struct {
+		desc    string
+		name    string
+		want    string
+		wantErr string
+	}{{
+		desc: "clier",
+		name: "clier",
+		want: "cli -d",
+	}, {
+		desc:    "clier failure",
+		name:    "clier_err",
+		wantErr: "failed to exec",
+	}, {
+		desc:    "not clier",
+		name:    "not_clier",
+		wantErr: "does not implement CLIer interface",
+	}, {
+		desc:    "node not found",
+		name:    "nonexistent",
+		wantErr: "not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := m.CLI(context.Background(), tt.name, nil, &stdout, io.Discard)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("CLI() unexpected error: %s", s)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("CLI() got output %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCommands(t *testing.T) {
+	m := &Manager{
+		nodes: map[string]node.Node{
+			"commander":     &commander{},
+			"commander_err": &commander{cErr: "failed to connect"},
+			"unreachable":   &commander{block: true},
+			"not_commander": &execable{},
+		},
+	}
+	tests := []struct {
+		desc    string
+		name    string
+		want    []*node.CommandResult
+		wantErr string
+	}{{
+		desc: "commander",
+		name: "commander",
+		want: []*node.CommandResult{
+			{Command: "show version", Output: "output of show version"},
+			{Command: "show ip route", Output: "output of show ip route"},
+		},
+	}, {
+		desc:    "commander failure",
+		name:    "commander_err",
+		wantErr: "failed to connect",
+	}, {
+		desc:    "unreachable",
+		name:    "unreachable",
+		wantErr: "deadline exceeded",
+	}, {
+		desc:    "not commander",
+		name:    "not_commander",
+		wantErr: "does not implement Commander interface",
+	}, {
+		desc:    "node not found",
+		name:    "nonexistent",
+		wantErr: "not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			got, err := m.RunCommands(ctx, tt.name, []string{"show version", "show ip route"})
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("RunCommands() unexpected error: %s", s)
+			}
+			if s := cmp.Diff(tt.want, got); s != "" {
+				t.Errorf("RunCommands() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
 func TestGenerateSelfSigned(t *testing.T) {
 	m := &Manager{
 		nodes: map[string]node.Node{
@@ -2108,6 +2557,77 @@ func TestUpdateServicePortName(t *testing.T) {
 	}
 }
 
+func TestPopulateServiceMap(t *testing.T) {
+	tests := []struct {
+		desc    string
+		svc     *corev1.Service
+		want    map[uint32]*tpb.Service
+		wantErr string
+	}{{
+		desc: "load balancer",
+		svc: &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeLoadBalancer,
+				ClusterIP: "10.1.1.1",
+				Ports: []corev1.ServicePort{{
+					Name:       "ssh",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       22,
+					TargetPort: intstr.FromInt(22),
+				}},
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "192.168.16.50"}},
+				},
+			},
+		},
+		want: map[uint32]*tpb.Service{
+			22: {Name: "ssh", Inside: 22, Outside: 22, InsideIp: "10.1.1.1", OutsideIp: "192.168.16.50"},
+		},
+	}, {
+		desc: "load balancer pending",
+		svc: &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "service-r1"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		wantErr: "service service-r1 has no external loadbalancer configured",
+	}, {
+		desc: "node port",
+		svc: &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeNodePort,
+				ClusterIP: "10.1.1.1",
+				Ports: []corev1.ServicePort{{
+					Name:       "syslog",
+					Protocol:   corev1.ProtocolUDP,
+					Port:       514,
+					TargetPort: intstr.FromInt(514),
+					NodePort:   30514,
+				}},
+			},
+		},
+		want: map[uint32]*tpb.Service{
+			514: {Name: "syslog", Inside: 514, Outside: 514, InsideIp: "10.1.1.1", NodePort: 30514, Protocol: tpb.Protocol_UDP},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := map[uint32]*tpb.Service{}
+			err := populateServiceMap(tt.svc, got)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("populateServiceMap() failed: %s", s)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if s := cmp.Diff(tt.want, got, protocmp.Transform()); s != "" {
+				t.Errorf("populateServiceMap() unexpected diff (-want +got):\n%s", s)
+			}
+		})
+	}
+}
+
 func TestOptions(t *testing.T) {
 	m := &Manager{}
 	WithKubecfg("kubeconfig")(m)